@@ -11,23 +11,64 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"tmcli/tmutil"
 	"tmcli/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	// tmcli config reports which global flags set what.
+	given := slices.Clone(os.Args[1:])
+	os.Args = logFlag(os.Args)
+	os.Args = compactFlag(os.Args)
+	os.Args = sortFlag(os.Args)
+	os.Args = asciiFlag(os.Args)
+	os.Args = redactFlag(os.Args)
+	os.Args = hostFlag(os.Args)
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		tmutil.SetOutputWidth(cols)
+	}
+	if err := ui.LoadLocale(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: translations disabled: %v\n", err)
+	}
+	if err := ui.ApplyConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring config: %v\n", err)
+	}
+	// Every path below may run tmutil, ssh or mount, each in a process
+	// group of its own that the terminal's ctrl+c does not reach.
+	ctx, stop := cancelOnInterrupt()
+	defer stop()
 	if len(os.Args) < 2 {
+		if tmutil.RemoteHost() != "" {
+			fmt.Fprintf(os.Stderr, "Error: --host needs a command; it works with %s\n", strings.Join(remoteVerbs(), ", "))
+			os.Exit(1)
+		}
 		runTUI()
 		return
 	}
 
 	verb := os.Args[1]
 	args := os.Args[2:]
+	if host := tmutil.RemoteHost(); host != "" && !slices.Contains(remoteVerbs(), verb) {
+		if cmd, _ := resolveCommand(verb); cmd == nil || !cmd.ReadOnly || !cmd.Remote {
+			fmt.Fprintf(os.Stderr, "Error: %s cannot run on %s: --host works with %s\n", verb, host, strings.Join(remoteVerbs(), ", "))
+			os.Exit(1)
+		}
+	}
 
 	switch verb {
 	case "--version", "-version", "-v", "version":
@@ -36,45 +77,713 @@ func main() {
 		fmt.Println("Licensed under the MIT License.")
 		return
 	case "--help", "-help", "-h", "help":
+		if slices.Contains(args, "--json") {
+			// Descriptions hold <placeholders>, which must stay readable.
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(ui.HelpCatalog()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 		printUsage()
 	case "tui":
-		runTUI()
+		runTUIAt(args)
+	case "completion":
+		shell := ""
+		if len(args) > 0 {
+			shell = args[0]
+		}
+		script, err := ui.CompletionScript(shell)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(script)
+	case "__complete":
+		// Called by the completion scripts: __complete <command> <arg index>
+		if len(args) < 2 {
+			return
+		}
+		idx, err := strconv.Atoi(args[1])
+		if err != nil {
+			return
+		}
+		for _, c := range ui.CompletionCandidates(args[0], idx) {
+			fmt.Println(c)
+		}
+	case "saved":
+		runCLI(ui.SavedCLI, args)
 	case "monitor":
-		runMonitor()
+		runMonitor(ctx, args)
+	case "doctor":
+		runDoctor(ctx)
+	case "check":
+		runCheck(ctx, args)
+	case "config":
+		runConfig(args, given)
+	case "serve":
+		runServe(ctx, args)
+	case "raw":
+		fmt.Fprintln(os.Stderr, "tmcli raw is unsupported: arguments go to tmutil unchecked.")
+		runCLI(tmutil.Raw, args)
 	default:
-		cmd := ui.FindCommand(verb)
+		cmd, candidates := resolveCommand(verb)
+		if len(candidates) > 1 {
+			fmt.Fprintf(os.Stderr, "Ambiguous command: %s could be %s\n", verb, strings.Join(candidates, ", "))
+			os.Exit(1)
+		}
 		if cmd == nil {
 			fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", verb)
 			printUsage()
 			os.Exit(1)
 		}
+		verb = cmd.ID
+		if cmd.ReadOnly {
+			// Nothing would change, so a dry run is the real run.
+			args = slices.DeleteFunc(args, func(a string) bool { return a == "--dry-run" })
+		}
 		if cmd.IsMonitor {
-			runMonitor()
+			// Check the deadline before startmonitor starts a backup.
+			if _, err := deadlineFlag(args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if cmd.Execute != nil {
+				output, err := cmd.Execute(args)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", tmutil.Redact(err.Error()))
+					os.Exit(1)
+				}
+				fmt.Println(tmutil.Redact(output))
+			}
+			runMonitor(ctx, args)
+			return
+		}
+		if verb == "start" && slices.Contains(args, "--follow") {
+			runFollow(ctx)
+			return
+		}
+		if cmd.IsDeleter {
+			runDeleter()
+			return
+		}
+		// A backup disk that is simply unplugged otherwise looks like a
+		// destination without backups.
+		if cmd.WhenUnmounted != "" {
+			if warning := tmutil.UnmountedWarning(); warning != "" {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+			}
+		}
+		if cmd.IsBrowser {
+			runBrowser()
+			return
+		}
+		interval, rest, watch, err := watchFlag(args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if watch {
+			// Re-running something that changes Time Machine would repeat
+			// the change every interval.
+			if !cmd.ReadOnly {
+				fmt.Fprintf(os.Stderr, "Error: --watch only re-runs read-only commands; %s changes Time Machine\n", cmd.ID)
+				os.Exit(1)
+			}
+			runWatch(ctx, verb, cmd.Execute, rest, interval, cmd.Timeout)
+			return
+		}
+		if cmd.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+			defer cancel()
+		}
+		tmutil.SetContext(ctx)
+		// Status-only progress is for the TUI; it would mix into output
+		// that scripts parse, such as findfile --json.
+		if cmd.Stream != nil && !cmd.StreamStatus {
+			runCLI(func(a []string) (string, error) {
+				output, err := cmd.Stream(a, func(line string) { fmt.Println(tmutil.Redact(line)) })
+				var inc *tmutil.IncompleteError
+				if errors.As(err, &inc) {
+					inc.Output = "" // printed already, line by line
+				}
+				return output, err
+			}, args)
 			return
 		}
 		runCLI(cmd.Execute, args)
 	}
 }
 
+// resolveCommand finds the command named by verb: an exact ID or alias, or
+// else the one command whose ID or alias starts with verb. When several
+// commands share the prefix it returns their IDs instead.
+func resolveCommand(verb string) (*ui.Command, []string) {
+	if cmd := ui.FindCommand(verb); cmd != nil || verb == "" {
+		return cmd, nil
+	}
+	var ids []string
+	for _, cmd := range ui.AllCommands() {
+		for _, name := range append([]string{cmd.ID}, cmd.Aliases...) {
+			if strings.HasPrefix(name, verb) {
+				ids = append(ids, cmd.ID)
+				break
+			}
+		}
+	}
+	if len(ids) != 1 {
+		return nil, ids
+	}
+	return ui.FindCommand(ids[0]), nil
+}
+
+// cancelOnInterrupt makes ctrl+c and SIGTERM cancel the commands being
+// run, which kills the tmutil they started: tmutil runs in a process group
+// of its own, out of reach of the terminal's ctrl+c. The TUI derives each
+// command's context from this one. runCLI exits without calling stop.
+func cancelOnInterrupt() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	tmutil.SetBaseContext(ctx)
+	return ctx, stop
+}
+
+// exitIfInterrupted exits 130, as a shell reports ctrl+c, once ctx is
+// cancelled, rather than report on checks that were cut short.
+func exitIfInterrupted(ctx context.Context) {
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "Interrupted.")
+		os.Exit(130)
+	}
+}
+
 func runCLI(fn func([]string) (string, error), args []string) {
 	output, err := fn(args)
+	if err != nil {
+		// A command stopped partway keeps what it printed, marked as such.
+		if partial, ok := tmutil.PartialOutput(err); ok {
+			if partial != "" {
+				fmt.Println(tmutil.Redact(partial))
+			}
+			fmt.Fprintln(os.Stderr, "Incomplete: the command stopped before it finished, so the output above is partial.")
+		}
+		fmt.Fprintf(os.Stderr, "Error: %s\n", tmutil.Redact(err.Error()))
+		if hint := ui.Remediation(err); hint != "" {
+			fmt.Fprintf(os.Stderr, "Suggestion: %s\n", hint)
+		}
+		// Pass tmutil's own exit status through so scripts can tell
+		// failures apart, and exit as ssh does when the remote host could
+		// not be reached; anything else exits 1.
+		if code, ok := tmutil.ExitCode(err); ok {
+			os.Exit(code)
+		}
+		var sshErr *tmutil.SSHError
+		if errors.As(err, &sshErr) {
+			os.Exit(255)
+		}
+		// Interrupted with ctrl+c: exit as a shell reports SIGINT.
+		if errors.Is(err, tmutil.ErrCancelled) && !errors.Is(err, tmutil.ErrTimeout) {
+			os.Exit(130)
+		}
+		os.Exit(1)
+	}
+	fmt.Println(tmutil.Redact(output))
+}
+
+// logFlag removes a global --log flag from args and, if it was given or
+// TMCLI_LOG is set, enables the command log.
+func logFlag(args []string) []string {
+	enable := os.Getenv("TMCLI_LOG") != ""
+	rest := []string{args[0]}
+	for _, a := range args[1:] {
+		if a == "--log" {
+			enable = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	if enable {
+		if _, err := ui.EnableCommandLog(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: command log disabled: %v\n", err)
+		}
+	}
+	return rest
+}
+
+// compactFlag removes a global --compact flag from args and, if it was
+// given, forces the compact TUI layout for small panes.
+func compactFlag(args []string) []string {
+	rest := []string{args[0]}
+	for _, a := range args[1:] {
+		if a == "--compact" {
+			ui.SetCompact(true)
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return rest
+}
+
+// sortFlag removes a global --sort flag from args and, if it was given,
+// lists the TUI menus and help alphabetically.
+func sortFlag(args []string) []string {
+	rest := []string{args[0]}
+	for _, a := range args[1:] {
+		if a == "--sort" {
+			ui.SetSortMenus(true)
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return rest
+}
+
+// asciiFlag removes a global --ascii flag from args and turns on ASCII-only
+// output if it was given, if TMCLI_ASCII=1, or if the terminal looks unable
+// to show UTF-8. TMCLI_ASCII=0 keeps the Unicode glyphs regardless.
+func asciiFlag(args []string) []string {
+	on := tmutil.DetectASCII()
+	switch os.Getenv("TMCLI_ASCII") {
+	case "1":
+		on = true
+	case "0":
+		on = false
+	}
+	rest := []string{args[0]}
+	for _, a := range args[1:] {
+		if a == "--ascii" {
+			on = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	tmutil.SetASCII(on)
+	return rest
+}
+
+// redactFlag removes a global --redact flag from args and, if it was given
+// or TMCLI_REDACT=1, masks UUIDs, network URL users and hosts, and this
+// Mac's names in all output.
+func redactFlag(args []string) []string {
+	on := os.Getenv("TMCLI_REDACT") == "1"
+	rest := []string{args[0]}
+	for _, a := range args[1:] {
+		if a == "--redact" {
+			on = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	tmutil.SetRedact(on)
+	return rest
+}
+
+// hostFlag removes a global --host user@mac (or --host=user@mac) flag from
+// args and, if it was given, runs tmutil on that Mac over SSH.
+func hostFlag(args []string) []string {
+	rest := []string{args[0]}
+	for i := 1; i < len(args); i++ {
+		switch a := args[i]; {
+		case a == "--host":
+			if i+1 >= len(args) || args[i+1] == "" {
+				fmt.Fprintln(os.Stderr, "Error: --host requires a host, e.g. --host admin@mac-mini.local")
+				os.Exit(1)
+			}
+			i++
+			tmutil.SetHost(args[i])
+		case strings.HasPrefix(a, "--host="):
+			tmutil.SetHost(strings.TrimPrefix(a, "--host="))
+		default:
+			rest = append(rest, a)
+		}
+	}
+	return rest
+}
+
+// remoteVerbs lists the commands --host can run on another Mac: those
+// marked ReadOnly and Remote, and check.
+func remoteVerbs() []string {
+	verbs := []string{"check"}
+	for _, cmd := range ui.AllCommands() {
+		if cmd.ReadOnly && cmd.Remote {
+			verbs = append(verbs, cmd.ID)
+		}
+	}
+	return verbs
+}
+
+// runConfig prints the effective configuration or, with "edit", opens
+// config.json in the user's editor.
+func runConfig(args, given []string) {
+	if len(args) > 0 && args[0] == "edit" {
+		if err := ui.EditConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(args) > 0 {
+		fmt.Fprintf(os.Stderr, "Unknown config action: %s (expected edit)\n", args[0])
+		os.Exit(1)
+	}
+	runCLI(func([]string) (string, error) { return ui.ConfigReport(given) }, nil)
+}
+
+// runDoctor prints the doctor checklist and exits 0 when every check
+// passes, 1 when any warns, and 2 when any fails.
+func runDoctor(ctx context.Context) {
+	checks := tmutil.Diagnose()
+	exitIfInterrupted(ctx)
+	fmt.Println(tmutil.Redact(tmutil.FormatChecks(checks)))
+	switch tmutil.Worst(checks) {
+	case tmutil.CheckWarn:
+		os.Exit(1)
+	case tmutil.CheckFail:
+		os.Exit(2)
+	}
+}
+
+// runCheck is the doctor for monitoring agents: it prints nothing unless
+// -v is given and exits 0 when the backups are healthy, 1 when the last
+// backup is stale, and 2 when it is failing or the destination cannot be
+// reached. --stale and --fail set the age limits; bad options exit 2 too,
+// so a broken check is never taken for a healthy one. --json prints the
+// verdict for scripts. --hosts checks every Mac listed in a file over SSH
+// instead, --timeout limiting each, prints a table and exits with the worst.
+func runCheck(ctx context.Context, args []string) {
+	verbose, asJSON := false, false
+	hostsFile := ""
+	timeout := tmutil.DefaultHostTimeout
+	var stale, failing time.Duration
+	var limits []string // --stale and --fail, passed on to each host's check
+	usage := func(format string, a ...any) {
+		fmt.Fprintf(os.Stderr, "Error: "+format+"\n", a...)
+		os.Exit(2)
+	}
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "-v", "--verbose":
+			verbose = true
+			continue
+		case "--json":
+			asJSON = true
+			continue
+		case "--stale", "--fail", "--hosts", "--timeout":
+		default:
+			usage("unknown check option: %s", args[i])
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				if name == "--hosts" {
+					usage("--hosts needs a file listing the Macs to check")
+				}
+				usage("%s needs an age, e.g. 36h or 7d", name)
+			}
+			i++
+			value = args[i]
+		}
+		if name == "--hosts" {
+			hostsFile = value
+			continue
+		}
+		age, err := tmutil.ParseAge(value)
+		if err != nil || age <= 0 {
+			usage("%s: invalid age %q (use e.g. 36h or 7d)", name, value)
+		}
+		switch name {
+		case "--stale":
+			stale = age
+		case "--fail":
+			failing = age
+		case "--timeout":
+			timeout = age
+			continue
+		}
+		limits = append(limits, name+"="+value)
+	}
+	if stale > 0 && failing > 0 && stale >= failing {
+		usage("--stale must be shorter than --fail")
+	}
+	if hostsFile != "" {
+		if tmutil.RemoteHost() != "" {
+			usage("give either --host or --hosts, not both")
+		}
+		runCheckHosts(ctx, hostsFile, timeout, limits, asJSON)
+		return
+	}
+
+	h := tmutil.GetBackupHealth(stale, failing)
+	exitIfInterrupted(ctx)
+	switch {
+	case asJSON:
+		data, _ := json.Marshal(h)
+		fmt.Println(tmutil.Redact(string(data)))
+	case verbose:
+		fmt.Println(tmutil.Redact(h.Label() + ": " + h.Detail))
+	}
+	os.Exit(int(h.Status))
+}
+
+// runCheckHosts runs check --json on each Mac in hostsFile through tmcli
+// --host, several at once, prints a table of their verdicts and exits
+// with the worst.
+func runCheckHosts(ctx context.Context, hostsFile string, timeout time.Duration, limits []string, asJSON bool) {
+	hosts, err := tmutil.ReadHosts(hostsFile)
 	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	results := tmutil.CheckFleet(hosts, timeout, func(ctx context.Context, host string) (tmutil.BackupHealth, error) {
+		cmd := exec.CommandContext(ctx, self, append([]string{"--host", host, "check", "--json"}, limits...)...)
+		cmd.WaitDelay = time.Second // don't wait on an ssh left holding the pipe
+		// Interrupted, each check kills its own ssh; killing the check
+		// outright would leave the ssh running.
+		cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		// The check exits 1 or 2 for an unhealthy host; its verdict is
+		// on stdout either way.
+		output, _ := cmd.Output()
+		var h tmutil.BackupHealth
+		if err := json.Unmarshal(output, &h); err != nil {
+			if msg, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); msg != "" {
+				return h, errors.New(strings.TrimPrefix(msg, "Error: "))
+			}
+			return h, fmt.Errorf("no verdict from %s", host)
+		}
+		return h, nil
+	})
+	exitIfInterrupted(ctx)
+	if asJSON {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(tmutil.Redact(string(data)))
+	} else {
+		fmt.Println(tmutil.Redact(tmutil.FormatFleet(results)))
+	}
+	os.Exit(int(tmutil.WorstHost(results)))
+}
+
+// runServe starts the read-only HTTP status endpoint. --addr (or
+// --addr=<addr>) overrides the loopback default.
+func runServe(ctx context.Context, args []string) {
+	addr := ui.DefaultServeAddr
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--addr" && i+1 < len(args):
+			i++
+			addr = args[i]
+		case strings.HasPrefix(args[i], "--addr="):
+			addr = strings.TrimPrefix(args[i], "--addr=")
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown serve option: %s\n", args[i])
+			os.Exit(1)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Serving /status, /health, /destinations, /metrics and /run/{command} on http://%s\n", addr)
+	if err := ui.Serve(ctx, addr); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Println(output)
 }
 
-func runMonitor() {
-	p := tea.NewProgram(ui.NewMonitorModel(Version, false))
+// defaultWatchInterval is used by --watch when no interval is given.
+const defaultWatchInterval = 2 * time.Second
+
+// watchFlag extracts --watch or --watch=<interval> from args. The interval
+// may be a Go duration ("5s", "1m") or a plain number of seconds; anything
+// else is an error rather than a silent fallback to the default.
+func watchFlag(args []string) (time.Duration, []string, bool, error) {
+	interval := defaultWatchInterval
+	found := false
+	var rest []string
+	for _, a := range args {
+		switch {
+		case a == "--watch":
+			found = true
+		case strings.HasPrefix(a, "--watch="):
+			found = true
+			v := strings.TrimPrefix(a, "--watch=")
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				interval = d
+			} else if n, err := strconv.Atoi(v); err == nil && n > 0 {
+				interval = time.Duration(n) * time.Second
+			} else {
+				return 0, nil, true, fmt.Errorf("--watch=%s: the interval must be a positive duration such as 5s or 1m, or a number of seconds", v)
+			}
+		default:
+			rest = append(rest, a)
+		}
+	}
+	return interval, rest, found, nil
+}
+
+// runWatch re-runs a command every interval, clearing the screen between
+// runs like watch(1), until interrupted with ctrl+c. A timeout, when set,
+// limits each run rather than the whole watch.
+func runWatch(ctx context.Context, verb string, fn func([]string) (string, error), args []string, interval, timeout time.Duration) {
+	for {
+		var runCtx context.Context
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			runCtx, cancel = context.WithTimeout(ctx, timeout)
+		} else {
+			runCtx, cancel = context.WithCancel(ctx)
+		}
+		tmutil.SetContext(runCtx)
+		output, err := fn(args)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("Every %s: tmcli %s    %s\n\n", interval, tmutil.ShellJoin(append([]string{verb}, args...)),
+			tmutil.FormatTime(time.Now()))
+		if err != nil {
+			fmt.Printf("Error: %s\n", tmutil.Redact(err.Error()))
+		} else {
+			fmt.Println(tmutil.Redact(output))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// deadlineFlag reads the monitor's "--by TIME" (or "--by=TIME") deadline;
+// it is zero when the flag is absent.
+func deadlineFlag(args []string) (time.Time, error) {
+	for i, a := range args {
+		by := ""
+		switch {
+		case a == "--by":
+			if i+1 >= len(args) {
+				return time.Time{}, fmt.Errorf("--by requires a time of day such as 18:00")
+			}
+			by = args[i+1]
+		case strings.HasPrefix(a, "--by="):
+			by = strings.TrimPrefix(a, "--by=")
+		default:
+			continue
+		}
+		return ui.ParseDeadline(by, time.Now())
+	}
+	return time.Time{}, nil
+}
+
+func runMonitor(ctx context.Context, args []string) {
+	emit, wait := "", false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--wait":
+			wait = true
+		case args[i] == "--emit" && i+1 < len(args):
+			i++
+			emit = args[i]
+		case strings.HasPrefix(args[i], "--emit="):
+			emit = strings.TrimPrefix(args[i], "--emit=")
+		}
+	}
+	if emit != "" {
+		if emit != "json" {
+			fmt.Fprintf(os.Stderr, "Error: unsupported --emit format %q (expected json)\n", emit)
+			os.Exit(1)
+		}
+		// ctrl+c or SIGTERM ends the feed after the last complete record,
+		// exiting 0 so a pipeline reading it does not report a failure.
+		if err := ui.RunMonitorFeed(ctx, os.Stdout, wait); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Bubbletea restores the terminal on the way out; a SIGINT from outside
+	// the program (ctrl+c arrives as a key) is a normal way to stop it.
+	monitor := ui.NewMonitorModel(Version, false)
+	if deadline, err := deadlineFlag(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	} else if !deadline.IsZero() {
+		monitor = monitor.WithDeadline(deadline)
+	}
+	p := tea.NewProgram(monitor)
+	if _, err := p.Run(); err != nil && !errors.Is(err, tea.ErrInterrupted) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runFollow starts a backup, unless one is already running, and prints its
+// progress until it ends. It exits 1 when the backup ends without
+// completing.
+func runFollow(ctx context.Context) {
+	output, err := tmutil.StartBackupIfIdle()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", tmutil.Redact(err.Error()))
+		os.Exit(1)
+	}
+	fmt.Println(tmutil.Redact(output))
+	// ctrl+c stops following; the backup carries on.
+	if err := ui.FollowBackup(ctx, os.Stdout); err != nil {
+		os.Exit(1)
+	}
+}
+
+func runDeleter() {
+	p := tea.NewProgram(ui.NewDeleteModel(Version, false))
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+func runBrowser() {
+	p := tea.NewProgram(ui.NewBrowserModel(Version, false), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if m, ok := final.(ui.BrowserModel); ok && m.Selected != "" {
+		fmt.Println(m.Selected)
+	}
+}
+
 func runTUI() {
-	p := tea.NewProgram(ui.NewModel(Version), tea.WithAltScreen())
+	runModel(ui.NewModel(Version))
+}
+
+// runTUIAt launches the TUI at the command named by args[0], an ID, alias
+// or unambiguous prefix, with its form filled in from the rest of args.
+// An unknown name falls back to the main menu.
+func runTUIAt(args []string) {
+	m := ui.NewModel(Version)
+	if len(args) > 0 {
+		id := args[0]
+		if cmd, _ := resolveCommand(id); cmd != nil {
+			id = cmd.ID
+		}
+		var ok bool
+		if m, ok = m.WithCommand(id, args[1:]); !ok {
+			fmt.Fprintf(os.Stderr, "Unknown command: %s; opening the menu\n", args[0])
+		}
+	}
+	runModel(m)
+}
+
+func runModel(m ui.Model) {
+	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -86,10 +795,25 @@ func printUsage() {
 	fmt.Fprintf(os.Stderr, "Usage: %s [command] [arguments]\n\n", os.Args[0])
 	fmt.Fprintf(os.Stderr, "Running with no arguments launches the interactive TUI.\n\n")
 	fmt.Fprintf(os.Stderr, "Commands:\n")
-	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "tui", "Launch the interactive TUI (default)")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "tui [command [args]]", "Launch the interactive TUI (default), optionally at a command's form")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "help --json", "Print every category and command, with its inputs, as JSON")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "completion <bash|zsh>", "Print a shell completion script")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "saved [add|rm|run] ...", "List, save, remove, or run saved commands")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "config [edit]", "Show the effective settings and their sources, or edit config.json")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "check [-v] [--stale D]", "Exit 0 if backups are healthy, 1 if stale, 2 if failing (--fail D)")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "check --hosts FILE", "Check every Mac listed in FILE over SSH and print a table (--timeout D each)")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "serve [--addr host:port]", "Serve read-only JSON status over HTTP (default localhost:8080)")
+	fmt.Fprintf(os.Stderr, "  %-26s %s\n", "raw -- <tmutil args>", "Run tmutil directly (advanced, unsupported)")
+	fmt.Fprintf(os.Stderr, "\n  Add --watch[=interval] to a read-only command to re-run it until ctrl+c (default 2s).\n")
+	fmt.Fprintf(os.Stderr, "  Add --log (or set TMCLI_LOG=1) to record every tmutil call in tmcli.log.\n")
+	fmt.Fprintf(os.Stderr, "  Add --compact to use the minimal TUI layout (automatic below %d lines).\n", ui.CompactHeight)
+	fmt.Fprintf(os.Stderr, "  Add --sort to list the TUI menus alphabetically instead of in their curated order.\n")
+	fmt.Fprintf(os.Stderr, "  Add --ascii (or set TMCLI_ASCII=1) to draw with plain ASCII on consoles without UTF-8.\n")
+	fmt.Fprintf(os.Stderr, "  Add --redact (or set TMCLI_REDACT=1) to mask IDs, URL users and hosts, and host names.\n")
+	fmt.Fprintf(os.Stderr, "  Add --host user@mac to run %s on another Mac over SSH.\n", strings.Join(remoteVerbs(), ", "))
 	fmt.Fprintf(os.Stderr, "\n")
 
-	for _, cat := range ui.Categories() {
+	for _, cat := range ui.MenuCategories() {
 		fmt.Fprintf(os.Stderr, "  %s:\n", strings.ToUpper(cat.Title))
 		for _, cmd := range cat.Commands {
 			desc := cmd.Title
@@ -104,6 +828,9 @@ func printUsage() {
 				}
 				desc = fmt.Sprintf("%s %s", cmd.ID, strings.Join(params, " "))
 			}
+			if len(cmd.Aliases) > 0 {
+				desc += fmt.Sprintf(" (alias: %s)", strings.Join(cmd.Aliases, ", "))
+			}
 			fmt.Fprintf(os.Stderr, "    %-24s %s\n", cmd.ID, desc)
 		}
 		fmt.Fprintf(os.Stderr, "\n")
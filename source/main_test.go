@@ -0,0 +1,100 @@
+//
+// main_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestResolveCommand(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	tests := []struct {
+		verb string
+		want string // the command's ID, or "" for none
+	}{
+		{"status", "status"},
+		{"stat", "status"},
+		{"backups", "listbackups"},                   // an alias
+		{"snapd", "listlocalsnapshotdates"},          // a prefix of an alias
+		{"listlocalsnapshots", "listlocalsnapshots"}, // exact, though also a prefix of listlocalsnapshotdates
+		{"latest", "latestbackup"},                   // an alias that is also a prefix of its own ID
+		{"nosuchcommand", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		cmd, candidates := resolveCommand(tt.verb)
+		got := ""
+		if cmd != nil {
+			got = cmd.ID
+		}
+		if got != tt.want || candidates != nil {
+			t.Errorf("resolveCommand(%q) = %q, %q; want %q", tt.verb, got, candidates, tt.want)
+		}
+	}
+}
+
+// TestResolveCommandAmbiguous checks that an ambiguous prefix runs nothing
+// and names each command it could mean once.
+func TestResolveCommandAmbiguous(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	tests := []struct {
+		verb string
+		want []string
+	}{
+		{"st", []string{"start", "startmonitor", "status", "stop"}},
+		{"dest", []string{"destinationinfo", "destinations"}}, // destinationinfo also by its alias dests
+		{"listlocal", []string{"listlocalsnapshots", "listlocalsnapshotdates"}},
+	}
+	for _, tt := range tests {
+		cmd, candidates := resolveCommand(tt.verb)
+		slices.Sort(candidates)
+		slices.Sort(tt.want)
+		if cmd != nil || !slices.Equal(candidates, tt.want) {
+			t.Errorf("resolveCommand(%q) = %v, %q; want nil, %q", tt.verb, cmd, candidates, tt.want)
+		}
+	}
+}
+
+func TestWatchFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		interval time.Duration
+		rest     []string
+		found    bool
+	}{
+		{[]string{"/"}, defaultWatchInterval, []string{"/"}, false},
+		{[]string{"--watch"}, defaultWatchInterval, nil, true},
+		{[]string{"/", "--watch=10s"}, 10 * time.Second, []string{"/"}, true},
+		{[]string{"--watch=1m"}, time.Minute, nil, true},
+		{[]string{"--watch=5"}, 5 * time.Second, nil, true},
+	}
+	for _, tt := range tests {
+		interval, rest, found, err := watchFlag(tt.args)
+		if err != nil {
+			t.Errorf("watchFlag(%q): %v", tt.args, err)
+			continue
+		}
+		if interval != tt.interval || !slices.Equal(rest, tt.rest) || found != tt.found {
+			t.Errorf("watchFlag(%q) = %v, %q, %v; want %v, %q, %v",
+				tt.args, interval, rest, found, tt.interval, tt.rest, tt.found)
+		}
+	}
+}
+
+func TestWatchFlagBadInterval(t *testing.T) {
+	for _, v := range []string{"abc", "0", "-5", "0s", "-1m", ""} {
+		if _, _, _, err := watchFlag([]string{"--watch=" + v}); err == nil {
+			t.Errorf("watchFlag(--watch=%s) accepted a bad interval", v)
+		}
+	}
+}
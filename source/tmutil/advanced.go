@@ -10,7 +10,16 @@
 
 package tmutil
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
 
 // AssociateDisk associates a volume with a backup.
 func AssociateDisk(args []string) (string, error) {
@@ -27,6 +36,60 @@ func AssociateDisk(args []string) (string, error) {
 	return output, nil
 }
 
+// VolumeBackupDirs returns the candidates for associatedisk's volume backup
+// directory: the per-volume directories inside the latest backup in each
+// machine directory, e.g. ".../2026-03-14-081130.backup/Macintosh HD - Data".
+func VolumeBackupDirs() ([]string, error) {
+	paths, err := listBackupPaths()
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]string)
+	var machines []string
+	for _, p := range paths {
+		dir := filepath.Dir(p)
+		if _, ok := latest[dir]; !ok {
+			machines = append(machines, dir)
+		}
+		latest[dir] = p // listbackups is oldest first
+	}
+	var dirs []string
+	for _, m := range machines {
+		entries, err := os.ReadDir(latest[m])
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+				dirs = append(dirs, filepath.Join(latest[m], e.Name()))
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// InheritCandidates returns what inheritbackup can claim on a mounted
+// destination: the machine directories under Backups.backupdb, one per
+// computer that has backed up there, and the sparse bundles or backup
+// bundles at the top of the volume (network destinations).
+func InheritCandidates(mount string) ([]string, error) {
+	if _, err := os.Stat(mount); err != nil {
+		return nil, err
+	}
+	var found []string
+	machines, _ := filepath.Glob(filepath.Join(mount, "Backups.backupdb", "*"))
+	for _, m := range machines {
+		if info, err := os.Stat(m); err == nil && info.IsDir() && !strings.HasPrefix(filepath.Base(m), ".") {
+			found = append(found, m)
+		}
+	}
+	for _, pattern := range []string{"*.sparsebundle", "*.backupbundle"} {
+		bundles, _ := filepath.Glob(filepath.Join(mount, pattern))
+		found = append(found, bundles...)
+	}
+	return found, nil
+}
+
 // InheritBackup inherits a machine directory or sparse bundle.
 func InheritBackup(args []string) (string, error) {
 	if len(args) == 0 || args[0] == "" {
@@ -42,36 +105,240 @@ func InheritBackup(args []string) (string, error) {
 	return output, nil
 }
 
-// CalculateDrift calculates drift for a machine directory.
-func CalculateDrift(args []string) (string, error) {
-	if len(args) == 0 || args[0] == "" {
-		return "", fmt.Errorf("machine directory is required")
-	}
-	return run("calculatedrift", args[0])
-}
-
 // DeleteInProgress deletes an in-progress backup.
+// With "--trash" the machine directory's *.inProgress entries are moved to
+// the volume's Trash instead of being deleted.
 func DeleteInProgress(args []string) (string, error) {
-	if len(args) == 0 || args[0] == "" {
+	dir, trash, err := inProgressArgs(args)
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
 		return "", fmt.Errorf("machine directory is required")
 	}
-	output, err := run("deleteinprogress", args[0])
+	if trash {
+		inProgress := inProgressPaths(dir)
+		if len(inProgress) == 0 {
+			return "", fmt.Errorf("no in-progress backup found in %s", dir)
+		}
+		return trashPaths(inProgress)
+	}
+	output, err := run("deleteinprogress", dir)
 	if err != nil {
 		return "", err
 	}
 	if output == "" {
-		return fmt.Sprintf("In-progress backup deleted for %s.", args[0]), nil
+		return fmt.Sprintf("In-progress backup deleted for %s.", dir), nil
 	}
 	return output, nil
 }
 
+// InProgressBackup is an incomplete backup left in a machine directory by
+// a backup that was interrupted or failed.
+type InProgressBackup struct {
+	Path     string
+	Started  time.Time // from its name, or its modification time
+	Bytes    int64     // bytes in its files, as far as measured
+	Complete bool      // false when measuring stopped at restoreMeasureBudget
+}
+
+// inProgressPaths returns the in-progress entries of machineDir: HFS+
+// destinations name them 2026-02-06-143000.inProgress, APFS ones use
+// .inprogress. When the layout cannot be told both are looked for.
+func inProgressPaths(machineDir string) []string {
+	patterns := []string{"*.inProgress", "*.inprogress"}
+	switch BackupFormat(machineDir) {
+	case LayoutHFS:
+		patterns = patterns[:1]
+	case LayoutAPFS:
+		patterns = patterns[1:]
+	}
+	seen := make(map[string]bool)
+	var paths []string
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(filepath.Join(machineDir, pattern))
+		for _, m := range matches {
+			if !seen[m] {
+				seen[m] = true
+				paths = append(paths, m)
+			}
+		}
+	}
+	return paths
+}
+
+// FindInProgress lists the incomplete backups in machineDir, with when each
+// was started and its size. Sizing each stops after restoreMeasureBudget,
+// so a large one is reported as at least that size.
+func FindInProgress(machineDir string) ([]InProgressBackup, error) {
+	if _, err := os.Stat(machineDir); err != nil {
+		return nil, err
+	}
+	var found []InProgressBackup
+	for _, p := range inProgressPaths(machineDir) {
+		b := InProgressBackup{Path: p}
+		base := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(p), ".inProgress"), ".inprogress")
+		if t, err := time.ParseInLocation(backupPathDateLayout, base, time.Local); err == nil {
+			b.Started = t
+		} else if info, err := os.Stat(p); err == nil {
+			b.Started = info.ModTime()
+		}
+		_, b.Bytes, b.Complete = measureTree(p, restoreMeasureBudget)
+		found = append(found, b)
+	}
+	return found, nil
+}
+
+// describeInProgress is one line about an incomplete backup, e.g.
+// "Incomplete backup from 2026-02-06 14:30 (3.2 GB, 11 days ago)".
+func describeInProgress(b InProgressBackup, now time.Time) string {
+	size := FormatBytesInt64(b.Bytes)
+	if !b.Complete {
+		size = "at least " + size
+	}
+	if b.Started.IsZero() {
+		return fmt.Sprintf("Incomplete backup (%s)", size)
+	}
+	return fmt.Sprintf("Incomplete backup from %s (%s, %s)", FormatTimeShort(b.Started), size, FormatRelative(b.Started, now))
+}
+
+// inProgressArgs reads a machine directory and "--trash" from args. The
+// TUI passes both in its single field, split as in a shell like Delete's,
+// so a directory with spaces is quoted there; a field naming a directory
+// that exists, as a pasted one does, is taken whole.
+func inProgressArgs(args []string) (dir string, trash bool, err error) {
+	if len(args) == 1 {
+		if _, statErr := os.Stat(args[0]); statErr != nil {
+			if args, err = ShellSplit(args[0]); err != nil {
+				return "", false, err
+			}
+		}
+	}
+	for _, a := range args {
+		switch {
+		case a == "--trash":
+			trash = true
+		case dir != "":
+			return "", false, fmt.Errorf("more than one machine directory given (%s and %s); quote a directory with spaces", dir, a)
+		default:
+			dir = a
+		}
+	}
+	return dir, trash, nil
+}
+
+// inProgressDir returns the machine directory a command was given, or the
+// current one when none was.
+func inProgressDir(args []string) (string, error) {
+	dir, _, err := inProgressArgs(args)
+	if err != nil || dir != "" {
+		return dir, err
+	}
+	return MachineDirectory()
+}
+
+// InProgress reports whether a machine directory holds an incomplete
+// backup, and its size and age, without changing anything, so that
+// deleteinprogress is only run when there is something to delete.
+// args[0] = machine directory (optional, default the current one)
+func InProgress(args []string) (string, error) {
+	dir, err := inProgressDir(args)
+	if err != nil {
+		return "", err
+	}
+	found, err := FindInProgress(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(found) == 0 {
+		return fmt.Sprintf("No incomplete backup in %s. There is nothing to clean up.", dir), nil
+	}
+	var b strings.Builder
+	now := time.Now()
+	for _, f := range found {
+		fmt.Fprintf(&b, "%s found:\n  %s\n\n", describeInProgress(f, now), f.Path)
+	}
+	fmt.Fprintf(&b, "To remove it: %s\n", ShellJoin([]string{"sudo", "tmcli", "deleteinprogress", dir}))
+	b.WriteString("Add --trash to move it to the Trash instead, so it can be recovered.")
+	return b.String(), nil
+}
+
+// InProgressQuestion asks before deleteinprogress runs, saying what was
+// found in the machine directory.
+// args[0] = machine directory, optionally with "--trash" (see inProgressArgs)
+func InProgressQuestion(args []string) string {
+	dir, err := inProgressDir(args)
+	if err != nil {
+		return ""
+	}
+	found, err := FindInProgress(dir)
+	if err != nil {
+		return fmt.Sprintf("Delete the in-progress backup in %s?\n\nThe directory could not be read: %v (y/N)", dir, err)
+	}
+	if len(found) == 0 {
+		return fmt.Sprintf("No incomplete backup was found in %s, so there is likely nothing to delete.\n\nRun deleteinprogress anyway? (y/N)", dir)
+	}
+	var b strings.Builder
+	now := time.Now()
+	for _, f := range found {
+		fmt.Fprintf(&b, "%s found:\n\n  %s\n\n", describeInProgress(f, now), f.Path)
+	}
+	b.WriteString("Delete it? (y/N)")
+	return b.String()
+}
+
 // Delete deletes a backup snapshot.
+// The arguments must be either "-p <path>" or "-d <mount point> -t <timestamp>";
+// they are validated before tmutil is invoked unless "--force" is given.
+// With "--trash" the -p paths are moved to their volume's Trash instead, so
+// they can be recovered until it is emptied.
 func Delete(args []string) (string, error) {
+	if len(args) == 1 {
+		// The TUI passes the whole argument string as a single field, in
+		// which a path with spaces is quoted as in a shell.
+		split, err := ShellSplit(args[0])
+		if err != nil {
+			return "", err
+		}
+		args = split
+	}
 	if len(args) == 0 {
 		return "", fmt.Errorf("arguments are required (e.g. -d mount_point -t timestamp or -p path)")
 	}
-	cmdArgs := append([]string{"delete"}, args...)
+
+	force, trash := false, false
+	var pass []string
+	for _, a := range args {
+		switch a {
+		case "--force":
+			force = true
+		case "--trash":
+			trash = true
+		default:
+			pass = append(pass, a)
+		}
+	}
+	if !force || trash {
+		if err := validateDeleteArgs(pass); err != nil {
+			return "", err
+		}
+	}
+	if trash {
+		var paths []string
+		for i := 0; i+1 < len(pass); i += 2 {
+			if pass[i] != "-p" {
+				return "", fmt.Errorf("--trash works only with -p path")
+			}
+			paths = append(paths, pass[i+1])
+		}
+		out, err := trashPaths(paths)
+		InvalidateBackupCache()
+		return out, err
+	}
+
+	cmdArgs := append([]string{"delete"}, pass...)
 	output, err := run(cmdArgs...)
+	InvalidateBackupCache()
 	if err != nil {
 		return "", err
 	}
@@ -80,3 +347,127 @@ func Delete(args []string) (string, error) {
 	}
 	return output, nil
 }
+
+// validateDeleteArgs checks that args are either "-p <path>" (the path must
+// exist) or "-d <mount point> -t <timestamp>" (the timestamp must parse).
+func validateDeleteArgs(args []string) error {
+	var paths []string
+	var mount, stamp string
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		if flag != "-p" && flag != "-d" && flag != "-t" {
+			return fmt.Errorf("unexpected argument %q: use -p path or -d mount_point -t timestamp (or --force)", flag)
+		}
+		if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+			return fmt.Errorf("%s requires a value", flag)
+		}
+		i++
+		switch flag {
+		case "-p":
+			paths = append(paths, args[i])
+		case "-d":
+			mount = args[i]
+		case "-t":
+			stamp = args[i]
+		}
+	}
+
+	switch {
+	case len(paths) > 0 && (mount != "" || stamp != ""):
+		return fmt.Errorf("use either -p path or -d mount_point -t timestamp, not both")
+	case len(paths) > 0:
+		for _, p := range paths {
+			if _, err := os.Stat(p); err != nil {
+				return fmt.Errorf("backup path %s does not exist", p)
+			}
+		}
+	case mount != "" && stamp != "":
+		if _, err := time.Parse(backupPathDateLayout, stamp); err != nil {
+			return fmt.Errorf("invalid timestamp %q: expected YYYY-MM-DD-HHMMSS", stamp)
+		}
+		if _, err := os.Stat(mount); err != nil {
+			return fmt.Errorf("mount point %s does not exist", mount)
+		}
+	case mount != "":
+		return fmt.Errorf("-d requires -t timestamp")
+	default:
+		return fmt.Errorf("-t requires -d mount_point")
+	}
+	return nil
+}
+
+// trashPaths moves each path to the Trash of the volume holding it and
+// reports where it went. Backups managed by Time Machine are often
+// protected and cannot be moved; the error then says so.
+func trashPaths(paths []string) (string, error) {
+	var b strings.Builder
+	for _, p := range paths {
+		dest, err := moveToTrash(p)
+		if errors.Is(err, syscall.EXDEV) {
+			return b.String(), fmt.Errorf("cannot move %s to the Trash: it is on a different volume from %s, and a Trash only takes items from its own volume; delete without --trash instead", p, filepath.Dir(dest))
+		}
+		if err != nil {
+			return b.String(), fmt.Errorf("cannot move %s to the Trash: %w (Time Machine-managed backups often cannot be trashed; delete without --trash instead)", p, err)
+		}
+		fmt.Fprintf(&b, "Moved %s to %s\n", p, dest)
+	}
+	b.WriteString("Empty the Trash to free the space, or drag the items back to recover them.")
+	return b.String(), nil
+}
+
+// moveToTrash renames path into the Trash on its own volume:
+// /Volumes/<name>/.Trashes/<uid> for external volumes, ~/.Trash otherwise.
+// When run with sudo the invoking user's Trash is used. When the rename
+// itself fails, dest is still where path would have gone: a path on a
+// volume mounted below another (or outside /Volumes) is on a different
+// device from that Trash, and the error is then syscall.EXDEV.
+func moveToTrash(path string) (dest string, err error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	uid := os.Getuid()
+	if v, err := strconv.Atoi(os.Getenv("SUDO_UID")); err == nil {
+		uid = v
+	}
+
+	var trash string
+	if rel, ok := strings.CutPrefix(abs, "/Volumes/"); ok {
+		volume := strings.SplitN(rel, "/", 2)[0]
+		trash = filepath.Join("/Volumes", volume, ".Trashes", strconv.Itoa(uid))
+	} else {
+		home, err := os.UserHomeDir()
+		if sudoUser := os.Getenv("SUDO_USER"); sudoUser != "" {
+			home, err = filepath.Join("/Users", sudoUser), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		trash = filepath.Join(home, ".Trash")
+	}
+	if err := os.MkdirAll(trash, 0o700); err != nil {
+		return "", err
+	}
+
+	dest = filepath.Join(trash, filepath.Base(abs))
+	if _, err := os.Lstat(dest); err == nil {
+		dest = fmt.Sprintf("%s %s", dest, time.Now().Format("15.04.05"))
+	}
+	if err := os.Rename(abs, dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
+// Raw passes args straight to tmutil and returns its output unchanged. It is
+// an unsupported escape hatch for tmutil verbs tmcli does not wrap: nothing
+// is checked, so it can do anything tmutil can. A leading "--" is dropped.
+func Raw(args []string) (string, error) {
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("usage: tmcli raw -- <tmutil arguments>")
+	}
+	return run(args...)
+}
@@ -0,0 +1,134 @@
+//
+// advanced_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// sq single-quotes s, which must not hold a quote itself.
+func sq(s string) string { return "'" + s + "'" }
+
+func TestValidateDeleteArgs(t *testing.T) {
+	dir := t.TempDir()
+	spaced := filepath.Join(dir, "My Backup", "2026-03-14-081130.backup")
+	if err := os.MkdirAll(spaced, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		line    string // as typed in the TUI's single field
+		wantErr string // "" for valid
+	}{
+		{"-p " + sq(spaced), ""},
+		{`-p "` + spaced + `"`, ""},
+		{"-p " + strings.ReplaceAll(spaced, " ", `\ `), ""},
+		{"-d " + sq(dir) + " -t 2026-03-14-081130", ""},
+		// Unquoted, the space splits the path in two.
+		{"-p " + spaced, "unexpected argument"},
+		{"-p " + sq(spaced+"-gone"), "does not exist"},
+		{"-d " + sq(dir) + " -t 2026-03-14", "invalid timestamp"},
+		{"-d " + sq(dir) + " -t 2026-13-14-081130", "invalid timestamp"},
+		{"-d " + sq(dir), "-d requires -t"},
+		{"-t 2026-03-14-081130", "-t requires -d"},
+		{"-p " + sq(spaced) + " -d " + sq(dir) + " -t 2026-03-14-081130", "not both"},
+		{"-p", "requires a value"},
+	}
+	for _, tt := range tests {
+		args, err := ShellSplit(tt.line)
+		if err != nil {
+			t.Errorf("ShellSplit(%s): %v", tt.line, err)
+			continue
+		}
+		err = validateDeleteArgs(args)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("validateDeleteArgs(%q): %v", args, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("validateDeleteArgs(%q) = %v, want an error containing %q", args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestInProgressArgs(t *testing.T) {
+	dir := t.TempDir()
+	spaced := filepath.Join(dir, "My Backup", "Mac")
+	if err := os.MkdirAll(spaced, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		args    []string
+		dir     string
+		trash   bool
+		wantErr string
+	}{
+		{[]string{spaced}, spaced, false, ""}, // pasted, or one argument on the command line
+		{[]string{spaced, "--trash"}, spaced, true, ""},
+		{[]string{"--trash", spaced}, spaced, true, ""},
+		{[]string{sq(spaced) + " --trash"}, spaced, true, ""},
+		{[]string{"--trash " + sq(spaced)}, spaced, true, ""},
+		{[]string{strings.ReplaceAll(spaced, " ", `\ `) + "  --trash"}, spaced, true, ""},
+		{[]string{dir + " --trash"}, dir, true, ""},
+		{[]string{spaced + " --trash"}, "", false, "quote a directory with spaces"},
+		{[]string{sq(spaced)}, spaced, false, ""},
+		{[]string{""}, "", false, ""},
+		{[]string{sq(spaced + " --trash")}, spaced + " --trash", false, ""},
+	}
+	for _, tt := range tests {
+		d, trash, err := inProgressArgs(tt.args)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("inProgressArgs(%q) = %v, want an error containing %q", tt.args, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || d != tt.dir || trash != tt.trash {
+			t.Errorf("inProgressArgs(%q) = %q, %v, %v; want %q, %v", tt.args, d, trash, err, tt.dir, tt.trash)
+		}
+	}
+}
+
+// TestTrashCrossDevice moves a file from /dev/shm to a Trash in the home
+// directory, which Linux test machines keep on another filesystem.
+func TestTrashCrossDevice(t *testing.T) {
+	src, err := os.MkdirTemp("/dev/shm", "tmcli-")
+	if err != nil {
+		t.Skip("no /dev/shm:", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(src) })
+	home := t.TempDir()
+	var a, b syscall.Stat_t
+	if syscall.Stat(src, &a) != nil || syscall.Stat(home, &b) != nil || a.Dev == b.Dev {
+		t.Skip("/dev/shm is on the same filesystem as", home)
+	}
+	t.Setenv("HOME", home)
+	t.Setenv("SUDO_USER", "")
+	t.Setenv("SUDO_UID", "")
+	path := filepath.Join(src, "2026-03-14-081130.inProgress")
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := moveToTrash(path); !errors.Is(err, syscall.EXDEV) {
+		t.Fatalf("moveToTrash across filesystems = %v, want EXDEV", err)
+	}
+	_, err = trashPaths([]string{path})
+	if err == nil || !strings.Contains(err.Error(), "different volume") || strings.Contains(err.Error(), "Time Machine-managed") {
+		t.Errorf("trashPaths across filesystems = %v, want the different-volume error", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("the backup was not left in place: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+//
+// ascii.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// asciiMode draws rules, bars and symbols in plain ASCII for terminals or
+// fonts without UTF-8 support, such as some serial and SSH consoles.
+var asciiMode atomic.Bool
+
+// asciiGlyphs maps every non-ASCII character tmcli draws to an ASCII one.
+// Each replacement is a single character so column widths are unchanged.
+var asciiGlyphs = strings.NewReplacer(
+	"─", "-", "│", "|", "╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"█", "#", "░", ".",
+	"▁", "_", "▂", ".", "▃", "-", "▄", "~", "▅", "=", "▆", "+", "▇", "*",
+	"•", "*", "●", "*", "…", ".", "—", "-", "–", "-",
+	"↑", "^", "↓", "v", "←", "<", "→", ">",
+)
+
+// SetASCII turns ASCII-only output on or off.
+func SetASCII(on bool) {
+	asciiMode.Store(on)
+}
+
+// ASCII reports whether ASCII-only output is on.
+func ASCII() bool {
+	return asciiMode.Load()
+}
+
+// ToASCII replaces tmcli's glyphs in s with ASCII when ASCII-only output is
+// on, and returns s unchanged otherwise.
+func ToASCII(s string) string {
+	if !ASCII() {
+		return s
+	}
+	return asciiGlyphs.Replace(s)
+}
+
+// Rule returns a horizontal rule n characters wide.
+func Rule(n int) string {
+	if ASCII() {
+		return strings.Repeat("-", n)
+	}
+	return strings.Repeat("─", n)
+}
+
+// Bar returns a bar of filled and empty cells, e.g. "████░░".
+func Bar(filled, empty int) string {
+	return BarFull(filled) + BarEmpty(empty)
+}
+
+// ProgressBar returns a bracketed bar width cells wide, filled to fraction
+// (0 to 1), e.g. "[████░░░░]". It is plain text for reports; the monitor
+// draws its own in color.
+func ProgressBar(fraction float64, width int) string {
+	fraction = min(max(fraction, 0), 1)
+	width = max(width, 1)
+	filled := int(float64(width) * fraction)
+	return "[" + Bar(filled, width-filled) + "]"
+}
+
+// BarFull returns n filled bar cells.
+func BarFull(n int) string {
+	if ASCII() {
+		return strings.Repeat("#", n)
+	}
+	return strings.Repeat("█", n)
+}
+
+// BarEmpty returns n empty bar cells.
+func BarEmpty(n int) string {
+	if ASCII() {
+		return strings.Repeat(".", n)
+	}
+	return strings.Repeat("░", n)
+}
+
+// sparkLevels are the glyphs of a sparkline, lowest first.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a one-line chart, one cell each, scaled from
+// the smallest value to the largest.
+func Sparkline(values []int64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = int(float64(v-lo) / float64(hi-lo) * float64(len(sparkLevels)-1))
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return ToASCII(b.String())
+}
+
+// DetectASCII reports whether the terminal looks unable to show UTF-8: a
+// console TERM such as linux or vt100, or a locale that is set but not
+// UTF-8. An unset locale is not taken as a sign either way.
+func DetectASCII() bool {
+	switch os.Getenv("TERM") {
+	case "linux", "vt100", "vt102", "vt220", "dumb":
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			return !strings.Contains(v, "utf-8") && !strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+//
+// attempts.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackupAttempt is one backup Time Machine started, from the AttemptDates
+// in its preferences, and the backup it completed, from SnapshotDates.
+type BackupAttempt struct {
+	Started   time.Time  `json:"started"`
+	Succeeded bool       `json:"succeeded"`
+	Completed *time.Time `json:"completed,omitempty"` // when the backup completed; nil when none did
+	Running   bool       `json:"running,omitempty"`   // the latest attempt, still going
+}
+
+// attemptFailStreak is how many failed attempts in a row, since the last
+// one that completed, make the doctor and health checks warn.
+const attemptFailStreak = 3
+
+// matchAttempts pairs each attempt with the first snapshot taken at or
+// after it and before the next attempt started; an attempt with none
+// failed. The attempts are returned newest first.
+func matchAttempts(prefs BackupPrefs) []BackupAttempt {
+	attempts := append([]time.Time(nil), prefs.AttemptDates...)
+	snaps := append([]time.Time(nil), prefs.SnapshotDates...)
+	sort.Slice(attempts, func(i, j int) bool { return attempts[i].Before(attempts[j]) })
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Before(snaps[j]) })
+
+	out := make([]BackupAttempt, 0, len(attempts))
+	for i, started := range attempts {
+		a := BackupAttempt{Started: started}
+		for _, s := range snaps {
+			if s.Before(started) {
+				continue
+			}
+			if i+1 < len(attempts) && !s.Before(attempts[i+1]) {
+				break
+			}
+			completed := s
+			a.Succeeded, a.Completed = true, &completed
+			break
+		}
+		out = append(out, a)
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// failStreak counts the failed attempts, newest first, before the latest
+// one that completed a backup. A running attempt is not counted.
+func failStreak(attempts []BackupAttempt) int {
+	n := 0
+	for _, a := range attempts {
+		if a.Succeeded {
+			break
+		}
+		if !a.Running {
+			n++
+		}
+	}
+	return n
+}
+
+// GetBackupAttempts returns the recorded backup attempts, newest first.
+func GetBackupAttempts() ([]BackupAttempt, error) {
+	prefs, err := GetBackupPrefs()
+	if err != nil {
+		return nil, err
+	}
+	return attemptsFrom(prefs), nil
+}
+
+// attemptsFrom matches the attempts in prefs, marking the latest as
+// running when it has not completed and a backup is running now.
+func attemptsFrom(prefs BackupPrefs) []BackupAttempt {
+	attempts := matchAttempts(prefs)
+	if len(attempts) > 0 && !attempts[0].Succeeded {
+		_, attempts[0].Running = BackupRunning()
+	}
+	return attempts
+}
+
+// BackupAttempts lists recent backup attempts and whether each completed a
+// backup, to tell backups that succeed from ones that only keep trying.
+// It reads the Time Machine preferences, so it works without the disk.
+// args[0] = number of attempts to show, newest first (optional; "--limit N"
+// also accepted)
+// "--json" or "--output table|json|yaml" renders the attempts for scripts.
+func BackupAttempts(args []string) (string, error) {
+	flags, format, err := parseOutputFormat(args)
+	if err != nil {
+		return "", err
+	}
+	rest, limit, err := parseLimit(flags)
+	if err != nil {
+		return "", err
+	}
+	if limit == 0 && len(rest) > 0 && rest[0] != "" {
+		if limit, err = limitValue(rest[0]); err != nil {
+			return "", err
+		}
+	}
+	attempts, err := GetBackupAttempts()
+	if err != nil {
+		return "", err
+	}
+	all := attempts
+	if limit > 0 && len(attempts) > limit {
+		attempts = attempts[:limit]
+	}
+	if format != "" && format != FormatPlain {
+		return renderFormat(attempts, format)
+	}
+	return formatAttempts(attempts, all), nil
+}
+
+// formatAttempts renders attempts as a dated list, followed by a summary
+// of all the recorded attempts.
+func formatAttempts(attempts, all []BackupAttempt) string {
+	if len(all) == 0 {
+		return "No backup attempts are recorded in the Time Machine preferences."
+	}
+	var b strings.Builder
+	b.WriteString("Backup attempts (newest first)\n")
+	b.WriteString(Rule(40) + "\n")
+	rows := make([][]string, 0, len(attempts))
+	for _, a := range attempts {
+		started := FormatTimeShort(a.Started.Local())
+		switch {
+		case a.Succeeded:
+			rows = append(rows, []string{"  ok", started, fmt.Sprintf("completed %s (%s)", FormatTimeShort(a.Completed.Local()), FormatDuration(a.Completed.Sub(a.Started)))})
+		case a.Running:
+			rows = append(rows, []string{"  running", started, "in progress now"})
+		default:
+			rows = append(rows, []string{"  FAILED", started, "no backup completed"})
+		}
+	}
+	b.WriteString(alignColumns(rows))
+	b.WriteString("\n\n" + attemptSummary(all))
+	return b.String()
+}
+
+// attemptSummary is one line on how many of the attempts completed a
+// backup, and how many failed since the last one that did.
+func attemptSummary(attempts []BackupAttempt) string {
+	ok, failed := 0, 0
+	for _, a := range attempts {
+		switch {
+		case a.Succeeded:
+			ok++
+		case !a.Running:
+			failed++
+		}
+	}
+	s := fmt.Sprintf("%d of %d recorded attempt(s) completed a backup", ok, ok+failed)
+	if streak := failStreak(attempts); streak > 0 {
+		s += fmt.Sprintf("; the last %d failed", streak)
+	}
+	return s + "."
+}
+
+// attemptCheck is the doctor's line on recent backup attempts: it warns
+// after attemptFailStreak failures in a row, and fails when no recorded
+// attempt completed.
+func attemptCheck(prefs BackupPrefs) Check {
+	c := Check{Name: "Backup attempts"}
+	attempts := attemptsFrom(prefs)
+	if len(attempts) == 0 {
+		c.Status, c.Detail = CheckWarn, "no backup attempts recorded"
+		return c
+	}
+	c.Detail = strings.TrimSuffix(attemptSummary(attempts), ".")
+	streak := failStreak(attempts)
+	switch {
+	case streak == len(attempts):
+		c.Status = CheckFail
+	case streak >= attemptFailStreak:
+		c.Status = CheckWarn
+	}
+	return c
+}
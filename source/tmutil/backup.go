@@ -11,14 +11,22 @@
 package tmutil
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// StartBackup starts a Time Machine backup.
+// StartBackup starts a Time Machine backup. It fails fast when every
+// destination is a local disk and none is connected; network shares are
+// left to Time Machine, which mounts them itself.
 func StartBackup() (string, error) {
+	if dests, err := ListDestinations(); err == nil {
+		if why, ok := noLocalReachable(dests); ok {
+			return "", fmt.Errorf("no backup destination is reachable: %s", why)
+		}
+	}
 	output, err := run("startbackup")
 	if err != nil {
 		return "", err
@@ -29,6 +37,24 @@ func StartBackup() (string, error) {
 	return output, nil
 }
 
+// noLocalReachable reports whether dests are all local disks, none of them
+// connected, and why. Time Machine backs up to any destination it can reach
+// and mounts network shares itself, so only then is a backup bound to fail.
+func noLocalReachable(dests []DestInfo) (string, bool) {
+	var reasons []string
+	for _, d := range dests {
+		if d.Name == "" || isNetwork(d) {
+			return "", false
+		}
+		ok, why := destinationReachable(d)
+		if ok {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %s", d.Name, why))
+	}
+	return strings.Join(reasons, "; "), len(reasons) > 0
+}
+
 // StopBackup stops a running Time Machine backup.
 func StopBackup() (string, error) {
 	output, err := run("stopbackup")
@@ -41,13 +67,254 @@ func StopBackup() (string, error) {
 	return output, nil
 }
 
-// Status returns a human-readable status of the current backup.
-func Status() (string, error) {
+// stopWait is how long Stop waits for Time Machine to wind a backup down
+// before reporting its state.
+const stopWait = 10 * time.Second
+
+// Stop stops a running backup after showing how far it got, so a nearly
+// complete one is not thrown away by accident. It only stops with "--yes"
+// ("-y"); without it, it reports the backup's progress and asks for the
+// flag. Once stopped it waits for Time Machine to go idle and reports the
+// final state.
+func Stop(args []string) (string, error) {
+	yes := false
+	for _, a := range args {
+		switch a {
+		case "--yes", "-y":
+			yes = true
+		default:
+			return "", fmt.Errorf("unexpected argument %q: expected --yes", a)
+		}
+	}
+	info, err := GetStatus()
+	if err != nil {
+		return "", err
+	}
+	if !info.Running {
+		return "No backup is running.", nil
+	}
+	if !yes {
+		return "", fmt.Errorf("backup is running (%s); add --yes to stop it", StopProgress(info))
+	}
+	if _, err := StopBackup(); err != nil {
+		return "", err
+	}
+
+	ctx := currentContext()
+	deadline := time.Now().Add(stopWait)
+	after, err := GetStatus()
+	for err == nil && after.Running && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(time.Second):
+		}
+		after, err = GetStatus()
+	}
+	msg := "Backup stopped (" + StopProgress(info) + ")."
+	switch {
+	case err != nil:
+		return msg + " Its state is unknown: " + err.Error(), nil
+	case after.Running:
+		phase := after.Phase
+		if phase == "" {
+			phase = "running"
+		}
+		return msg + " Time Machine is still winding down (" + phase + ").", nil
+	}
+	return msg + " Time Machine is idle.", nil
+}
+
+// StopProgress describes how far a running backup is, e.g. "63% done,
+// Copying, 12.4 GB of 19.7 GB, about 5 minutes left".
+func StopProgress(info StatusInfo) string {
+	var details []string
+	if info.HasProgress && info.Percent > 0 {
+		details = append(details, fmt.Sprintf("%.0f%% done", info.Percent*100))
+	}
+	if info.Phase != "" {
+		details = append(details, info.Phase)
+	}
+	if info.TotalBytes > 0 {
+		details = append(details, FormatBytesInt64(info.BytesCopied)+" of "+FormatBytesInt64(info.TotalBytes))
+	}
+	if info.TimeRemaining > 0 {
+		details = append(details, "about "+FormatDuration(time.Duration(info.TimeRemaining)*time.Second)+" left")
+	}
+	if len(details) == 0 {
+		return "no progress reported yet"
+	}
+	return strings.Join(details, ", ")
+}
+
+// BackupRunning reports whether a backup is in progress, with its status,
+// for commands that should not run alongside one. A status that cannot be
+// read counts as idle, leaving tmutil to report any conflict itself.
+func BackupRunning() (StatusInfo, bool) {
+	info, err := GetStatus()
+	return info, err == nil && info.Running
+}
+
+// StartBackupIfIdle starts a backup unless one is already running, in which
+// case it reports that and does nothing.
+func StartBackupIfIdle() (string, error) {
+	if _, running := BackupRunning(); running {
+		return "Backup already running.", nil
+	}
+	return StartBackup()
+}
+
+// DefaultMaxBackupAge is the age past which autobackup starts a backup.
+const DefaultMaxBackupAge = 24 * time.Hour
+
+// AutoBackup starts a backup only when the last completed one is older than
+// a maximum age, for best-effort top-ups from cron or launchd. When the
+// last backup is recent, or one is already running, it does nothing.
+// "--max-age D" (or "--max-age=D", or args[0]) sets the age, e.g. 24h or
+// 7d; the default is 24h.
+// "--dry-run" (or args[1] = "y") only reports what it would do.
+func AutoBackup(args []string) (string, error) {
+	maxAge := DefaultMaxBackupAge
+	dryRun := false
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--dry-run":
+			dryRun = true
+		case a == "--max-age":
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("--max-age requires a duration, e.g. 24h")
+			}
+			i++
+			positional = append(positional, args[i])
+		case strings.HasPrefix(a, "--max-age="):
+			positional = append(positional, strings.TrimPrefix(a, "--max-age="))
+		default:
+			positional = append(positional, a)
+		}
+	}
+	if len(positional) > 0 && positional[0] != "" {
+		d, err := ParseAge(positional[0])
+		if err != nil {
+			return "", err
+		}
+		maxAge = d
+	}
+	if len(positional) > 1 {
+		v, err := yesNo("dry run", positional[1])
+		if err != nil {
+			return "", err
+		}
+		dryRun = dryRun || v
+	}
+
+	if _, running := BackupRunning(); running {
+		return "A backup is already running; nothing to do.", nil
+	}
+
+	var last time.Time
+	if prefs, err := GetBackupPrefs(); err == nil {
+		last = prefs.LastSnapshot()
+	}
+	if last.IsZero() {
+		if latest, err := LatestBackup(); err == nil && latest != "" {
+			last, _ = parseBackupDate(latest)
+		}
+	}
+
+	var reason string
+	if last.IsZero() {
+		reason = "no completed backup is recorded"
+	} else {
+		age := time.Since(last)
+		if age <= maxAge {
+			return fmt.Sprintf("Last backup was %s ago (limit %s); nothing to do.",
+				FormatDuration(age), FormatDuration(maxAge)), nil
+		}
+		reason = fmt.Sprintf("last backup was %s ago (limit %s)", FormatDuration(age), FormatDuration(maxAge))
+	}
+	if dryRun {
+		return fmt.Sprintf("Would start a backup: %s.", reason), nil
+	}
+	output, err := StartBackup()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Starting a backup: %s.\n%s", reason, output), nil
+}
+
+// ParseAge parses a Go duration ("36h", "90m") or a whole number of days
+// ("7d").
+func ParseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid age %q: expected a duration such as 24h or 7d", s)
+}
+
+// Status returns a human-readable status of the current backup. With
+// --raw it returns tmutil's unformatted status output instead, which is
+// useful when the report leaves out a field tmutil does print.
+// args[0] = destination ID (optional); scopes the last backup, history and
+// disk usage sections to that destination instead of the active one
+// With "--output table|json|yaml" the parsed StatusInfo of the active backup
+// is rendered in that format; "plain" is the usual report.
+func Status(args []string) (string, error) {
+	args, format, err := parseOutputFormat(args)
+	if err != nil {
+		return "", err
+	}
+	raw := false
+	var destID string
+	for _, a := range args {
+		switch {
+		case a == "--raw":
+			raw = true
+		case a != "" && !strings.HasPrefix(a, "-"):
+			destID = a
+		}
+	}
+	var scope *DestInfo
+	if destID != "" {
+		dest, err := findDestination(destID)
+		if err != nil {
+			return "", err
+		}
+		scope = &dest
+	}
+	if format != "" && format != FormatPlain {
+		if raw || scope != nil {
+			return "", fmt.Errorf("--output %s reports the active backup; drop --raw and the destination ID", format)
+		}
+	}
 	output, err := run("status")
 	if err != nil {
 		return "", err
 	}
-	return formatStatus(output), nil
+	if raw {
+		return output, nil
+	}
+	if format != "" && format != FormatPlain {
+		return renderFormat(parseStatusInfo(output), format)
+	}
+	return formatStatus(output, scope, time.Now()), nil
+}
+
+// StatusReport returns the report from Status together with the raw tmutil
+// output it was built from and whether a backup is running, so callers can
+// show either form and keep refreshing (for a live elapsed time) only while
+// a backup runs.
+func StatusReport() (string, string, bool, error) {
+	output, err := run("status")
+	if err != nil {
+		return "", "", false, err
+	}
+	return formatStatus(output, nil, time.Now()), output, !strings.Contains(output, "Running = 0"), nil
 }
 
 // Enable enables automatic Time Machine backups.
@@ -57,7 +324,25 @@ func Enable() (string, error) {
 		return "", err
 	}
 	if output == "" {
-		return "Time Machine enabled.", nil
+		output = "Time Machine enabled."
+	}
+	prefs, prefsErr := GetBackupPrefs()
+	if prefsErr != nil {
+		return output, nil
+	}
+	interval, set := prefs.Interval()
+	note := ""
+	if !set {
+		note = " (macOS default)"
+	}
+	output += fmt.Sprintf("\nAutomatic backups run %s%s.", DescribeInterval(interval), note)
+	if last := prefs.LastSnapshot(); !last.IsZero() {
+		next := last.Add(interval)
+		if next.Before(time.Now()) {
+			output += "\nA backup is due now; the next one should start shortly."
+		} else {
+			output += fmt.Sprintf("\nNext backup expected around %s.", FormatTimeShort(next.Local()))
+		}
 	}
 	return output, nil
 }
@@ -69,81 +354,174 @@ func Disable() (string, error) {
 		return "", err
 	}
 	if output == "" {
-		return "Time Machine disabled.", nil
+		output = "Time Machine disabled."
+	}
+	prefs, prefsErr := GetBackupPrefs()
+	if prefsErr != nil {
+		return output + "\nScheduled backups will stop; run 'tmcli start' to back up manually.", nil
+	}
+	interval, _ := prefs.Interval()
+	output += fmt.Sprintf("\nScheduled backups (%s) will stop; run 'tmcli start' to back up manually.", DescribeInterval(interval))
+	if last := prefs.LastSnapshot(); !last.IsZero() {
+		output += fmt.Sprintf("\nLast backup: %s (%s ago).", FormatTimeShort(last.Local()), FormatDuration(time.Since(last)))
+	} else {
+		output += "\nNo completed backup is recorded."
 	}
 	return output, nil
 }
 
+// Schedule reports how often automatic backups run, read from the
+// AutoBackupInterval preference, along with when the last backup finished
+// and when the next one is expected.
+func Schedule() (string, error) {
+	prefs, err := GetBackupPrefs()
+	if err != nil {
+		return "", fmt.Errorf("cannot read the backup schedule from %s (the terminal may need Full Disk Access): %w", tmPlistDomain, err)
+	}
+
+	var b strings.Builder
+	b.WriteString("Backup Schedule\n")
+	b.WriteString(Rule(40) + "\n\n")
+
+	auto := "unknown"
+	if prefs.AutoBackupSet {
+		auto = "disabled"
+		if prefs.AutoBackup {
+			auto = "enabled"
+		}
+	}
+	fmt.Fprintf(&b, "  Automatic:   %s\n", auto)
+
+	interval, set := prefs.Interval()
+	source := "macOS default"
+	if set {
+		source = "set in preferences"
+	}
+	fmt.Fprintf(&b, "  Interval:    %s (%s)\n", DescribeInterval(interval), source)
+
+	last := prefs.LastSnapshot()
+	if last.IsZero() {
+		b.WriteString("  Last:        no completed backup recorded\n")
+	} else {
+		fmt.Fprintf(&b, "  Last:        %s (%s ago)\n", FormatTimeShort(last.Local()), FormatDuration(time.Since(last)))
+	}
+	if prefs.AutoBackup && !last.IsZero() {
+		next := last.Add(interval)
+		if next.Before(time.Now()) {
+			b.WriteString("  Next:        due now\n")
+		} else {
+			fmt.Fprintf(&b, "  Next:        around %s\n", FormatTimeShort(next.Local()))
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// PhaseExplanation says what a backup does in one of tmutil's BackupPhase
+// values, e.g. "scanning for files changed since the last backup" for
+// FindingChanges, or returns "" for a phase it does not know.
+func PhaseExplanation(phase string) string {
+	id := "phase." + phase
+	if s := T(id); s != id {
+		return s
+	}
+	return ""
+}
+
+// DescribePhase returns the raw phase followed by its explanation, e.g.
+// "FindingChanges — scanning for files changed since the last backup", or
+// the phase alone when it has none.
+func DescribePhase(phase string) string {
+	if e := PhaseExplanation(phase); e != "" {
+		return ToASCII(phase + " — " + e)
+	}
+	return phase
+}
+
 // Version returns the tmutil version.
 func Version() (string, error) {
 	return run("version")
 }
 
-func formatStatus(raw string) string {
+// formatStatus renders tmutil status output as of now. scope, when not
+// nil, is the destination the idle report describes; nil means the active
+// destination.
+func formatStatus(raw string, scope *DestInfo, now time.Time) string {
 	if strings.Contains(raw, "Running = 0") {
-		return formatIdleStatus(raw)
+		return formatIdleStatus(raw, scope)
 	}
 
 	fields := parseFields(raw)
 	var b strings.Builder
 
-	b.WriteString("Time Machine Backup Status\n")
-	b.WriteString(strings.Repeat("─", 40) + "\n\n")
+	b.WriteString(T("status.title") + "\n")
+	b.WriteString(Rule(40) + "\n\n")
 
 	if v, ok := fields["BackupPhase"]; ok {
-		b.WriteString(fmt.Sprintf("  Phase:         %s\n", v))
+		b.WriteString(statusLine("status.phase", DescribePhase(v)))
 	}
 	if v, ok := fields["Running"]; ok {
 		if v == "1" {
-			b.WriteString("  Running:       Yes\n")
+			b.WriteString(statusLine("status.running", T("common.yes")))
 		} else {
-			b.WriteString("  Running:       No\n")
+			b.WriteString(statusLine("status.running", T("common.no")))
 		}
 	}
 	if v, ok := fields["DestinationMountPoint"]; ok {
-		b.WriteString(fmt.Sprintf("  Destination:   %s\n", v))
+		b.WriteString(statusLine("status.destination", v))
 	}
+	b.WriteString(reachableLine(DestinationReachable()))
+	elapsedShown := false
 	if v, ok := fields["DateOfStateChange"]; ok {
-		b.WriteString(fmt.Sprintf("  Started:       %s\n", v))
+		b.WriteString(statusLine("status.started", v))
 		if t, err := time.Parse(tmutilTimeLayout, v); err == nil {
-			elapsed := time.Since(t)
-			b.WriteString(fmt.Sprintf("  Elapsed:       %s\n", FormatDuration(elapsed)))
+			elapsed := now.Sub(t)
+			b.WriteString(statusLine("status.elapsed", FormatDuration(elapsed)))
+			elapsedShown = true
+		}
+	}
+	if !elapsedShown {
+		// Fall back to the latest backup attempt recorded in the plist.
+		if prefs, err := GetBackupPrefs(); err == nil && len(prefs.AttemptDates) > 0 {
+			last := prefs.AttemptDates[len(prefs.AttemptDates)-1]
+			b.WriteString(statusLine("status.started", FormatTime(last.Local())))
+			b.WriteString(statusLine("status.elapsed", FormatDuration(now.Sub(last))))
+		} else {
+			b.WriteString(statusLine("status.elapsed", T("common.unknown")))
 		}
 	}
 
 	progress := parseProgress(raw)
 	if len(progress) > 0 {
-		b.WriteString("\n  Progress:\n")
-		if v, ok := progress["Percent"]; ok {
-			if pct, err := strconv.ParseFloat(v, 64); err == nil {
-				b.WriteString(fmt.Sprintf("    Completed:   %.1f%%\n", pct*100))
-			}
+		b.WriteString("\n  " + T("status.progress") + ":\n")
+		if pct, ok := statusPercent(fields, progress); ok {
+			width := min(statusBarWidth, OutputWidth()-17-8)
+			b.WriteString(detailLine("status.completed", fmt.Sprintf("%s %.1f%%", ProgressBar(pct, width), pct*100)))
 		}
 		if v, ok := progress["TimeRemaining"]; ok {
 			if secs, err := strconv.ParseFloat(v, 64); err == nil && secs > 0 {
 				mins := int(secs) / 60
 				hrs := mins / 60
 				mins = mins % 60
-				estimate := time.Now().Add(time.Duration(secs) * time.Second)
+				estimate := now.Add(time.Duration(secs) * time.Second)
 				if hrs > 0 {
-					b.WriteString(fmt.Sprintf("    Remaining:   %dh %dm [%s]\n", hrs, mins, estimate.Local().Format("2006-01-02 15:04:05")))
+					b.WriteString(detailLine("status.remaining", fmt.Sprintf("%dh %dm [%s]", hrs, mins, FormatTime(estimate.Local()))))
 				} else {
-					b.WriteString(fmt.Sprintf("    Remaining:   %dm [%s]\n", mins, estimate.Local().Format("2006-01-02 15:04:05")))
+					b.WriteString(detailLine("status.remaining", fmt.Sprintf("%dm [%s]", mins, FormatTime(estimate.Local()))))
 				}
 			} else {
-				b.WriteString("    Remaining:   Calculating...\n")
+				b.WriteString(detailLine("status.remaining", T("status.calculating")))
 			}
 		} else {
-			b.WriteString("    Remaining:   Calculating...\n")
+			b.WriteString(detailLine("status.remaining", T("status.calculating")))
 		}
 		if bytes, ok := progress["bytes"]; ok {
 			if total, ok2 := progress["totalBytes"]; ok2 {
-				b.WriteString(fmt.Sprintf("    Bytes:       %s / %s\n", formatBytes(bytes), formatBytes(total)))
+				b.WriteString(detailLine("status.bytes", fmt.Sprintf("%s / %s", formatBytes(bytes), formatBytes(total))))
 			}
 		}
 		if files, ok := progress["files"]; ok {
 			if total, ok2 := progress["totalFiles"]; ok2 {
-				b.WriteString(fmt.Sprintf("    Files:       %s / %s\n", files, total))
+				b.WriteString(detailLine("status.files", fmt.Sprintf("%s / %s", files, total)))
 			}
 		}
 	}
@@ -151,34 +529,89 @@ func formatStatus(raw string) string {
 	return b.String()
 }
 
-func formatIdleStatus(raw string) string {
+// statusBarWidth is the width of the progress bar in status output, inside
+// its brackets.
+const statusBarWidth = 30
+
+// statusLine renders a top-level "  Label:         value" status line.
+// Values too long for the output width, such as network URLs, lose their
+// middle.
+func statusLine(id, value string) string {
+	return "  " + Label(id, 15) + TruncateMiddle(value, OutputWidth()-17) + "\n"
+}
+
+// detailLine renders an indented "    Label:   value" line within a section.
+func detailLine(id, value string) string {
+	return "    " + Label(id, 13) + TruncateMiddle(value, OutputWidth()-17) + "\n"
+}
+
+// sectionLine renders a section heading such as "  Last Backup".
+func sectionLine(id string) string {
+	return "\n  " + T(id) + "\n"
+}
+
+// reachableLine renders a destination's reachability for status output.
+func reachableLine(ok bool, why string) string {
+	if ok {
+		return statusLine("status.reachable", fmt.Sprintf("%s (%s)", T("common.yes"), why))
+	}
+	return statusLine("status.reachable", fmt.Sprintf("%s (%s)", T("common.no"), why))
+}
+
+func formatIdleStatus(raw string, scope *DestInfo) string {
 	fields := parseFields(raw)
 	var b strings.Builder
 
-	b.WriteString("Time Machine Status\n")
-	b.WriteString(strings.Repeat("─", 40) + "\n\n")
-	b.WriteString("  State:         Idle\n")
+	b.WriteString(T("status.idle_title") + "\n")
+	b.WriteString(Rule(40) + "\n\n")
+	head := b.Len()
+	b.WriteString(statusLine("status.state", T("status.idle")))
+
+	// Sections left out because their source could not be read.
+	var missing []unavailable
 
 	// Read preferences plist for rich data (available even when disk is unmounted).
-	prefs, prefsErr := GetBackupPrefs()
+	var prefs BackupPrefs
+	var prefsErr error
+	if scope != nil {
+		prefs, prefsErr = GetBackupPrefsFor(scope.ID)
+	} else {
+		prefs, prefsErr = GetBackupPrefs()
+	}
+	switch {
+	case errors.Is(prefsErr, ErrNotConfigured):
+		// Nothing is missing: there is nothing to read yet.
+		b.WriteString("\n  " + T("status.not_configured") + "\n")
+	case prefsErr != nil:
+		missing = append(missing, unavailable{"status.preferences", prefsErr})
+	}
 
 	// Auto-backup: prefer plist, fall back to tmutil status fields.
 	if prefsErr == nil && prefs.AutoBackupSet {
 		if prefs.AutoBackup {
-			b.WriteString("  Auto Backup:   Enabled\n")
+			b.WriteString(statusLine("status.auto_backup", T("common.enabled")))
 		} else {
-			b.WriteString("  Auto Backup:   Disabled\n")
+			b.WriteString(statusLine("status.auto_backup", T("common.disabled")))
 		}
 	} else if v, ok := fields["AutoBackup"]; ok {
 		if v == "1" {
-			b.WriteString("  Auto Backup:   Enabled\n")
+			b.WriteString(statusLine("status.auto_backup", T("common.enabled")))
 		} else {
-			b.WriteString("  Auto Backup:   Disabled\n")
+			b.WriteString(statusLine("status.auto_backup", T("common.disabled")))
 		}
 	}
 
 	// Destination info (best-effort).
-	if dest, err := GetDestinationInfo(); err == nil && dest.Name != "" {
+	dest, destErr := DestInfo{}, error(nil)
+	if scope != nil {
+		dest = *scope
+	} else {
+		dest, destErr = GetDestinationInfo()
+		if IsPermissionError(destErr) {
+			missing = append(missing, unavailable{"status.destination", destErr})
+		}
+	}
+	if destErr == nil && dest.Name != "" {
 		label := dest.Name
 		if dest.MountPoint != "" && dest.MountPoint != dest.Name {
 			label += " (" + dest.MountPoint
@@ -189,70 +622,130 @@ func formatIdleStatus(raw string) string {
 		} else if dest.Kind != "" {
 			label += " (" + dest.Kind + ")"
 		}
-		b.WriteString(fmt.Sprintf("  Destination:   %s\n", label))
+		b.WriteString(statusLine("status.destination", label))
+		if dest.URL != "" {
+			b.WriteString(statusLine("status.url", dest.URL))
+		}
+		b.WriteString(reachableLine(destinationReachable(dest)))
 	}
 
 	if prefsErr == nil && prefs.Encryption != "" {
-		b.WriteString(fmt.Sprintf("  Encryption:    %s\n", prefs.Encryption))
+		b.WriteString(statusLine("status.encryption", prefs.Encryption))
 	}
 
 	// Last backup: prefer plist SnapshotDates (works without disk), fall back to tmutil latestbackup.
-	lastShown := false
+	// tmutil only reports on the active destination, so a scoped report
+	// relies on the plist alone.
+	lastShown := scope != nil
 	if prefsErr == nil && !prefs.LastSnapshot().IsZero() {
 		t := prefs.LastSnapshot()
-		b.WriteString("\n  Last Backup\n")
-		b.WriteString(fmt.Sprintf("    Completed:   %s\n", t.Local().Format("2006-01-02 15:04:05")))
+		b.WriteString(sectionLine("status.last_backup"))
+		b.WriteString(detailLine("status.completed", FormatTime(t.Local())))
 		if d := prefs.LastBackupDuration(); d > 0 {
-			b.WriteString(fmt.Sprintf("    Elapsed:     %s\n", FormatDuration(d)))
+			b.WriteString(detailLine("status.elapsed", FormatDuration(d)))
 		}
 		lastShown = true
 	}
 	if !lastShown {
 		if latest, err := LatestBackup(); err == nil && latest != "" {
 			if t, parseErr := parseBackupDate(latest); parseErr == nil {
-				b.WriteString("\n  Last Backup\n")
-				b.WriteString(fmt.Sprintf("    Completed:   %s\n", t.Local().Format("2006-01-02 15:04:05")))
+				b.WriteString(sectionLine("status.last_backup"))
+				b.WriteString(detailLine("status.completed", FormatTime(t.Local())))
 				lastShown = true
 			}
 		}
 	}
 
 	// Backup history: prefer plist SnapshotDates, fall back to tmutil listbackups.
-	historyShown := false
+	historyShown := scope != nil
 	if prefsErr == nil && len(prefs.SnapshotDates) > 0 {
-		b.WriteString("\n  Backup History\n")
-		b.WriteString(fmt.Sprintf("    Total:       %d snapshot(s)\n", len(prefs.SnapshotDates)))
-		b.WriteString(fmt.Sprintf("    Oldest:      %s\n", prefs.FirstSnapshot().Local().Format("2006-01-02 15:04:05")))
-		b.WriteString(fmt.Sprintf("    Newest:      %s\n", prefs.LastSnapshot().Local().Format("2006-01-02 15:04:05")))
+		b.WriteString(sectionLine("status.history"))
+		b.WriteString(detailLine("status.total", fmt.Sprintf(T("status.snapshot_count"), len(prefs.SnapshotDates))))
+		b.WriteString(detailLine("status.oldest", FormatTime(prefs.FirstSnapshot().Local())))
+		b.WriteString(detailLine("status.newest", FormatTime(prefs.LastSnapshot().Local())))
 		historyShown = true
 	}
 	if !historyShown {
-		if paths, err := listBackupPaths(); err == nil && len(paths) > 0 {
-			b.WriteString("\n  Backup History\n")
-			b.WriteString(fmt.Sprintf("    Total:       %d snapshot(s)\n", len(paths)))
+		paths, err := listBackupPaths()
+		if err != nil && !errors.Is(err, ErrNoBackups) {
+			missing = append(missing, unavailable{"status.history", err})
+		}
+		if len(paths) > 0 {
+			b.WriteString(sectionLine("status.history"))
+			b.WriteString(detailLine("status.total", fmt.Sprintf(T("status.snapshot_count"), len(paths))))
 			if oldest, err := parseBackupDate(paths[0]); err == nil {
-				b.WriteString(fmt.Sprintf("    Oldest:      %s\n", oldest.Local().Format("2006-01-02 15:04:05")))
+				b.WriteString(detailLine("status.oldest", FormatTime(oldest.Local())))
 			}
 			if newest, err := parseBackupDate(paths[len(paths)-1]); err == nil {
-				b.WriteString(fmt.Sprintf("    Newest:      %s\n", newest.Local().Format("2006-01-02 15:04:05")))
+				b.WriteString(detailLine("status.newest", FormatTime(newest.Local())))
+			}
+		}
+	}
+
+	// Local APFS snapshots per volume (best-effort).
+	if counts, err := CountLocalSnapshots(); err == nil && len(counts) > 0 {
+		total := 0
+		for _, c := range counts {
+			total += c.Count
+		}
+		b.WriteString(sectionLine("status.local_snapshots"))
+		b.WriteString(detailLine("status.total", strconv.Itoa(total)))
+		for _, c := range counts {
+			if c.Count > 0 {
+				b.WriteString(fmt.Sprintf("    %-12s %d\n", c.MountPoint+":", c.Count))
 			}
 		}
 	}
 
 	// Disk usage from plist.
 	if prefsErr == nil && (prefs.BytesUsed > 0 || prefs.BytesAvailable > 0) {
-		b.WriteString("\n  Disk Usage\n")
+		b.WriteString(sectionLine("status.disk_usage"))
 		if prefs.BytesUsed > 0 {
-			b.WriteString(fmt.Sprintf("    Used:        %s\n", FormatBytesInt64(prefs.BytesUsed)))
+			b.WriteString(detailLine("status.used", FormatBytesInt64(prefs.BytesUsed)))
 		}
 		if prefs.BytesAvailable > 0 {
-			b.WriteString(fmt.Sprintf("    Available:   %s\n", FormatBytesInt64(prefs.BytesAvailable)))
+			b.WriteString(detailLine("status.available", FormatBytesInt64(prefs.BytesAvailable)))
 		}
 		if prefs.BytesUsed > 0 && prefs.BytesAvailable > 0 {
 			total := prefs.BytesUsed + prefs.BytesAvailable
-			b.WriteString(fmt.Sprintf("    Total:       %s\n", FormatBytesInt64(total)))
+			b.WriteString(detailLine("status.total", FormatBytesInt64(total)))
+		}
+	}
+
+	if len(missing) == 0 {
+		return b.String()
+	}
+	report := b.String()
+	return report[:head] + partialNotice(missing) + report[head:] + unavailableSection(missing)
+}
+
+// unavailable is a section of a report whose source could not be read.
+type unavailable struct {
+	section string // message ID of the section's title
+	err     error
+}
+
+// partialNotice heads a report that lacks sections for want of
+// permissions; other failures are only listed at the end.
+func partialNotice(missing []unavailable) string {
+	for _, m := range missing {
+		if IsPermissionError(m.err) {
+			return "  " + T("status.partial") + "\n\n"
 		}
 	}
+	return ""
+}
 
+// unavailableSection lists the sections left out of a report and why.
+func unavailableSection(missing []unavailable) string {
+	var b strings.Builder
+	b.WriteString(sectionLine("status.unavailable"))
+	for _, m := range missing {
+		why := firstLine(m.err.Error())
+		if IsPermissionError(m.err) {
+			why = T("status.no_permission")
+		}
+		b.WriteString(detailLine(m.section, why))
+	}
 	return b.String()
 }
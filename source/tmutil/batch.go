@@ -0,0 +1,89 @@
+//
+// batch.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BatchError is returned when items of a batch fail. Its message is the
+// whole report, each failure with its own reason.
+type BatchError struct {
+	Report string
+	Failed int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%s\n%d failed", e.Report, e.Failed)
+}
+
+// runBatch runs fn on each item in turn, carrying on past failures, and
+// reports every outcome with a count, e.g. "Added 3 of 4 exclusion(s)".
+// When any item fails the report is returned as a *BatchError, so the
+// failures are not lost and the command exits non-zero.
+func runBatch(done, noun string, items []string, fn func(item string) error) (string, error) {
+	var b strings.Builder
+	failed := 0
+	for _, item := range items {
+		if err := cancelled(currentContext()); err != nil {
+			return "", err
+		}
+		if err := fn(item); err != nil {
+			failed++
+			fmt.Fprintf(&b, "  failed  %s: %v\n", item, err)
+			continue
+		}
+		fmt.Fprintf(&b, "  ok      %s\n", item)
+	}
+	report := fmt.Sprintf("%s %d of %d %s:\n%s", done, len(items)-failed, len(items), noun, strings.TrimSuffix(b.String(), "\n"))
+	if failed > 0 {
+		return "", &BatchError{Report: report, Failed: failed}
+	}
+	return report, nil
+}
+
+// readPathList reads one path per line from a file. Blank lines and lines
+// starting with # are skipped, surrounding quotes are removed, so paths
+// with spaces may be quoted or not, and a leading ~/ is the home directory.
+func readPathList(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	home, _ := os.UserHomeDir()
+	var paths []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if len(line) >= 2 && (line[0] == '"' || line[0] == '\'') && line[len(line)-1] == line[0] {
+			line = line[1 : len(line)-1]
+		}
+		if rest, ok := strings.CutPrefix(line, "~/"); ok && home != "" {
+			line = filepath.Join(home, rest)
+		}
+		paths = append(paths, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", name, err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%s lists no paths", name)
+	}
+	return paths, nil
+}
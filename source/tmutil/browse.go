@@ -10,16 +10,124 @@
 
 package tmutil
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // LatestBackup returns the path to the most recent backup.
 func LatestBackup() (string, error) {
 	return run("latestbackup")
 }
 
-// ListBackups lists all completed backups.
-func ListBackups() (string, error) {
-	return run("listbackups")
+// ListBackups lists completed backups. As an explicit request for the
+// list it always queries tmutil, refreshing the backup cache.
+// args[0] = show only the newest N backups (optional; "--limit N" also accepted)
+// With "--output json" (or "--json") or "--output yaml" it emits a ListPage
+// of {path, date} objects instead, and with "--output table" a path and
+// date table; "plain" is the usual list of paths.
+func ListBackups(args []string) (string, error) {
+	return listBackups(args, nil)
+}
+
+// ListBackupsStream is ListBackups reporting how many backups tmutil has
+// listed so far, since a large or network destination can take a long
+// time to enumerate.
+func ListBackupsStream(args []string, progress func(string)) (string, error) {
+	return listBackups(args, progress)
+}
+
+func listBackups(args []string, progress func(string)) (string, error) {
+	InvalidateBackupCache()
+	flags, format, err := parseOutputFormat(args)
+	if err != nil {
+		return "", err
+	}
+	structured := format != "" && format != FormatPlain
+	rest, limit, err := parseLimit(flags)
+	if err != nil {
+		return "", err
+	}
+	if limit == 0 && len(rest) > 0 && rest[0] != "" {
+		if limit, err = limitValue(rest[0]); err != nil {
+			return "", err
+		}
+	}
+	if limit == 0 && !structured && progress == nil {
+		return run("listbackups")
+	}
+	var paths []string
+	if progress != nil {
+		paths, err = streamBackupPaths(progress)
+	} else {
+		paths, err = listBackupPaths()
+	}
+	if err != nil {
+		return "", err
+	}
+	if structured {
+		listings := make([]BackupListing, len(paths))
+		for i, e := range backupEntries(paths) {
+			listings[i].Path = e.Path
+			if !e.Date.IsZero() {
+				listings[i].Date = &e.Date
+			}
+		}
+		page := newListPage(listings, limit)
+		if format == FormatTable {
+			return renderFormat(page.Items, format)
+		}
+		return renderFormat(page, format)
+	}
+	if len(paths) > limit {
+		paths = paths[len(paths)-limit:]
+	}
+	return strings.Join(paths, "\n"), nil
+}
+
+// parseLimit removes "--limit N" or "--limit=N" from args and returns the
+// remaining args and the limit (0 when absent, meaning unlimited).
+func parseLimit(args []string) ([]string, int, error) {
+	var rest []string
+	limit := 0
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--limit":
+			if i+1 >= len(args) {
+				return nil, 0, fmt.Errorf("--limit requires a number")
+			}
+			i++
+			n, err := limitValue(args[i])
+			if err != nil {
+				return nil, 0, err
+			}
+			limit = n
+		case strings.HasPrefix(a, "--limit="):
+			n, err := limitValue(strings.TrimPrefix(a, "--limit="))
+			if err != nil {
+				return nil, 0, err
+			}
+			limit = n
+		default:
+			rest = append(rest, a)
+		}
+	}
+	return rest, limit, nil
+}
+
+// limitValue parses a positive limit.
+func limitValue(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid limit %q: expected a positive number", s)
+	}
+	return n, nil
 }
 
 // MachineDirectory returns the machine backup directory path.
@@ -27,27 +135,257 @@ func MachineDirectory() (string, error) {
 	return run("machinedirectory")
 }
 
-// Compare compares the current system to a backup or two paths.
-func Compare(args []string) (string, error) {
-	cmdArgs := []string{"compare"}
-	cmdArgs = append(cmdArgs, args...)
-	return run(cmdArgs...)
-}
-
 // UniqueSize calculates the unique size of a path in backups.
+// args[0] = backup path (required)
+// args[1] = "y" to break the size down by top-level subdirectory (optional;
+// "--breakdown" also accepted). This runs uniquesize once per subdirectory,
+// so it can take a while.
 func UniqueSize(args []string) (string, error) {
-	if len(args) == 0 || args[0] == "" {
-		return "", fmt.Errorf("path is required")
+	list, err := UniqueSizeList(args)
+	if err != nil {
+		return "", err
+	}
+	return list.String(), nil
+}
+
+// UniqueSizeList is UniqueSize returning the breakdown with each entry's
+// size, so it can be re-sorted by size. A single figure is its header.
+func UniqueSizeList(args []string) (SizedList, error) {
+	breakdown := false
+	var rest []string
+	for _, a := range args {
+		if a == "--breakdown" {
+			breakdown = true
+			continue
+		}
+		rest = append(rest, a)
 	}
-	cmdArgs := append([]string{"uniquesize"}, args...)
-	return run(cmdArgs...)
+	if len(rest) == 0 || rest[0] == "" {
+		return SizedList{}, fmt.Errorf("path is required")
+	}
+	if len(rest) > 1 {
+		v, err := yesNo("breakdown", rest[1])
+		if err != nil {
+			return SizedList{}, err
+		}
+		breakdown = breakdown || v
+	}
+	if !breakdown {
+		output, err := run("uniquesize", rest[0])
+		return SizedList{Header: output}, err
+	}
+	return uniqueSizeBreakdown(rest[0])
+}
+
+// uniqueSizeBreakdown runs uniquesize on each entry directly under path and
+// ranks them, largest first, followed by the figure for path itself.
+func uniqueSizeBreakdown(path string) (SizedList, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return SizedList{}, err
+	}
+	list := SizedList{Header: fmt.Sprintf("Unique size by subdirectory of %s\n%s\n", path, Rule(40)), Ranked: true}
+	var failed []string
+	for _, e := range entries {
+		if err := cancelled(currentContext()); err != nil {
+			return SizedList{}, err
+		}
+		label, err := UniqueSizeOf(filepath.Join(path, e.Name()))
+		if errors.Is(err, ErrCancelled) {
+			return SizedList{}, err
+		}
+		if err != nil {
+			failed = append(failed, e.Name())
+			continue
+		}
+		size, ok := parseAbbrevSize(label)
+		if !ok {
+			size = -1
+		}
+		name := e.Name()
+		if e.IsDir() {
+			name += "/"
+		}
+		list.Rows = append(list.Rows, SizedRow{Line: fmt.Sprintf("%10s  %s", label, name), Size: size})
+	}
+	list = list.SortedBySize(true)
+
+	var b strings.Builder
+	if len(list.Rows) == 0 {
+		b.WriteString("  (no entries)\n")
+	}
+	if total, err := UniqueSizeOf(path); err == nil {
+		fmt.Fprintf(&b, "\n  Total: %s\n", total)
+	}
+	if len(failed) > 0 {
+		fmt.Fprintf(&b, "\nCould not size %d of the entries: %s\n", len(failed), strings.Join(failed, ", "))
+	}
+	list.Footer = strings.TrimSuffix(b.String(), "\n")
+	return list, nil
 }
 
 // VerifyChecksums verifies checksums for a path in backups.
 func VerifyChecksums(args []string) (string, error) {
+	return VerifyChecksumsStream(args, nil)
+}
+
+// VerifyChecksumsStream verifies checksums for a path in backups, passing
+// each line of tmutil output to progress as it arrives (progress may be nil).
+// It returns an error listing the corrupted files if any were found.
+func VerifyChecksumsStream(args []string, progress func(string)) (string, error) {
 	if len(args) == 0 || args[0] == "" {
 		return "", fmt.Errorf("path is required")
 	}
-	cmdArgs := append([]string{"verifychecksums"}, args...)
-	return run(cmdArgs...)
+	result, err := VerifyPaths(args, progress)
+	if err != nil {
+		return "", err
+	}
+	return result.Summary()
+}
+
+// VerifyLatestBackup verifies the checksums of the most recent backup and
+// reports a pass/fail summary. Output lines are passed to progress as
+// tmutil produces them (progress may be nil), since verification is slow.
+func VerifyLatestBackup(progress func(line string)) (string, error) {
+	latest, err := LatestBackup()
+	if err != nil {
+		return "", err
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no completed backup found")
+	}
+	return VerifyChecksumsStream([]string{latest}, progress)
+}
+
+// VerifyResult summarizes a verifychecksums run.
+type VerifyResult struct {
+	Paths   []string
+	Checked int      // files reported by tmutil
+	Corrupt []string // "path: status" for each file whose checksum did not match
+}
+
+// VerifyPaths runs verifychecksums on paths and parses the result with
+// parseVerifyOutput, passing each output line to progress as it arrives
+// (progress may be nil). Headers and totals reach progress too but are not
+// counted as files.
+func VerifyPaths(paths []string, progress func(string)) (VerifyResult, error) {
+	cmdArgs := append([]string{"verifychecksums"}, paths...)
+	output, err := runStream(progress, cmdArgs...)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	result := parseVerifyOutput(output)
+	result.Paths = paths
+	return result, nil
+}
+
+// Summary returns a pass summary, or an error listing the corrupted files.
+func (r VerifyResult) Summary() (string, error) {
+	target := strings.Join(r.Paths, ", ")
+	if len(r.Corrupt) == 0 {
+		return fmt.Sprintf("PASS: checked %d file(s) in %s, no corrupted files found.", r.Checked, target), nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "verification failed: %d of %d file(s) in %s corrupted:", len(r.Corrupt), r.Checked, target)
+	for _, c := range r.Corrupt {
+		fmt.Fprintf(&b, "\n  %s", c)
+	}
+	return "", fmt.Errorf("%s", b.String())
+}
+
+// parseVerifyOutput reads the per-file results of verifychecksums, each a
+// path and a status ("/Volumes/Backup/.../file.txt: OK"). Other lines, such
+// as headers and totals, are not counted. Only the status decides whether a
+// file is corrupt, so a healthy file named failed_upload.log passes.
+func parseVerifyOutput(output string) VerifyResult {
+	var r VerifyResult
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		path, status, ok := verifyResultLine(line)
+		if !ok {
+			continue
+		}
+		r.Checked++
+		if !verifyStatusOK(status) {
+			r.Corrupt = append(r.Corrupt, path+": "+status)
+		}
+	}
+	return r
+}
+
+// verifyResultLine splits a result line at its last ": ", since a file name
+// may itself contain one but a status does not.
+func verifyResultLine(line string) (path, status string, ok bool) {
+	if !strings.HasPrefix(line, "/") {
+		return "", "", false
+	}
+	i := strings.LastIndex(line, ": ")
+	if i < 0 {
+		return "", "", false
+	}
+	path, status = line[:i], strings.TrimSpace(line[i+2:])
+	return path, status, status != ""
+}
+
+// verifyStatusOK reports whether a verifychecksums status means the file's
+// checksum matched. Anything else (a mismatch, an unreadable file, a status
+// this version of tmcli does not know) is reported as corrupt.
+func verifyStatusOK(status string) bool {
+	switch strings.ToLower(status) {
+	case "ok", "verified", "passed", "match":
+		return true
+	}
+	return false
+}
+
+// BackupPaths returns the paths of all completed backups, oldest first.
+func BackupPaths() ([]string, error) {
+	return listBackupPaths()
+}
+
+// BackupEntry is one completed backup, as listed by listbackups.
+type BackupEntry struct {
+	Path        string    `json:"path"`
+	Date        time.Time `json:"date"`        // zero when the path carries no date
+	Destination string    `json:"destination"` // the /Volumes mount point the backup is on, or its directory
+}
+
+// ListBackupEntries returns the completed backups, oldest first, with the
+// date and destination of each read from its path. It uses the backup cache
+// like BackupPaths; ListBackups is the command form for display.
+func ListBackupEntries() ([]BackupEntry, error) {
+	paths, err := listBackupPaths()
+	if err != nil {
+		return nil, err
+	}
+	return backupEntries(paths), nil
+}
+
+// backupEntries parses backup paths into entries, in the same order.
+func backupEntries(paths []string) []BackupEntry {
+	entries := make([]BackupEntry, len(paths))
+	for i, p := range paths {
+		entries[i] = BackupEntry{Path: p, Destination: backupVolume(p)}
+		entries[i].Date, _ = parseBackupDate(p)
+	}
+	return entries
+}
+
+// BackupDate returns the date encoded in the final component of a backup path.
+func BackupDate(backupPath string) (time.Time, error) {
+	return parseBackupDate(backupPath)
+}
+
+// UniqueSizeOf returns just the size column reported by tmutil uniquesize
+// for a single backup path (e.g. "1.2G").
+func UniqueSizeOf(path string) (string, error) {
+	output, err := run("uniquesize", path)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("no size reported for %s", path)
+	}
+	return fields[0], nil
 }
@@ -0,0 +1,65 @@
+//
+// browse_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestParseVerifyOutput checks that only per-file results are counted and
+// that a file is corrupt by its status, not by words in its name.
+func TestParseVerifyOutput(t *testing.T) {
+	r := parseVerifyOutput(fixture(t, "verifychecksums.txt"))
+	goldenJSON(t, "verifychecksums.json", r)
+	if r.Checked != 6 {
+		t.Errorf("Checked = %d, want 6", r.Checked)
+	}
+	if len(r.Corrupt) != 2 {
+		t.Errorf("Corrupt = %q, want report.pdf and beach.jpg", r.Corrupt)
+	}
+}
+
+func TestVerifyLatestBackup(t *testing.T) {
+	useFixtures(t, "")
+	_, err := VerifyLatestBackup(nil)
+	if err == nil {
+		t.Fatal("VerifyLatestBackup passed with corrupted files in the fixture")
+	}
+	golden(t, "verifylatest.golden", err.Error()+"\n")
+}
+
+// TestVerifyPaths checks that every line reaches progress as it arrives but
+// only the file results are counted, across several paths.
+func TestVerifyPaths(t *testing.T) {
+	output := "Verifying checksums in /Volumes/Backup/a\n" +
+		"/Volumes/Backup/a/failed_upload.log: OK\n" +
+		"Verifying checksums in /Volumes/Backup/b\n" +
+		"/Volumes/Backup/b/corrupt-test.txt: checksum mismatch\n" +
+		"Checked 2 files: 1 OK, 1 mismatch\n"
+	useTmutil(t, map[string]string{"verifychecksums": output})
+	var lines []string
+	paths := []string{"/Volumes/Backup/a", "/Volumes/Backup/b"}
+	r, err := VerifyPaths(paths, func(line string) { lines = append(lines, line) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.Split(strings.TrimSuffix(output, "\n"), "\n"); !slices.Equal(lines, want) {
+		t.Errorf("progress got %q, want %q", lines, want)
+	}
+	if !slices.Equal(r.Paths, paths) || r.Checked != 2 {
+		t.Errorf("VerifyPaths = %+v, want 2 files checked in %q", r, paths)
+	}
+	if want := []string{"/Volumes/Backup/b/corrupt-test.txt: checksum mismatch"}; !slices.Equal(r.Corrupt, want) {
+		t.Errorf("Corrupt = %q, want %q", r.Corrupt, want)
+	}
+}
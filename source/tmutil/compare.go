@@ -0,0 +1,226 @@
+//
+// compare.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CompareEntry is one path reported by tmutil compare.
+type CompareEntry struct {
+	Path string
+	Size int64 // bytes, parsed from tmutil's abbreviated size; 0 if absent
+}
+
+// CompareResult groups tmutil compare output by kind of change.
+type CompareResult struct {
+	Added   []CompareEntry
+	Removed []CompareEntry
+	Changed []CompareEntry
+}
+
+// Compare compares the current system to a backup or two paths and prints
+// the differences grouped into added, removed and changed files.
+// args[0], args[1] = zero, one or two paths, as for tmutil compare
+// args[2] = backup date YYYY-MM-DD (optional; "--date DATE" also accepted),
+// compared against the newest backup taken that day
+// "--summary" prints only the counts and sizes of each group.
+// "--output FILE" (or "--output=FILE") saves the report to FILE.
+func Compare(args []string) (string, error) {
+	summary := false
+	output := ""
+	date := ""
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--summary":
+			summary = true
+		case a == "--output":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return "", fmt.Errorf("--output requires a file name")
+			}
+			i++
+			output = args[i]
+		case strings.HasPrefix(a, "--output="):
+			output = strings.TrimPrefix(a, "--output=")
+		case a == "--date":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return "", fmt.Errorf("--date requires YYYY-MM-DD")
+			}
+			i++
+			date = args[i]
+		case strings.HasPrefix(a, "--date="):
+			date = strings.TrimPrefix(a, "--date=")
+		default:
+			positional = append(positional, a)
+		}
+	}
+	if len(positional) > 2 {
+		if positional[2] != "" {
+			date = positional[2]
+		}
+		positional = positional[:2]
+	}
+	var paths []string
+	for _, p := range positional {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	if date != "" {
+		if len(paths) > 0 {
+			return "", fmt.Errorf("give either a backup date or paths, not both")
+		}
+		backup, err := backupForDate(date)
+		if err != nil {
+			return "", err
+		}
+		paths = []string{backup}
+	}
+
+	result, err := GetCompare(paths)
+	if err != nil {
+		return "", err
+	}
+	report := result.Summary()
+	if !summary {
+		report = result.Report()
+	}
+	if output == "" {
+		return report, nil
+	}
+	if err := os.WriteFile(output, []byte(report+"\n"), 0o644); err != nil {
+		return "", fmt.Errorf("cannot save report: %w", err)
+	}
+	return fmt.Sprintf("Report saved to %s\n\n%s", output, result.Summary()), nil
+}
+
+// GetCompare runs tmutil compare on paths and parses the result.
+func GetCompare(paths []string) (CompareResult, error) {
+	raw, err := run(append([]string{"compare"}, paths...)...)
+	if err != nil {
+		return CompareResult{}, err
+	}
+	return parseCompare(raw), nil
+}
+
+// parseCompare parses lines such as "+    1.2M    /Users/me/new.txt", where
+// the marker is + (added), - (removed) or ! (changed). tmutil's own totals
+// and separator lines are skipped.
+func parseCompare(raw string) CompareResult {
+	var r CompareResult
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) < 2 || !strings.ContainsRune("+-!", rune(line[0])) || line[1] == '-' {
+			continue
+		}
+		rest := strings.TrimSpace(line[1:])
+		entry := CompareEntry{Path: rest}
+		if f := strings.Fields(rest); len(f) > 1 {
+			if n, ok := parseAbbrevSize(f[0]); ok {
+				entry.Size = n
+				entry.Path = strings.TrimSpace(strings.TrimPrefix(rest, f[0]))
+			}
+		}
+		switch line[0] {
+		case '+':
+			r.Added = append(r.Added, entry)
+		case '-':
+			r.Removed = append(r.Removed, entry)
+		case '!':
+			r.Changed = append(r.Changed, entry)
+		}
+	}
+	return r
+}
+
+// parseAbbrevSize parses tmutil's abbreviated sizes such as "512B", "23.1K"
+// or "1.2G" (binary multiples).
+func parseAbbrevSize(s string) (int64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	mult := 1.0
+	switch s[len(s)-1] {
+	case 'B':
+	case 'K':
+		mult = 1 << 10
+	case 'M':
+		mult = 1 << 20
+	case 'G':
+		mult = 1 << 30
+	case 'T':
+		mult = 1 << 40
+	default:
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s[:len(s)-1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(n * mult), true
+}
+
+func totalSize(entries []CompareEntry) int64 {
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	return total
+}
+
+// Summary returns only the count and size of each group.
+func (r CompareResult) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Added:    %6d file(s)  %s\n", len(r.Added), FormatBytesInt64(totalSize(r.Added)))
+	fmt.Fprintf(&b, "Removed:  %6d file(s)  %s\n", len(r.Removed), FormatBytesInt64(totalSize(r.Removed)))
+	fmt.Fprintf(&b, "Changed:  %6d file(s)  %s\n", len(r.Changed), FormatBytesInt64(totalSize(r.Changed)))
+	total := len(r.Added) + len(r.Removed) + len(r.Changed)
+	bytes := totalSize(r.Added) + totalSize(r.Removed) + totalSize(r.Changed)
+	fmt.Fprintf(&b, "Total:    %6d file(s)  %s", total, FormatBytesInt64(bytes))
+	return b.String()
+}
+
+// Report renders the full result, diff-style: each group under a header
+// with its count and size, one "+", "-" or "!" line per path, and the
+// summary at the end.
+func (r CompareResult) Report() string {
+	var b strings.Builder
+	b.WriteString("Compare Report\n")
+	b.WriteString(Rule(40) + "\n")
+	groups := []struct {
+		title  string
+		marker string
+		items  []CompareEntry
+	}{
+		{"Added", "+", r.Added},
+		{"Removed", "-", r.Removed},
+		{"Changed", "!", r.Changed},
+	}
+	for _, g := range groups {
+		if len(g.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s (%d, %s)\n", g.title, len(g.items), FormatBytesInt64(totalSize(g.items)))
+		for _, e := range g.items {
+			fmt.Fprintf(&b, "%s %10s  %s\n", g.marker, FormatBytesInt64(e.Size), e.Path)
+		}
+	}
+	if len(r.Added)+len(r.Removed)+len(r.Changed) == 0 {
+		b.WriteString("\nNo differences.\n")
+	}
+	b.WriteString("\n" + r.Summary())
+	return b.String()
+}
@@ -0,0 +1,214 @@
+//
+// coverage.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dayLayout keys backups by local calendar day.
+const dayLayout = "2006-01-02"
+
+// CoverageRun is a stretch of days on which only one of two destinations,
+// or both, were backed up.
+type CoverageRun struct {
+	Side     int // 0 or 1: only that destination has backups; 2: both do
+	From, To time.Time
+	Days     int // backup days in the run
+}
+
+// Span is the number of calendar days from the run's first day to its last.
+func (r CoverageRun) Span() int {
+	return int(r.To.Sub(r.From).Hours()/24+0.5) + 1
+}
+
+// Coverage compares the backups of two destinations by day.
+type Coverage struct {
+	Names [2]string
+	Dates [2][]time.Time // backup times from the preferences, oldest first
+	Runs  []CoverageRun
+}
+
+// GetCoverage reads both destinations' backup dates from the preferences
+// plist, which has them even for disconnected disks, and groups the days
+// into runs covered by one or both.
+func GetCoverage(a, b string) (Coverage, error) {
+	var c Coverage
+	prefs, err := GetDestinationPrefs()
+	if err != nil {
+		return c, err
+	}
+	names := make(map[string]string)
+	if dests, err := ListDestinations(); err == nil {
+		for _, d := range dests {
+			names[strings.ToUpper(d.ID)] = d.Name
+		}
+	}
+	for i, id := range []string{a, b} {
+		found := false
+		for _, p := range prefs {
+			if strings.EqualFold(p.ID, id) {
+				c.Dates[i] = p.SnapshotDates
+				found = true
+			}
+		}
+		if !found {
+			return c, fmt.Errorf("destination %s is not in the Time Machine preferences", id)
+		}
+		c.Names[i] = names[strings.ToUpper(id)]
+		if c.Names[i] == "" {
+			c.Names[i] = id
+		}
+	}
+	c.Runs = coverageRuns(c.Dates)
+	return c, nil
+}
+
+// coverageRuns merges the two sets of backup days, in order, into runs of
+// the same side.
+func coverageRuns(dates [2][]time.Time) []CoverageRun {
+	sides := make(map[string]int)
+	for i, ds := range dates {
+		for _, t := range ds {
+			day := t.Local().Format(dayLayout)
+			if s, ok := sides[day]; ok && s != i {
+				sides[day] = 2
+			} else {
+				sides[day] = i
+			}
+		}
+	}
+	days := make([]string, 0, len(sides))
+	for d := range sides {
+		days = append(days, d)
+	}
+	sort.Strings(days)
+
+	var runs []CoverageRun
+	for _, d := range days {
+		t, _ := time.ParseInLocation(dayLayout, d, time.Local)
+		if n := len(runs); n > 0 && runs[n-1].Side == sides[d] {
+			runs[n-1].To = t
+			runs[n-1].Days++
+			continue
+		}
+		runs = append(runs, CoverageRun{Side: sides[d], From: t, To: t, Days: 1})
+	}
+	return runs
+}
+
+// CoverageDiff reports which backup days exist on one of two destinations
+// but not the other, for keeping both disks of a rotation current.
+// args[0], args[1] = destination IDs; optional when exactly two
+// destinations are configured.
+func CoverageDiff(args []string) (string, error) {
+	var ids []string
+	for _, a := range args {
+		if a != "" {
+			ids = append(ids, a)
+		}
+	}
+	if len(ids) == 0 {
+		all, err := DestinationIDs()
+		if err != nil {
+			return "", err
+		}
+		if len(all) != 2 {
+			return "", fmt.Errorf("found %d destination(s); give the IDs of the two to compare", len(all))
+		}
+		ids = all
+	}
+	if len(ids) != 2 {
+		return "", fmt.Errorf("two destination IDs are required")
+	}
+	if strings.EqualFold(ids[0], ids[1]) {
+		return "", fmt.Errorf("give two different destinations")
+	}
+	c, err := GetCoverage(ids[0], ids[1])
+	if err != nil {
+		return "", err
+	}
+	return formatCoverage(c, time.Now()), nil
+}
+
+func formatCoverage(c Coverage, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Backup Coverage: %s vs %s\n", c.Names[0], c.Names[1])
+	b.WriteString(Rule(60) + "\n\n")
+
+	rows := [][]string{{"", c.Names[0], c.Names[1]}}
+	counts, oldest, newest := []string{"Backups"}, []string{"Oldest"}, []string{"Newest"}
+	for _, ds := range c.Dates {
+		counts = append(counts, fmt.Sprint(len(ds)))
+		if len(ds) == 0 {
+			oldest, newest = append(oldest, "-"), append(newest, "never backed up")
+			continue
+		}
+		oldest = append(oldest, FormatTimeShort(ds[0].Local()))
+		newest = append(newest, FormatTimeShort(ds[len(ds)-1].Local()))
+	}
+	rows = append(rows, counts, oldest, newest)
+	for _, line := range strings.Split(alignColumns(rows), "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+
+	if len(c.Runs) == 0 {
+		b.WriteString("\nNeither destination has a recorded backup.")
+		return b.String()
+	}
+
+	// Runs on one side only are gaps on the other; "!" marks them.
+	b.WriteString("\nDays with backups, oldest first (! = missing on one disk):\n")
+	var longest *CoverageRun
+	for i := range c.Runs {
+		r := &c.Runs[i]
+		span := r.From.Format(dayLayout)
+		if r.Span() > 1 {
+			span += " to " + r.To.Format(dayLayout)
+		}
+		if r.Side == 2 {
+			fmt.Fprintf(&b, "    %-26s both (%d day(s))\n", span, r.Days)
+			continue
+		}
+		fmt.Fprintf(&b, "  ! %-26s only %s (%d day(s)); %s has none\n", span, c.Names[r.Side], r.Days, c.Names[1-r.Side])
+		if longest == nil || r.Span() > longest.Span() {
+			longest = r
+		}
+	}
+
+	if longest != nil {
+		fmt.Fprintf(&b, "\nLongest gap: %s has no backup from %s to %s (%d day(s)), while %s does.",
+			c.Names[1-longest.Side], longest.From.Format(dayLayout), longest.To.Format(dayLayout), longest.Span(), c.Names[longest.Side])
+	} else {
+		b.WriteString("\nBoth disks have backups on every day either one does.")
+	}
+	// How far the staler disk lags behind the fresher one.
+	var last [2]time.Time
+	for i, ds := range c.Dates {
+		if len(ds) > 0 {
+			last[i] = ds[len(ds)-1]
+		}
+	}
+	behind := 0
+	if last[1].Before(last[0]) {
+		behind = 1
+	}
+	switch lag := last[1-behind].Sub(last[behind]); {
+	case last[behind].IsZero():
+		fmt.Fprintf(&b, "\n%s has never been backed up.", c.Names[behind])
+	case lag >= 24*time.Hour:
+		fmt.Fprintf(&b, "\n%s is %d day(s) behind %s (%s).", c.Names[behind], int(lag.Hours()/24), c.Names[1-behind], backupAge(last[behind], now))
+	}
+	return b.String()
+}
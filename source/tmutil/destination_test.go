@@ -0,0 +1,61 @@
+//
+// destination_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDestinationReachableAnyOfSeveral(t *testing.T) {
+	mounted := t.TempDir()
+	useTmutil(t, map[string]string{"destinationinfo": `====================================================
+Name          : Away
+Kind          : Local
+ID            : 11111111-1111-1111-1111-111111111111
+====================================================
+Name          : Desk
+Kind          : Local
+Mount Point   : ` + mounted + `
+ID            : 22222222-2222-2222-2222-222222222222
+`})
+	ok, why := DestinationReachable()
+	if !ok || !strings.HasPrefix(why, "Desk: ") {
+		t.Errorf("DestinationReachable() = %v, %q; want Desk reachable", ok, why)
+	}
+	dests, err := ListDestinations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if why, refused := noLocalReachable(dests); refused {
+		t.Errorf("StartBackup would refuse: %s", why)
+	}
+}
+
+func TestDestinationReachableNone(t *testing.T) {
+	useFixtures(t, "multi")
+	ok, why := DestinationReachable()
+	want := "Backup: /Volumes/Backup is not mounted; TimeMachine: network share is not mounted"
+	if ok || why != want {
+		t.Errorf("DestinationReachable() = %v, %q; want false, %q", ok, why, want)
+	}
+	dests, err := ListDestinations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Time Machine mounts the share itself, so the backup is left to it.
+	if why, refused := noLocalReachable(dests); refused {
+		t.Errorf("StartBackup would refuse with a network destination: %s", why)
+	}
+	if why, refused := noLocalReachable(dests[:1]); !refused || why != "Backup: /Volumes/Backup is not mounted" {
+		t.Errorf("noLocalReachable(local only) = %q, %v; want a refusal", why, refused)
+	}
+}
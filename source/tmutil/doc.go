@@ -0,0 +1,26 @@
+//
+// doc.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+// Package tmutil wraps the macOS tmutil utility and the Time Machine
+// preferences plist. It has no UI dependencies and can be imported by
+// other Go programs (for example a menu-bar app).
+//
+// Two kinds of functions are exported:
+//
+//   - Data accessors return structured values: GetStatus, GetBackupPrefs,
+//     GetDestinationInfo, BackupPaths, and the aggregate GetOverview.
+//   - Command functions (StartBackup, SetQuota, FindFile, ...) take the
+//     CLI-style argument slice used by the tmcli front end and return
+//     human-readable text.
+//
+// Errors from tmutil itself carry its combined output. Conditions callers
+// commonly need to distinguish are reported with sentinel errors such as
+// ErrNoBackups; test for them with errors.Is.
+package tmutil
@@ -0,0 +1,275 @@
+//
+// doctor.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// CheckStatus is the outcome of a single doctor check.
+type CheckStatus int
+
+const (
+	CheckPass CheckStatus = iota
+	CheckWarn
+	CheckFail
+)
+
+// String returns the label shown in the checklist.
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckPass:
+		return "PASS"
+	case CheckWarn:
+		return "WARN"
+	}
+	return "FAIL"
+}
+
+// MarshalText writes the status as its label, e.g. "WARN", in JSON.
+func (s CheckStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText reads a label written by MarshalText.
+func (s *CheckStatus) UnmarshalText(text []byte) error {
+	for _, c := range []CheckStatus{CheckPass, CheckWarn, CheckFail} {
+		if string(text) == c.String() {
+			*s = c
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown check status %q", text)
+}
+
+// Check is one line of the doctor checklist.
+type Check struct {
+	Name   string
+	Status CheckStatus
+	Detail string
+}
+
+// Thresholds used by the doctor checks.
+const (
+	backupAgeWarn = 24 * time.Hour
+	backupAgeFail = 7 * 24 * time.Hour
+	freeSpaceWarn = 10 // percent of the boot volume
+	freeSpaceFail = 5
+)
+
+// Diagnose runs a battery of read-only checks on the Time Machine setup.
+func Diagnose() []Check {
+	var checks []Check
+	add := func(name string, status CheckStatus, format string, a ...any) {
+		checks = append(checks, Check{Name: name, Status: status, Detail: fmt.Sprintf(format, a...)})
+	}
+
+	if path, err := exec.LookPath(binary()); err != nil {
+		add("tmutil present", CheckFail, "%s not found", binary())
+	} else {
+		add("tmutil present", CheckPass, "%s", path)
+	}
+
+	dests, err := ListDestinations()
+	switch {
+	case err != nil && !strings.Contains(strings.ToLower(err.Error()), "no destinations"):
+		add("Destination configured", CheckFail, "cannot read destinations: %v", err)
+	case len(dests) == 0:
+		add("Destination configured", CheckFail, "no destination is configured")
+	default:
+		add("Destination configured", CheckPass, "%d destination(s), current: %s", len(dests), dests[0].Name)
+		if ok, why := DestinationReachable(); ok {
+			add("Destination reachable", CheckPass, "%s", why)
+		} else {
+			add("Destination reachable", CheckWarn, "%s", why)
+		}
+	}
+
+	prefs, prefsErr := GetBackupPrefs()
+	switch {
+	case errors.Is(prefsErr, ErrNotConfigured):
+		add("Automatic backups", CheckWarn, "Time Machine has never been configured")
+	case prefsErr != nil:
+		add("Automatic backups", CheckWarn, "cannot read Time Machine preferences: %v", prefsErr)
+	case prefs.AutoBackupSet && prefs.AutoBackup:
+		add("Automatic backups", CheckPass, "enabled")
+	default:
+		add("Automatic backups", CheckWarn, "disabled")
+	}
+
+	last := lastBackupTime(prefs, prefsErr)
+	age := time.Since(last)
+	switch {
+	case last.IsZero():
+		add("Last backup", CheckFail, "no completed backup recorded")
+	case age > backupAgeFail:
+		add("Last backup", CheckFail, "%s ago (%s)", FormatDuration(age), FormatTimeShort(last.Local()))
+	case age > backupAgeWarn:
+		add("Last backup", CheckWarn, "%s ago (%s)", FormatDuration(age), FormatTimeShort(last.Local()))
+	default:
+		add("Last backup", CheckPass, "%s ago (%s)", FormatDuration(age), FormatTimeShort(last.Local()))
+	}
+	if prefsErr == nil {
+		checks = append(checks, attemptCheck(prefs))
+	}
+
+	checks = append(checks, snapshotPressure())
+	return checks
+}
+
+// lastBackupTime is when the last backup completed, or zero when none is
+// recorded. It prefers the plist, which works without the disk, and falls
+// back to tmutil.
+func lastBackupTime(prefs BackupPrefs, prefsErr error) time.Time {
+	var last time.Time
+	if prefsErr == nil {
+		last = prefs.LastSnapshot()
+	}
+	if last.IsZero() {
+		if latest, err := LatestBackup(); err == nil && latest != "" {
+			last, _ = parseBackupDate(latest)
+		}
+	}
+	return last
+}
+
+// BackupHealth is a single verdict on the backups for monitoring agents:
+// whether a destination is configured and reachable, and how old the last
+// backup is.
+type BackupHealth struct {
+	Status CheckStatus `json:"status"`        // CheckWarn when stale, CheckFail when failing or unreachable
+	Last   time.Time   `json:"last,omitzero"` // last completed backup; zero when none is recorded
+	Detail string      `json:"detail"`
+}
+
+// Label names the verdict as tmcli check -v prints it.
+func (h BackupHealth) Label() string {
+	switch h.Status {
+	case CheckPass:
+		return "OK"
+	case CheckWarn:
+		return "STALE"
+	}
+	return "FAILING"
+}
+
+// GetBackupHealth derives a BackupHealth from the doctor's destination,
+// last backup and backup attempt checks. A backup older than stale warns
+// and one older than failing fails; zero uses the doctor's limits of a day
+// and a week. Several failed attempts in a row warn however recent the
+// last backup is.
+func GetBackupHealth(stale, failing time.Duration) BackupHealth {
+	if stale <= 0 {
+		stale = backupAgeWarn
+	}
+	if failing <= 0 {
+		failing = backupAgeFail
+	}
+	var h BackupHealth
+	dests, err := ListDestinations()
+	switch {
+	case err != nil && !strings.Contains(strings.ToLower(err.Error()), "no destinations"):
+		h.Status, h.Detail = CheckFail, fmt.Sprintf("cannot read destinations: %v", err)
+		return h
+	case len(dests) == 0:
+		h.Status, h.Detail = CheckFail, "no destination is configured"
+		return h
+	}
+
+	prefs, prefsErr := GetBackupPrefs()
+	h.Last = lastBackupTime(prefs, prefsErr)
+	age := time.Since(h.Last)
+	if h.Last.IsZero() {
+		h.Status, h.Detail = CheckFail, "no completed backup recorded"
+	} else {
+		h.Detail = fmt.Sprintf("last backup %s ago (%s)", FormatDuration(age), FormatTimeShort(h.Last.Local()))
+		switch {
+		case age > failing:
+			h.Status = CheckFail
+		case age > stale:
+			h.Status = CheckWarn
+		}
+	}
+	if prefsErr == nil {
+		if streak := failStreak(attemptsFrom(prefs)); streak >= attemptFailStreak {
+			h.Status = max(h.Status, CheckWarn)
+			h.Detail += fmt.Sprintf("; the last %d backup attempts failed", streak)
+		}
+	}
+	if ok, why := DestinationReachable(); !ok {
+		h.Status = CheckFail
+		h.Detail += "; destination unreachable: " + why
+	}
+	return h
+}
+
+// snapshotPressure checks free space on the boot volume, where local
+// snapshots hold on to deleted data until macOS thins them.
+func snapshotPressure() Check {
+	c := Check{Name: "Local snapshot disk pressure"}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs("/", &st); err != nil || st.Blocks == 0 {
+		c.Status = CheckWarn
+		c.Detail = "cannot read free space on /"
+		return c
+	}
+	free := int64(st.Bavail) * int64(st.Bsize)
+	pct := int(st.Bavail * 100 / st.Blocks)
+
+	snapshots := 0
+	if counts, err := CountLocalSnapshots(); err == nil {
+		for _, v := range counts {
+			snapshots += v.Count
+		}
+	}
+
+	c.Detail = fmt.Sprintf("%d%% free (%s) on /, %d local snapshot(s)", pct, FormatBytesInt64(free), snapshots)
+	switch {
+	case pct < freeSpaceFail:
+		c.Status = CheckFail
+	case pct < freeSpaceWarn:
+		c.Status = CheckWarn
+	}
+	return c
+}
+
+// Worst returns the most severe status among checks.
+func Worst(checks []Check) CheckStatus {
+	worst := CheckPass
+	for _, c := range checks {
+		if c.Status > worst {
+			worst = c.Status
+		}
+	}
+	return worst
+}
+
+// FormatChecks renders checks as a checklist with an overall verdict.
+func FormatChecks(checks []Check) string {
+	var b strings.Builder
+	b.WriteString("Time Machine Doctor\n")
+	b.WriteString(Rule(40) + "\n\n")
+	for _, c := range checks {
+		fmt.Fprintf(&b, "  [%s] %-30s %s\n", c.Status, c.Name, c.Detail)
+	}
+	fmt.Fprintf(&b, "\nOverall: %s", Worst(checks))
+	return b.String()
+}
+
+// Doctor runs Diagnose and returns the checklist.
+func Doctor() (string, error) {
+	return FormatChecks(Diagnose()), nil
+}
@@ -0,0 +1,162 @@
+//
+// drift.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// driftBarWidth is the width of the largest bar in the drift chart.
+const driftBarWidth = 30
+
+// DriftEntry is the drift between two consecutive backups: how much data
+// was added, removed and changed from Start to End.
+type DriftEntry struct {
+	Start   time.Time
+	End     time.Time
+	Added   int64
+	Removed int64
+	Changed int64
+}
+
+// Total is the entry's overall drift.
+func (d DriftEntry) Total() int64 {
+	return d.Added + d.Removed + d.Changed
+}
+
+// GetDrift runs calculatedrift on a machine directory and returns one entry
+// per pair of consecutive backups, oldest first.
+func GetDrift(machineDir string) ([]DriftEntry, error) {
+	raw, err := run("calculatedrift", machineDir)
+	if err != nil {
+		return nil, err
+	}
+	return parseDrift(raw), nil
+}
+
+// parseDrift reads calculatedrift output: blocks of "Starting time:",
+// "Ending time:", "Added:", "Removed:" and "Changed:" lines separated by
+// dashes, followed by a "Drift Averages" block, which is skipped.
+func parseDrift(raw string) []DriftEntry {
+	var entries []DriftEntry
+	var cur *DriftEntry
+	for _, line := range strings.Split(raw, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			if strings.HasPrefix(strings.TrimSpace(line), "Drift Averages") {
+				break
+			}
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Starting time":
+			entries = append(entries, DriftEntry{})
+			cur = &entries[len(entries)-1]
+			cur.Start, _ = time.ParseInLocation(backupPathDateLayout, value, time.Local)
+		case "Ending time":
+			if cur != nil {
+				cur.End, _ = time.ParseInLocation(backupPathDateLayout, value, time.Local)
+			}
+		case "Added", "Removed", "Changed":
+			if cur == nil {
+				continue
+			}
+			n, _ := parseAbbrevSize(value)
+			switch key {
+			case "Added":
+				cur.Added = n
+			case "Removed":
+				cur.Removed = n
+			default:
+				cur.Changed = n
+			}
+		}
+	}
+	return entries
+}
+
+// CalculateDrift shows how much changed between each pair of backups in a
+// machine directory, as a bar chart with the largest drifts listed first.
+// args[0] = machine directory (required)
+// With "--raw" tmutil's own output is shown instead.
+func CalculateDrift(args []string) (string, error) {
+	raw := false
+	var rest []string
+	for _, a := range args {
+		if a == "--raw" {
+			raw = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	if len(rest) == 0 || rest[0] == "" {
+		return "", fmt.Errorf("machine directory is required")
+	}
+	if raw {
+		return run("calculatedrift", rest[0])
+	}
+	entries, err := GetDrift(rest[0])
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "No drift to report; the machine directory needs at least two backups.", nil
+	}
+	return formatDrift(entries), nil
+}
+
+func formatDrift(entries []DriftEntry) string {
+	var largest int64
+	for _, e := range entries {
+		largest = max(largest, e.Total())
+	}
+
+	var b strings.Builder
+	b.WriteString("Backup Drift\n")
+	b.WriteString(Rule(60) + "\n\n")
+	for _, e := range entries {
+		filled := 0
+		if largest > 0 {
+			filled = int(float64(driftBarWidth) * float64(e.Total()) / float64(largest))
+		}
+		if filled == 0 && e.Total() > 0 {
+			filled = 1
+		}
+		fmt.Fprintf(&b, "  %-19s  %s%s  %s\n", driftWhen(e.End), BarFull(filled),
+			strings.Repeat(" ", driftBarWidth-filled), FormatBytesInt64(e.Total()))
+	}
+
+	ranked := append([]DriftEntry(nil), entries...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Total() > ranked[j].Total() })
+	if len(ranked) > 5 {
+		ranked = ranked[:5]
+	}
+	b.WriteString("\nLargest drift\n\n")
+	fmt.Fprintf(&b, "  %-19s  %10s  %10s  %10s  %10s\n", "Backup", "Total", "Added", "Removed", "Changed")
+	for _, e := range ranked {
+		fmt.Fprintf(&b, "  %-19s  %10s  %10s  %10s  %10s\n", driftWhen(e.End),
+			FormatBytesInt64(e.Total()), FormatBytesInt64(e.Added), FormatBytesInt64(e.Removed), FormatBytesInt64(e.Changed))
+	}
+	b.WriteString("\nEach figure is the change since the previous backup.")
+	return b.String()
+}
+
+// driftWhen labels an entry by the backup it ends at.
+func driftWhen(t time.Time) string {
+	if t.IsZero() {
+		return "unknown date"
+	}
+	return FormatTime(t)
+}
@@ -10,20 +10,67 @@
 
 package tmutil
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
 
-// AddExclusion adds a fixed-path exclusion for an item.
+// AddExclusion excludes an item from backups.
+// args[0] = path; "-p" (fixed path) or "-v" (whole volume) may appear
+// anywhere and is passed ahead of the path.
+// "--from-file F" (or "--from-file=F") adds every path listed in F instead,
+// one per line, carrying on past failures and reporting each.
 func AddExclusion(args []string) (string, error) {
-	if len(args) == 0 || args[0] == "" {
+	var flags, paths []string
+	fromFile := ""
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "-p", a == "-v":
+			flags = append(flags, a)
+		case a == "":
+		case a == "--from-file":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return "", fmt.Errorf("--from-file requires a file name")
+			}
+			i++
+			fromFile = args[i]
+		case strings.HasPrefix(a, "--from-file="), strings.HasPrefix(a, "--from-file "):
+			// The TUI passes "--from-file F" typed in the path field as one.
+			fromFile = strings.TrimSpace(a[len("--from-file="):])
+		default:
+			paths = append(paths, a)
+		}
+	}
+	if len(flags) > 1 {
+		return "", fmt.Errorf("choose either a fixed-path (-p) or a volume (-v) exclusion, not both")
+	}
+	if fromFile != "" {
+		if len(paths) > 0 {
+			return "", fmt.Errorf("give either paths or --from-file, not both")
+		}
+		list, err := readPathList(fromFile)
+		if err != nil {
+			return "", err
+		}
+		return runBatch("Added", "exclusion(s)", list, func(path string) error {
+			_, err := run(append(append([]string{"addexclusion"}, flags...), path)...)
+			return err
+		})
+	}
+	if len(paths) == 0 {
 		return "", fmt.Errorf("path is required")
 	}
-	cmdArgs := append([]string{"addexclusion"}, args...)
+	cmdArgs := append(append([]string{"addexclusion"}, flags...), paths...)
 	output, err := run(cmdArgs...)
 	if err != nil {
 		return "", err
 	}
 	if output == "" {
-		return fmt.Sprintf("Exclusion added for %s.", args[0]), nil
+		return fmt.Sprintf("Exclusion added for %s.", paths[0]), nil
 	}
 	return output, nil
 }
@@ -52,3 +99,124 @@ func IsExcluded(args []string) (string, error) {
 	cmdArgs := append([]string{"isexcluded"}, args...)
 	return run(cmdArgs...)
 }
+
+// ListExclusions lists what Time Machine skips: the fixed-path exclusions
+// and the sticky ones, with their kind.
+// args[0] = "y" to measure each excluded path and total them (optional;
+// "--sizes" also accepted). This runs du over every path, so it is slow.
+func ListExclusions(args []string) (string, error) {
+	return ListExclusionsStream(args, nil)
+}
+
+// ListExclusionsStream is ListExclusions reporting which path it is
+// measuring through progress (which may be nil).
+func ListExclusionsStream(args []string, progress func(string)) (string, error) {
+	sizes := false
+	for i, a := range args {
+		if a == "--sizes" {
+			sizes = true
+			continue
+		}
+		if i == 0 {
+			v, err := yesNo("sizes", a)
+			if err != nil {
+				return "", err
+			}
+			sizes = sizes || v
+			continue
+		}
+		return "", fmt.Errorf("unexpected argument %q", a)
+	}
+	excluded, err := GetExclusions()
+	if err != nil {
+		return "", err
+	}
+	var missing []bool
+	for _, e := range excluded {
+		_, err := os.Lstat(e.Path)
+		missing = append(missing, errors.Is(err, fs.ErrNotExist))
+	}
+	if sizes {
+		ctx := currentContext()
+		for i, e := range excluded {
+			if err := cancelled(ctx); err != nil {
+				return "", err
+			}
+			if missing[i] {
+				continue
+			}
+			if progress != nil {
+				progress(fmt.Sprintf("Measuring %d of %d: %s", i+1, len(excluded), e.Path))
+			}
+			if n, err := liveBytes(e.Path); err == nil {
+				excluded[i].Size = n
+			}
+		}
+	}
+	return formatExclusions(excluded, missing, sizes), nil
+}
+
+// formatExclusions lays out the exclusions, with their sizes and a total
+// when measured. A path inside another excluded path is not added to the
+// total again.
+func formatExclusions(excluded []ExcludedPath, missing []bool, sizes bool) string {
+	if len(excluded) == 0 {
+		return "Nothing is excluded: backups include everything."
+	}
+	var b strings.Builder
+	b.WriteString("Backup Exclusions\n")
+	b.WriteString(Rule(60) + "\n\n")
+	var total int64
+	gone, unmeasured := 0, 0
+	rows := make([][]string, 0, len(excluded))
+	for i, e := range excluded {
+		row := []string{e.Kind, e.Path}
+		switch {
+		case missing[i]:
+			gone++
+			row = append([]string{"missing"}, row...)
+		case !sizes:
+			row = append([]string{""}, row...)
+		case e.Size < 0:
+			unmeasured++
+			row = append([]string{"unreadable"}, row...)
+		default:
+			row = append([]string{FormatBytesInt64(e.Size)}, row...)
+			if !insideExclusion(e.Path, excluded[:i]) {
+				total += e.Size
+			}
+		}
+		rows = append(rows, row)
+	}
+	if !sizes && gone == 0 {
+		for i := range rows {
+			rows[i] = rows[i][1:]
+		}
+	}
+	for _, line := range strings.Split(alignColumns(rows), "\n") {
+		b.WriteString(strings.TrimRight("  "+line, " ") + "\n")
+	}
+	b.WriteString("\n")
+	if sizes {
+		fmt.Fprintf(&b, "%d exclusion(s), %s not backed up.", len(excluded), FormatBytesInt64(total))
+		if unmeasured > 0 {
+			fmt.Fprintf(&b, "\n%d path(s) could not be measured and are not counted; run with sudo to read them.", unmeasured)
+		}
+	} else {
+		fmt.Fprintf(&b, "%d exclusion(s). Measure sizes (--sizes) to see how much they hold; this is slow.", len(excluded))
+	}
+	if gone > 0 {
+		fmt.Fprintf(&b, "\n%d excluded path(s) no longer exist; remove them with Remove Exclusion.", gone)
+	}
+	return b.String()
+}
+
+// insideExclusion reports whether path is under one of the excluded paths.
+func insideExclusion(path string, excluded []ExcludedPath) bool {
+	for _, x := range excluded {
+		if strings.HasPrefix(path, strings.TrimSuffix(x.Path, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
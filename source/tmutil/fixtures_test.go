@@ -0,0 +1,115 @@
+//
+// fixtures_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// update rewrites the golden files from the current output:
+//
+//	go test ./tmutil -update
+var update = flag.Bool("update", false, "rewrite testdata/golden from the current output")
+
+// useFixtures makes tmutil, defaults and mount replay testdata: tmutil
+// through fake-tmutil with the given TMCLI_FIXTURE variant ("" for none),
+// the preferences from prefs-multi.plist and the mount table from
+// mount.txt. Times are shown in UTC.
+func useFixtures(t *testing.T, variant string) {
+	t.Helper()
+	dir, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := t.TempDir()
+	mount := "#!/bin/sh\ncat " + ShellQuote(filepath.Join(dir, "mount.txt")) + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "mount"), []byte(mount), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("TMCLI_TMUTIL", filepath.Join(dir, "fake-tmutil"))
+	t.Setenv("TMCLI_FIXTURE", variant)
+	t.Setenv("TMCLI_PREFS", filepath.Join(dir, "prefs-multi.plist"))
+	useZone(t, time.UTC)
+}
+
+// useTmutil makes tmutil print outputs[subcommand], or nothing for a
+// subcommand not in outputs.
+func useTmutil(t *testing.T, outputs map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for cmd, out := range outputs {
+		if err := os.WriteFile(filepath.Join(dir, cmd+".txt"), []byte(out), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	script := "#!/bin/sh\nf=" + ShellQuote(dir) + "/$1.txt\n[ -f \"$f\" ] && cat \"$f\"\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(dir, "tmutil"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TMCLI_TMUTIL", filepath.Join(dir, "tmutil"))
+}
+
+// useZone sets time.Local to loc for the rest of the test.
+func useZone(t *testing.T, loc *time.Location) {
+	t.Helper()
+	old := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = old })
+}
+
+// fixture returns the contents of testdata/name.
+func fixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// golden compares got with testdata/golden/name, or rewrites the file
+// with -update.
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from the golden file\n--- got\n%s\n--- want\n%s", name, got, want)
+	}
+}
+
+// goldenJSON is golden for v rendered as indented JSON.
+func goldenJSON(t *testing.T, name string, v any) {
+	t.Helper()
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, name, string(data)+"\n")
+}
@@ -0,0 +1,101 @@
+//
+// fleet.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostHealth is the BackupHealth of one Mac in a fleet check.
+type HostHealth struct {
+	Host string `json:"host"`
+	BackupHealth
+}
+
+// fleetWorkers is how many Macs CheckFleet checks at once.
+const fleetWorkers = 8
+
+// DefaultHostTimeout is how long CheckFleet gives each Mac by default.
+const DefaultHostTimeout = time.Minute
+
+// ReadHosts reads the Macs to check from a file, one ssh destination such
+// as admin@studio.local per line; blank lines and # comments are skipped.
+func ReadHosts(name string) ([]string, error) {
+	return readPathList(name)
+}
+
+// CheckFleet runs check against every host, fleetWorkers at a time, each
+// under a context that ends after timeout. A host whose check fails to
+// run, or runs out of time, is failing with the reason as its detail. The
+// results are in the order of hosts.
+func CheckFleet(hosts []string, timeout time.Duration, check func(ctx context.Context, host string) (BackupHealth, error)) []HostHealth {
+	results := make([]HostHealth, len(hosts))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(fleetWorkers, len(hosts)) {
+		wg.Go(func() {
+			for i := range jobs {
+				ctx, cancel := context.WithTimeout(currentContext(), timeout)
+				h, err := check(ctx, hosts[i])
+				switch {
+				case errors.Is(ctx.Err(), context.DeadlineExceeded):
+					h = BackupHealth{Status: CheckFail, Detail: "no answer within " + FormatDuration(timeout)}
+				case err != nil:
+					h = BackupHealth{Status: CheckFail, Detail: err.Error()}
+				}
+				cancel()
+				results[i] = HostHealth{Host: hosts[i], BackupHealth: h}
+			}
+		})
+	}
+	for i := range hosts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// WorstHost returns the most severe status among results.
+func WorstHost(results []HostHealth) CheckStatus {
+	worst := CheckPass
+	for _, r := range results {
+		worst = max(worst, r.Status)
+	}
+	return worst
+}
+
+// FormatFleet renders results as a table of each host, its verdict and the
+// age of its last backup, followed by a count of each verdict.
+func FormatFleet(results []HostHealth) string {
+	rows := [][]string{{"HOST", "HEALTH", "LAST BACKUP", "DETAIL"}}
+	counts := map[CheckStatus]int{}
+	for _, r := range results {
+		age := "-"
+		if !r.Last.IsZero() {
+			age = FormatDuration(time.Since(r.Last)) + " ago"
+		}
+		rows = append(rows, []string{r.Host, r.Label(), age, r.Detail})
+		counts[r.Status]++
+	}
+	var summary []string
+	for _, s := range []CheckStatus{CheckPass, CheckWarn, CheckFail} {
+		if counts[s] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[s], strings.ToLower(BackupHealth{Status: s}.Label())))
+		}
+	}
+	return alignColumns(rows) + fmt.Sprintf("\n\n%d host(s): %s", len(results), strings.Join(summary, ", "))
+}
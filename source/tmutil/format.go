@@ -0,0 +1,286 @@
+//
+// format.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Output formats accepted by "--output".
+const (
+	FormatPlain = "plain" // the command's usual human-readable output
+	FormatTable = "table" // aligned columns
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// parseOutputFormat removes "--output F" or "--output=F" from args and
+// returns the remaining args and the format, "" when absent. "--json" is
+// kept as shorthand for "--output json".
+func parseOutputFormat(args []string) ([]string, string, error) {
+	var rest []string
+	format := ""
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--json":
+			format = FormatJSON
+		case a == "--output":
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("--output requires a format (table, plain, json or yaml)")
+			}
+			i++
+			format = args[i]
+		case strings.HasPrefix(a, "--output="):
+			format = strings.TrimPrefix(a, "--output=")
+		default:
+			rest = append(rest, a)
+			continue
+		}
+		switch format {
+		case FormatPlain, FormatTable, FormatJSON, FormatYAML:
+		default:
+			return nil, "", fmt.Errorf("invalid output format %q: expected table, plain, json or yaml", format)
+		}
+	}
+	return rest, format, nil
+}
+
+// renderFormat renders v, a struct or a slice of structs, as a table, JSON
+// or YAML. Field names come from the json tags. Plain output is specific
+// to each command, so FormatPlain renders as a table here.
+func renderFormat(v any, format string) (string, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case FormatYAML:
+		return strings.Join(yamlLines(reflect.ValueOf(v)), "\n"), nil
+	default:
+		return renderTable(reflect.ValueOf(v)), nil
+	}
+}
+
+// field is an exported struct field as it appears in structured output.
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool // left out of YAML when zero, like omitempty in JSON
+}
+
+// fieldsOf lists t's fields by their json names, with embedded structs
+// flattened as encoding/json does.
+func fieldsOf(t reflect.Type) []field {
+	var fields []field
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, field{name: name, index: f.Index, omitEmpty: strings.Contains(opts, "omitempty")})
+	}
+	return fields
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// deref follows pointers; ok is false for a nil pointer.
+func deref(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+// isScalar reports whether v renders on one line.
+func isScalar(v reflect.Value) bool {
+	v, ok := deref(v)
+	if !ok || v.Type() == timeType {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array:
+		return false
+	}
+	return true
+}
+
+// yamlScalar renders a scalar value; strings are double-quoted.
+func yamlScalar(v reflect.Value) string {
+	v, ok := deref(v)
+	if !ok {
+		return "null"
+	}
+	if t, isTime := v.Interface().(time.Time); isTime {
+		if t.IsZero() {
+			return "null"
+		}
+		return strconv.Quote(t.Format(time.RFC3339))
+	}
+	if v.Kind() == reflect.String {
+		return strconv.Quote(v.String())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// yamlLines renders v as YAML block lines.
+func yamlLines(v reflect.Value) []string {
+	if isScalar(v) {
+		return []string{yamlScalar(v)}
+	}
+	v, _ = deref(v)
+	var lines []string
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range fieldsOf(v.Type()) {
+			fv := v.FieldByIndex(f.index)
+			switch {
+			case f.omitEmpty && fv.IsZero():
+			case isScalar(fv):
+				lines = append(lines, f.name+": "+yamlScalar(fv))
+			case (fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array) && fv.Len() == 0:
+				lines = append(lines, f.name+": []")
+			default:
+				lines = append(lines, f.name+":")
+				for _, l := range yamlLines(fv) {
+					lines = append(lines, "  "+l)
+				}
+			}
+		}
+	default:
+		if v.Len() == 0 {
+			return []string{"[]"}
+		}
+		for i := 0; i < v.Len(); i++ {
+			for j, l := range yamlLines(v.Index(i)) {
+				if j == 0 {
+					lines = append(lines, "- "+l)
+				} else {
+					lines = append(lines, "  "+l)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// cellText renders a scalar for a table cell.
+func cellText(v reflect.Value) string {
+	v, ok := deref(v)
+	if !ok {
+		return "-"
+	}
+	if t, isTime := v.Interface().(time.Time); isTime {
+		if t.IsZero() {
+			return "-"
+		}
+		return FormatTime(t)
+	}
+	if isScalar(v) {
+		if s := fmt.Sprint(v.Interface()); s != "" {
+			return s
+		}
+		return "-"
+	}
+	return fmt.Sprintf("(%d)", v.Len())
+}
+
+// renderTable renders a slice of structs as columns under a header, or a
+// single struct as aligned name/value rows.
+func renderTable(v reflect.Value) string {
+	v, ok := deref(v)
+	if !ok {
+		return ""
+	}
+	var rows [][]string
+	switch {
+	case v.Kind() == reflect.Struct:
+		for _, f := range fieldsOf(v.Type()) {
+			rows = append(rows, []string{f.name, cellText(v.FieldByIndex(f.index))})
+		}
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		elem := v.Type().Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct || elem == timeType {
+			var lines []string
+			for i := 0; i < v.Len(); i++ {
+				lines = append(lines, cellText(v.Index(i)))
+			}
+			return strings.Join(lines, "\n")
+		}
+		fields := fieldsOf(elem)
+		header := make([]string, len(fields))
+		for i, f := range fields {
+			header[i] = strings.ToUpper(f.name)
+		}
+		rows = append(rows, header)
+		for i := 0; i < v.Len(); i++ {
+			item, ok := deref(v.Index(i))
+			row := make([]string, len(fields))
+			for j, f := range fields {
+				row[j] = "-"
+				if ok {
+					row[j] = cellText(item.FieldByIndex(f.index))
+				}
+			}
+			rows = append(rows, row)
+		}
+	default:
+		return cellText(v)
+	}
+	return alignColumns(rows)
+}
+
+// alignColumns pads every column but the last to its widest cell.
+func alignColumns(rows [][]string) string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+	lines := make([]string, len(rows))
+	for r, row := range rows {
+		var b strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[i]-len([]rune(cell))+2))
+		}
+		lines[r] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
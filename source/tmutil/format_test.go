@@ -0,0 +1,73 @@
+//
+// format_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestFormatBytesInt64(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{999, "999 B"},
+		{1000, "1.0 KB"},
+		{999_949, "999.9 KB"},
+		{999_950, "1.0 MB"},
+		{1_500_000, "1.5 MB"},
+		{999_949_999_999_999, "999.9 TB"},
+		{1e15, "1.0 PB"},
+		{1e18, "1.0 EB"},
+		{math.MaxInt64, "9.2 EB"},
+		{-1, "-1 B"},
+		{-999, "-999 B"},
+		{-999_950, "-1.0 MB"},
+		{-2_500_000_000, "-2.5 GB"},
+		{math.MinInt64, "-9.2 EB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytesInt64(tt.n); got != tt.want {
+			t.Errorf("FormatBytesInt64(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytesMatchesInt64(t *testing.T) {
+	for _, n := range []int64{0, 1, 999, 1000, 999_949, 999_950, 123_456_789, 1e15, 1e18, math.MaxInt64, -1, -999_950, math.MinInt64} {
+		s := strconv.FormatInt(n, 10)
+		if got, want := formatBytes(s), FormatBytesInt64(n); got != want {
+			t.Errorf("formatBytes(%q) = %q, FormatBytesInt64 gives %q", s, got, want)
+		}
+	}
+}
+
+func TestFormatBytesStrings(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"1.5e6", "1.5 MB"},
+		{"999949.4", "999.9 KB"},
+		{"999949.6", "1.0 MB"}, // rounds to 999,950 bytes first
+		{"1e30", "9.2 EB"},
+		{"-1e30", "-9.2 EB"},
+		{"unknown", "unknown"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.s); got != tt.want {
+			t.Errorf("formatBytes(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+//
+// ignore.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ignoreRule is one line of a .tmcliignore file.
+type ignoreRule struct {
+	parts   []string // pattern split on "/"; "**" matches any number of folders
+	negate  bool     // "!pattern": keep what an earlier rule left out
+	dirOnly bool     // "pattern/": folders only
+	nested  bool     // pattern has a "/", so it is matched from the volume's root
+}
+
+var ignoreRules []ignoreRule
+
+// SetIgnorePatterns sets the patterns FindFile and backup listings leave
+// out, one per line in the style of .gitignore: blank lines and lines
+// starting with # are skipped, a pattern without a slash matches a name at
+// any depth, one with a slash matches from the root of the backed-up
+// volume, a trailing slash matches folders only, ** matches any number of
+// folders and a leading ! brings back what an earlier pattern left out.
+// The last pattern that matches wins.
+func SetIgnorePatterns(lines []string) {
+	var rules []ignoreRule
+	for _, line := range lines {
+		p := strings.TrimSpace(line)
+		if p == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+		var r ignoreRule
+		if strings.HasPrefix(p, "!") {
+			r.negate = true
+			p = p[1:]
+		}
+		if strings.HasSuffix(p, "/") {
+			r.dirOnly = true
+			p = strings.TrimRight(p, "/")
+		}
+		r.nested = strings.Contains(p, "/")
+		p = strings.TrimPrefix(p, "/")
+		if p == "" {
+			continue
+		}
+		r.parts = strings.Split(p, "/")
+		rules = append(rules, r)
+	}
+	ignoreRules = rules
+}
+
+// ignored reports whether the ignore patterns leave out the item at rel, a
+// slash-separated path relative to the root of the backed-up volume, e.g.
+// "Users/me/Library/Caches".
+func ignored(rel string, dir bool) bool {
+	if len(ignoreRules) == 0 || rel == "" || rel == "." {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	out := false
+	for _, r := range ignoreRules {
+		if r.dirOnly && !dir || out != r.negate {
+			continue
+		}
+		if r.nested && matchParts(r.parts, parts) || !r.nested && matchParts(r.parts, parts[len(parts)-1:]) {
+			out = !r.negate
+		}
+	}
+	return out
+}
+
+// matchParts matches path components against pattern components, each a
+// filepath.Match glob, with "**" matching zero or more components.
+func matchParts(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchParts(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	if ok, _ := filepath.Match(pattern[0], parts[0]); !ok {
+		return false
+	}
+	return matchParts(pattern[1:], parts[1:])
+}
+
+// ignoredIn reports whether the ignore patterns leave out path, inside the
+// backup at root. The first component below root is the backed-up volume,
+// so patterns are matched from below it.
+func ignoredIn(root, path string, dir bool) bool {
+	if len(ignoreRules) == 0 {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	_, inVolume, ok := strings.Cut(filepath.ToSlash(rel), "/")
+	return ok && ignored(inVolume, dir)
+}
+
+// backupRoot returns the backup that path lies in, found by its dated
+// name, or "" when path is not inside one.
+func backupRoot(path string) string {
+	for d := filepath.Clean(path); d != filepath.Dir(d); d = filepath.Dir(d) {
+		if _, err := parseBackupDate(d); err == nil {
+			return d
+		}
+	}
+	return ""
+}
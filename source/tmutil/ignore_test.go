@@ -0,0 +1,111 @@
+//
+// ignore_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import "testing"
+
+func TestIgnored(t *testing.T) {
+	type item struct {
+		rel  string
+		dir  bool
+		want bool
+	}
+	tests := []struct {
+		name     string
+		patterns []string
+		items    []item
+	}{
+		{"comments and blank lines", []string{"# *.log", "", "   "}, []item{
+			{"a.log", false, false},
+		}},
+		{"unanchored name at any depth", []string{"*.tmp"}, []item{
+			{"a.tmp", false, true},
+			{"Users/me/a.tmp", false, true},
+			{"Users/me/a.tmpl", false, false},
+		}},
+		{"anchored from the volume root", []string{"Users/me/Downloads", "/Applications"}, []item{
+			{"Users/me/Downloads", true, true},
+			{"Users/you/Downloads", true, false},
+			{"Other/Users/me/Downloads", true, false},
+			{"Applications", true, true},
+			{"Users/me/Applications", true, false},
+		}},
+		{"dir-only", []string{"Caches/"}, []item{
+			{"Users/me/Library/Caches", true, true},
+			{"Users/me/Library/Caches", false, false},
+		}},
+		{"negation", []string{"*.log", "!keep.log"}, []item{
+			{"a.log", false, true},
+			{"keep.log", false, false},
+			{"Users/me/keep.log", false, false},
+		}},
+		{"negation without an earlier match", []string{"!keep.log"}, []item{
+			{"keep.log", false, false},
+		}},
+		{"negation after a dir-only rule", []string{"build/", "!Users/me/src/build"}, []item{
+			{"Users/me/src/build", true, false},
+			{"Users/you/build", true, true},
+			{"build", false, false},
+		}},
+		{"unanchored negation after a dir-only rule", []string{"build/", "!build"}, []item{
+			{"build", true, false},
+			{"Users/me/build", true, false},
+		}},
+		{"** at the start", []string{"**/node_modules"}, []item{
+			{"node_modules", true, true},
+			{"Users/me/src/app/node_modules", true, true},
+			{"Users/me/src/app/node_modules_old", true, false},
+		}},
+		{"** in the middle", []string{"Users/**/Caches"}, []item{
+			{"Users/Caches", true, true},
+			{"Users/me/Library/Caches", true, true},
+			{"Library/Caches", true, false},
+		}},
+		{"** at the end", []string{"Users/me/tmp/**"}, []item{
+			{"Users/me/tmp/a.txt", false, true},
+			{"Users/me/tmp/a/b/c.txt", false, true},
+			{"Users/me/tmp", true, true}, // ** matches zero folders too
+			{"Users/me/tmpfiles/a.txt", false, false},
+		}},
+		{"last match wins", []string{"*.log", "!keep.log", "keep.log"}, []item{
+			{"keep.log", false, true},
+		}},
+		{"a later negation wins over an earlier rule", []string{"Users/me/*", "!Users/me/Documents"}, []item{
+			{"Users/me/Music", true, true},
+			{"Users/me/Documents", true, false},
+		}},
+	}
+	t.Cleanup(func() { SetIgnorePatterns(nil) })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetIgnorePatterns(tt.patterns)
+			for _, it := range tt.items {
+				if got := ignored(it.rel, it.dir); got != it.want {
+					t.Errorf("%q: ignored(%q, dir=%v) = %v, want %v", tt.patterns, it.rel, it.dir, got, it.want)
+				}
+			}
+		})
+	}
+}
+
+// TestIgnoredIn checks that patterns are matched from below the backed-up
+// volume, not from the backup's root.
+func TestIgnoredIn(t *testing.T) {
+	t.Cleanup(func() { SetIgnorePatterns(nil) })
+	SetIgnorePatterns([]string{"Users/me/Downloads"})
+	root := "/Volumes/Backup/2026-03-14-081130.backup"
+	if !ignoredIn(root, root+"/Macintosh HD - Data/Users/me/Downloads", true) {
+		t.Error("Users/me/Downloads inside the volume was not ignored")
+	}
+	if ignoredIn(root, root+"/Users/me/Downloads", true) {
+		t.Error("a volume named Users was matched as part of the pattern")
+	}
+}
@@ -0,0 +1,133 @@
+//
+// layout.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BackupLayout is how a destination stores its backups.
+type BackupLayout string
+
+const (
+	LayoutUnknown BackupLayout = ""
+	// LayoutAPFS is used by destinations formatted on macOS 11 and later:
+	// each backup is a snapshot named 2026-03-14-081130.backup, with
+	// .inprogress and .previous entries beside them.
+	LayoutAPFS BackupLayout = "APFS"
+	// LayoutHFS is the older Backups.backupdb layout: a directory per
+	// machine holding backups named 2026-03-14-081130 that hard-link the
+	// files unchanged since the one before, a Latest link and .inProgress
+	// entries.
+	LayoutHFS BackupLayout = "HFS+"
+)
+
+// hfsBackupDir is the directory HFS+ destinations keep their machine
+// directories in.
+const hfsBackupDir = "Backups.backupdb"
+
+// pathLayout tells the layout from the shape of a path on a destination
+// alone, without touching the disk: anything under Backups.backupdb is
+// HFS+, and the names APFS destinations give their entries are APFS.
+func pathLayout(path string) BackupLayout {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if part == hfsBackupDir {
+			return LayoutHFS
+		}
+		for _, ext := range []string{".backup", ".inprogress", ".previous"} {
+			if strings.HasSuffix(part, ext) && len(part) > len(ext) {
+				return LayoutAPFS
+			}
+		}
+	}
+	return LayoutUnknown
+}
+
+// BackupFormat reports how the destination mounted at destination stores
+// its backups. A path inside a destination, such as a backup or machine
+// directory, works too. It looks at the path first, then at what the
+// volume holds, and for a destination with no backups yet at the file
+// system it is formatted with; a network share, whose backups live in a
+// disk image, is LayoutUnknown until that image is mounted.
+func BackupFormat(destination string) BackupLayout {
+	if l := pathLayout(destination); l != LayoutUnknown {
+		return l
+	}
+	if info, err := os.Stat(filepath.Join(destination, hfsBackupDir)); err == nil && info.IsDir() {
+		return LayoutHFS
+	}
+	for _, pattern := range []string{"*.backup", "*.inprogress", "*.previous"} {
+		if matches, _ := filepath.Glob(filepath.Join(destination, pattern)); len(matches) > 0 {
+			return LayoutAPFS
+		}
+	}
+	output, err := mountTable()
+	if err != nil {
+		return LayoutUnknown
+	}
+	switch mountFSType(output, destination) {
+	case "apfs":
+		return LayoutAPFS
+	case "hfs":
+		return LayoutHFS
+	}
+	return LayoutUnknown
+}
+
+// mountFSType returns the file system type mount(8) output gives for the
+// volume mounted at mountPoint, e.g. "apfs" from
+//
+//	/dev/disk4s1 on /Volumes/Backup (apfs, local, nodev, nosuid, journaled)
+//
+// or "" when it is not mounted.
+func mountFSType(raw, mountPoint string) string {
+	mountPoint = filepath.Clean(mountPoint)
+	for _, line := range strings.Split(raw, "\n") {
+		on := strings.Index(line, " on ")
+		open := strings.LastIndex(line, " (")
+		if on < 0 || open < on || line[on+4:open] != mountPoint {
+			continue
+		}
+		fsType, _, _ := strings.Cut(line[open+2:], ",")
+		return strings.TrimSpace(fsType)
+	}
+	return ""
+}
+
+// annotateLayouts adds a Format line after each Mount Point line of
+// destinationinfo output, for the destinations whose layout is known. The
+// volumes of a remote host cannot be looked into, so its output is left
+// as it is.
+func annotateLayouts(raw string) string {
+	if RemoteHost() != "" {
+		return raw
+	}
+	lines := strings.Split(raw, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, line)
+		key, val, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != "Mount Point" {
+			continue
+		}
+		if l := BackupFormat(strings.TrimSpace(val)); l != LayoutUnknown {
+			// Pad the label to the width tmutil gives "Mount Point".
+			label := "Format"
+			if len(key) > len(label) {
+				label += strings.Repeat(" ", len(key)-len(label))
+			}
+			out = append(out, label+": "+string(l))
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,143 @@
+//
+// log.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// commandLog is the optional audit log of tmutil invocations.
+var commandLog struct {
+	sync.Mutex
+	path      string
+	retention LogRetention
+}
+
+// LogRetention bounds how much of the command log is kept. A log is
+// rotated by moving it to path+".1", shifting older ones up to path+".N",
+// and starting a new file.
+type LogRetention struct {
+	MaxBytes int64         // rotate once the log reaches this size; 0 never
+	MaxAge   time.Duration // rotate on opening once the first entry is this old; 0 never
+	Keep     int           // rotated logs kept; older ones are deleted (at least 1)
+}
+
+// EnableLog appends a line for every tmutil invocation to the file at path:
+// timestamp, arguments, duration, and exit status. The log is rotated when
+// it is opened if it is too large or too old, and again whenever it grows
+// past r.MaxBytes. Status polls, which the monitor issues every second, are
+// not logged.
+func EnableLog(path string, r LogRetention) error {
+	r.Keep = max(r.Keep, 1)
+	if info, err := os.Stat(path); err == nil && (r.tooLarge(info.Size()) || r.tooOld(path)) {
+		if err := rotateLog(path, r.Keep); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	f.Close()
+	commandLog.Lock()
+	commandLog.path, commandLog.retention = path, r
+	commandLog.Unlock()
+	return nil
+}
+
+func (r LogRetention) tooLarge(size int64) bool {
+	return r.MaxBytes > 0 && size >= r.MaxBytes
+}
+
+// tooOld reports whether the log's first entry is older than r.MaxAge. The
+// file's modification time changes with every entry, so the timestamp at
+// the start of the first line is used instead.
+func (r LogRetention) tooOld(path string) bool {
+	if r.MaxAge <= 0 {
+		return false
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	line, _ := bufio.NewReader(f).ReadString('\n')
+	stamp, _, _ := strings.Cut(line, " ")
+	t, err := time.Parse(time.RFC3339, stamp)
+	return err == nil && time.Since(t) >= r.MaxAge
+}
+
+// rotateLog moves path to path+".1" after shifting the older logs up by
+// one, and deletes any beyond keep.
+func rotateLog(path string, keep int) error {
+	for n := keep; ; n++ {
+		old := fmt.Sprintf("%s.%d", path, n)
+		if _, err := os.Stat(old); err != nil {
+			break
+		}
+		os.Remove(old)
+	}
+	for n := keep - 1; n >= 1; n-- {
+		os.Rename(fmt.Sprintf("%s.%d", path, n), fmt.Sprintf("%s.%d", path, n+1))
+	}
+	return os.Rename(path, path+".1")
+}
+
+// logCommand records one invocation if logging is enabled. Failures to
+// write the log are ignored so that logging never breaks a command.
+func logCommand(args []string, start time.Time, err error) {
+	if len(args) > 0 && args[0] == "status" {
+		return
+	}
+	commandLog.Lock()
+	defer commandLog.Unlock()
+	if commandLog.path == "" {
+		return
+	}
+	if info, statErr := os.Stat(commandLog.path); statErr == nil && commandLog.retention.tooLarge(info.Size()) {
+		rotateLog(commandLog.path, commandLog.retention.Keep)
+	}
+	f, openErr := os.OpenFile(commandLog.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if openErr != nil {
+		return
+	}
+	defer f.Close()
+
+	status := "ok"
+	if err != nil {
+		status = "failed: " + maskPasswords(strings.ReplaceAll(err.Error(), "\n", " "))
+	}
+	fmt.Fprintf(f, "%s  uid=%d  tmutil %s  %s  %s\n",
+		start.Format(time.RFC3339), os.Getuid(), maskPasswords(ShellJoin(args)),
+		time.Since(start).Round(time.Millisecond), status)
+}
+
+// maskPasswords replaces the password of each URL in s, such as the share
+// URL setdestination is given, with "xxxxx", so the log never holds one.
+func maskPasswords(s string) string {
+	for _, field := range strings.Fields(s) {
+		field = strings.Trim(field, "'\"")
+		u, err := url.Parse(field)
+		if err != nil || u.User == nil {
+			continue
+		}
+		if _, ok := u.User.Password(); ok {
+			s = strings.ReplaceAll(s, field, u.Redacted())
+		}
+	}
+	return s
+}
@@ -0,0 +1,193 @@
+//
+// messages.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// english is the built-in catalog and the fallback for every lookup. Keys
+// are stable message IDs; translations map the same IDs.
+var english = map[string]string{
+	"common.yes":      "Yes",
+	"common.no":       "No",
+	"common.enabled":  "Enabled",
+	"common.disabled": "Disabled",
+	"common.unknown":  "unknown",
+
+	"status.title":           "Time Machine Backup Status",
+	"status.idle_title":      "Time Machine Status",
+	"status.phase":           "Phase",
+	"status.running":         "Running",
+	"status.destination":     "Destination",
+	"status.reachable":       "Reachable",
+	"status.started":         "Started",
+	"status.elapsed":         "Elapsed",
+	"status.progress":        "Progress",
+	"status.completed":       "Completed",
+	"status.remaining":       "Remaining",
+	"status.calculating":     "Calculating...",
+	"status.bytes":           "Bytes",
+	"status.files":           "Files",
+	"status.state":           "State",
+	"status.idle":            "Idle",
+	"status.auto_backup":     "Auto Backup",
+	"status.url":             "URL",
+	"status.encryption":      "Encryption",
+	"status.last_backup":     "Last Backup",
+	"status.history":         "Backup History",
+	"status.total":           "Total",
+	"status.oldest":          "Oldest",
+	"status.newest":          "Newest",
+	"status.snapshot_count":  "%d snapshot(s)",
+	"status.local_snapshots": "Local Snapshots",
+	"status.disk_usage":      "Disk Usage",
+	"status.used":            "Used",
+	"status.available":       "Available",
+	"status.preferences":     "Preferences",
+	"status.unavailable":     "Unavailable",
+	"status.no_permission":   "insufficient permissions",
+	"status.partial":         "Insufficient permissions, showing partial data. Run with sudo or grant the terminal Full Disk Access.",
+	"status.not_configured":  "Time Machine has never been configured on this Mac. Choose a backup disk with Destinations → Set Destination (sudo tmcli setdestination).",
+
+	"monitor.title":     "Backup Monitor",
+	"monitor.complete":  "Backup complete.",
+	"monitor.waiting":   "No backup in progress. Waiting...",
+	"monitor.current":   "Current",
+	"monitor.observed":  "Observed",
+	"monitor.first_eta": "First ETA",
+	"monitor.finished":  "Finished",
+	"monitor.early":     "early",
+	"monitor.late":      "late",
+	"monitor.phases":    "Phases",
+	"monitor.deadline":  "Deadline",
+
+	"monitor.on_track":      "on track, %s to spare",
+	"monitor.will_miss":     "predicted %s, %s late",
+	"monitor.no_prediction": "no prediction yet",
+	"monitor.met":           "met, %s to spare",
+	"monitor.missed":        "missed by %s",
+
+	"monitor.copied_so_far": "Copied so far",
+	"monitor.total_unknown": "total not yet known",
+
+	"monitor.thinning_stalled": "Backup has been thinning for %s with no progress: the destination may be low on space.",
+
+	"dashboard.title":   "Dashboard",
+	"dashboard.backups": "Backups",
+	"dashboard.health":  "Health",
+	"dashboard.updated": "Updated %s, every %s",
+
+	// What a backup does in each of tmutil's BackupPhase values; see
+	// DescribePhase.
+	"phase.Starting":                        "starting up",
+	"phase.Preparing":                       "getting ready to back up",
+	"phase.PreparingSourceVolumes":          "taking a snapshot of the disks to back up",
+	"phase.FindingBackupVol":                "looking for the backup disk",
+	"phase.MountingBackupVol":               "mounting the backup disk",
+	"phase.MountingBackupVolForHealthCheck": "mounting the disk to verify it",
+	"phase.MountingDiskImage":               "mounting the network backup's disk image",
+	"phase.ThinningPreBackup":               "deleting old backups to make room",
+	"phase.FindingChanges":                  "scanning for files changed since the last backup",
+	"phase.Copying":                         "copying changed files to the backup disk",
+	"phase.ThinningPostBackup":              "deleting backups that have expired",
+	"phase.LazyThinning":                    "deleting expired backups in the background",
+	"phase.Finishing":                       "recording the completed backup",
+	"phase.Stopping":                        "stopping the backup",
+	"phase.HealthCheckFsck":                 "checking the backup disk for errors",
+	"phase.HealthCheckCopyHFSMeta":          "saving disk details for the check",
+}
+
+// messages holds the registered catalogs and the active one.
+var messages = struct {
+	sync.RWMutex
+	catalogs map[string]map[string]string
+	active   map[string]string
+}{
+	catalogs: map[string]map[string]string{"en": english},
+	active:   english,
+}
+
+// T returns the text for a message ID in the active locale, falling back
+// to English and then to the ID itself.
+func T(id string) string {
+	messages.RLock()
+	s, ok := messages.active[id]
+	messages.RUnlock()
+	if ok {
+		return s
+	}
+	if s, ok := english[id]; ok {
+		return s
+	}
+	return id
+}
+
+// RegisterCatalog adds (or replaces) the catalog for a language code such
+// as "de". Missing IDs fall back to English.
+func RegisterCatalog(lang string, msgs map[string]string) {
+	messages.Lock()
+	messages.catalogs[lang] = msgs
+	messages.Unlock()
+}
+
+// LoadCatalog registers a catalog read from a JSON object of ID → text.
+func LoadCatalog(lang, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var msgs map[string]string
+	if err := json.Unmarshal(data, &msgs); err != nil {
+		return fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	RegisterCatalog(lang, msgs)
+	return nil
+}
+
+// SetLocale makes lang the active catalog. It reports false, leaving
+// English active, when no catalog is registered for lang.
+func SetLocale(lang string) bool {
+	messages.Lock()
+	defer messages.Unlock()
+	c, ok := messages.catalogs[lang]
+	if !ok {
+		messages.active = english
+		return false
+	}
+	messages.active = c
+	return true
+}
+
+// LocaleFromEnv returns the language code from TMCLI_LANG, LC_ALL,
+// LC_MESSAGES or LANG (e.g. "de_DE.UTF-8" → "de"), or "en".
+func LocaleFromEnv() string {
+	for _, key := range []string{"TMCLI_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(key)
+		if v == "" || v == "C" || v == "POSIX" {
+			continue
+		}
+		if i := strings.IndexAny(v, "_.@"); i > 0 {
+			v = v[:i]
+		}
+		return strings.ToLower(v)
+	}
+	return "en"
+}
+
+// Label renders a message as an aligned "Label:" column of the given width.
+func Label(id string, width int) string {
+	return fmt.Sprintf("%-*s", width, T(id)+":")
+}
@@ -0,0 +1,141 @@
+//
+// mounted.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// markMounted sets Mounted on each destination whose mount point, as
+// destinationinfo reports it, is mounted now. destinationinfo can keep a
+// mount point for a disk that has since been unplugged, so the mount
+// table decides; without one, the mount point must at least exist.
+func markMounted(dests []DestInfo) {
+	output, err := mountTable()
+	for i := range dests {
+		dests[i].Mounted = isMounted(dests[i], output, err == nil)
+	}
+}
+
+func isMounted(dest DestInfo, mountRaw string, haveTable bool) bool {
+	if dest.MountPoint == "" {
+		return false
+	}
+	if haveTable {
+		return mountFSType(mountRaw, dest.MountPoint) != ""
+	}
+	if RemoteHost() != "" {
+		return false
+	}
+	info, err := os.Stat(dest.MountPoint)
+	return err == nil && info.IsDir()
+}
+
+// DestinationsByMount splits the configured destinations into those whose
+// disk or share is mounted now and those that are not.
+func DestinationsByMount() (mounted, unmounted []DestInfo, err error) {
+	dests, err := ListDestinations()
+	if err != nil {
+		return nil, nil, err
+	}
+	markMounted(dests)
+	for _, d := range dests {
+		if d.Mounted {
+			mounted = append(mounted, d)
+		} else {
+			unmounted = append(unmounted, d)
+		}
+	}
+	return mounted, unmounted, nil
+}
+
+// UnmountedWarning explains why commands that read backups will find none
+// when no configured destination is mounted, naming each destination and
+// what is missing. It is "" when one is mounted, or when the destinations
+// cannot be read, so a failing destinationinfo does not block anything.
+func UnmountedWarning() string {
+	mounted, unmounted, err := DestinationsByMount()
+	if err != nil || len(mounted) > 0 {
+		return ""
+	}
+	if len(unmounted) == 0 {
+		return "No backup destination is configured, so there are no backups to read."
+	}
+	names := make([]string, len(unmounted))
+	for i, d := range unmounted {
+		why := d.MountPoint + " is not mounted"
+		if d.MountPoint == "" {
+			_, why = destinationReachable(d)
+		}
+		names[i] = fmt.Sprintf("%s (%s)", destinationName(d), why)
+	}
+	return fmt.Sprintf("No backup destination is mounted: %s. No backups can be read until one is connected.", strings.Join(names, ", "))
+}
+
+// destinationName is how a destination is named in messages.
+func destinationName(d DestInfo) string {
+	switch {
+	case d.Name != "":
+		return d.Name
+	case d.URL != "":
+		return Redact(d.URL)
+	}
+	return d.ID
+}
+
+// annotateMounts adds a Mounted line to each destination in
+// destinationinfo output, after the destination's last line.
+func annotateMounts(raw string) string {
+	output, err := mountTable()
+	var out, block []string
+	flush := func() {
+		d := parseDestinationInfo(strings.Join(block, "\n"))
+		last := len(block)
+		for last > 0 && strings.TrimSpace(block[last-1]) == "" {
+			last--
+		}
+		out = append(out, block[:last]...)
+		if d != (DestInfo{}) {
+			out = append(out, padLabel("Mounted", block)+": "+yesNoLabel(isMounted(d, output, err == nil)))
+		}
+		out = append(out, block[last:]...)
+		block = nil
+	}
+	for _, line := range strings.Split(raw, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "===") {
+			flush()
+			out = append(out, line)
+			continue
+		}
+		block = append(block, line)
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+// padLabel pads label to the width tmutil gives the labels in lines.
+func padLabel(label string, lines []string) string {
+	for _, line := range lines {
+		if key, _, ok := strings.Cut(line, ":"); ok && len(key) > len(label) {
+			return label + strings.Repeat(" ", len(key)-len(label))
+		}
+	}
+	return label
+}
+
+func yesNoLabel(v bool) string {
+	if v {
+		return "Yes"
+	}
+	return "No"
+}
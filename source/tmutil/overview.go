@@ -0,0 +1,61 @@
+//
+// overview.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import "time"
+
+// Overview aggregates the read-only Time Machine state in one call.
+// Each part is collected independently; a failure in one is recorded in
+// the matching error field and does not prevent the others.
+type Overview struct {
+	Status         StatusInfo
+	StatusErr      error
+	Prefs          BackupPrefs
+	PrefsErr       error
+	Destination    DestInfo
+	DestinationErr error
+	Backups        []string // completed backup paths, oldest first
+	BackupsErr     error
+}
+
+// GetOverview collects status, preferences, destination, and backup list.
+func GetOverview() Overview {
+	var o Overview
+	o.Status, o.StatusErr = GetStatus()
+	o.Prefs, o.PrefsErr = GetBackupPrefs()
+	o.Destination, o.DestinationErr = GetDestinationInfo()
+	o.Backups, o.BackupsErr = BackupPaths()
+	return o
+}
+
+// LastBackup returns the time of the most recent completed backup, preferring
+// the preferences plist and falling back to the backup list. It returns the
+// zero time if neither source is available.
+func (o Overview) LastBackup() time.Time {
+	if o.PrefsErr == nil && !o.Prefs.LastSnapshot().IsZero() {
+		return o.Prefs.LastSnapshot()
+	}
+	if o.BackupsErr == nil && len(o.Backups) > 0 {
+		if t, err := parseBackupDate(o.Backups[len(o.Backups)-1]); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// BackupCount returns the number of completed backups, preferring the
+// preferences plist and falling back to the backup list.
+func (o Overview) BackupCount() int {
+	if o.PrefsErr == nil && len(o.Prefs.SnapshotDates) > 0 {
+		return len(o.Prefs.SnapshotDates)
+	}
+	return len(o.Backups)
+}
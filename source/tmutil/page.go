@@ -0,0 +1,61 @@
+//
+// page.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PageInfo tells a JSON consumer whether a list is complete.
+type PageInfo struct {
+	Total     int  `json:"total"`     // items available
+	Returned  int  `json:"returned"`  // items included
+	Truncated bool `json:"truncated"` // true when --limit left some out
+}
+
+// ListPage is the JSON envelope of list commands: the items returned and
+// how they relate to everything available.
+type ListPage[T any] struct {
+	PageInfo
+	Items []T `json:"items"`
+}
+
+// newListPage keeps the last limit items, the newest for lists in
+// chronological order; a limit of 0 keeps them all.
+func newListPage[T any](items []T, limit int) ListPage[T] {
+	if items == nil {
+		items = []T{}
+	}
+	total := len(items)
+	if limit > 0 && len(items) > limit {
+		items = items[len(items)-limit:]
+	}
+	return ListPage[T]{
+		PageInfo: PageInfo{Total: total, Returned: len(items), Truncated: len(items) < total},
+		Items:    items,
+	}
+}
+
+// marshalPage renders a list page as indented JSON.
+func marshalPage[T any](items []T, limit int) (string, error) {
+	data, err := json.MarshalIndent(newListPage(items, limit), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// BackupListing is one completed backup in listbackups JSON output.
+type BackupListing struct {
+	Path string     `json:"path"`
+	Date *time.Time `json:"date"` // nil if the path has no parseable date
+}
@@ -11,7 +11,11 @@
 package tmutil
 
 import (
-	"os/exec"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -30,6 +34,36 @@ type BackupPrefs struct {
 	BytesAvailable int64
 	SnapshotDates  []time.Time
 	AttemptDates   []time.Time
+
+	// AutoBackupInterval is the configured time between automatic backups
+	// (the AutoBackupInterval key, in seconds); 0 if the key is absent.
+	AutoBackupInterval time.Duration
+}
+
+// defaultBackupInterval is how often macOS backs up when no interval is set.
+const defaultBackupInterval = time.Hour
+
+// Interval returns the effective time between automatic backups and
+// whether it was read from the plist (false means the macOS default).
+func (p BackupPrefs) Interval() (time.Duration, bool) {
+	if p.AutoBackupInterval > 0 {
+		return p.AutoBackupInterval, true
+	}
+	return defaultBackupInterval, false
+}
+
+// DescribeInterval renders an interval the way System Settings does:
+// "every hour", "daily", "weekly", or "every N hours".
+func DescribeInterval(d time.Duration) string {
+	switch d {
+	case time.Hour:
+		return "every hour"
+	case 24 * time.Hour:
+		return "daily"
+	case 7 * 24 * time.Hour:
+		return "weekly"
+	}
+	return "every " + FormatDuration(d)
 }
 
 // LastSnapshot returns the most recent snapshot date, or zero time if none.
@@ -69,14 +103,53 @@ func (p BackupPrefs) LastBackupDuration() time.Duration {
 	return last.Sub(best)
 }
 
+// readPrefs returns the Time Machine preferences plist as `defaults read`
+// prints it. Reading it needs no root, but macOS hides it from a terminal
+// without Full Disk Access, and defaults then reports that the domain does
+// not exist, as it does on a Mac where Time Machine was never set up. The
+// plist file itself tells the two apart: it exists, unreadable, only in
+// the first case, which is ErrNoPermission; the second is ErrNotConfigured.
+// TMCLI_PREFS names a file of `defaults read` output to use instead, e.g.
+// testdata/prefs-multi.plist, as TMCLI_TMUTIL replaces tmutil.
+func readPrefs() (string, error) {
+	if path := os.Getenv("TMCLI_PREFS"); path != "" && RemoteHost() == "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("cannot read %s from TMCLI_PREFS: %w", tmPlistDomain, err)
+		}
+		return string(data), nil
+	}
+	output, err := hostCommand(context.Background(), "defaults", "read", tmPlistDomain).CombinedOutput()
+	if err == nil {
+		return string(output), nil
+	}
+	if sshErr := sshFailure(string(output), err); sshErr != nil {
+		return "", sshErr
+	}
+	msg := strings.TrimSpace(string(output))
+	if strings.Contains(msg, "does not exist") && RemoteHost() != "" {
+		// The plist cannot be looked at to tell the two cases apart.
+		return "", fmt.Errorf("cannot read %s on %s: %s: %w", tmPlistDomain, RemoteHost(), firstLine(msg), ErrNotConfigured)
+	}
+	if strings.Contains(msg, "does not exist") {
+		if _, statErr := os.Stat(tmPlistDomain + ".plist"); errors.Is(statErr, fs.ErrNotExist) {
+			return "", fmt.Errorf("%s does not exist: %w", tmPlistDomain, ErrNotConfigured)
+		}
+		return "", fmt.Errorf("cannot read %s: the terminal lacks Full Disk Access: %w", tmPlistDomain, ErrNoPermission)
+	}
+	if msg != "" {
+		return "", fmt.Errorf("cannot read %s: %s: %w", tmPlistDomain, firstLine(msg), err)
+	}
+	return "", fmt.Errorf("cannot read %s: %w", tmPlistDomain, err)
+}
+
 // GetBackupPrefs reads the Time Machine preferences plist via `defaults read`.
 func GetBackupPrefs() (BackupPrefs, error) {
-	cmd := exec.Command("defaults", "read", tmPlistDomain)
-	output, err := cmd.CombinedOutput()
+	output, err := readPrefs()
 	if err != nil {
 		return BackupPrefs{}, err
 	}
-	return parseBackupPrefs(string(output)), nil
+	return parseBackupPrefs(output), nil
 }
 
 func parseBackupPrefs(raw string) BackupPrefs {
@@ -97,6 +170,10 @@ func parseBackupPrefs(raw string) BackupPrefs {
 		case "AutoBackup":
 			prefs.AutoBackupSet = true
 			prefs.AutoBackup = val == "1"
+		case "AutoBackupInterval":
+			if n, err := strconv.ParseInt(val, 10, 64); err == nil && n > 0 {
+				prefs.AutoBackupInterval = time.Duration(n) * time.Second
+			}
 		}
 	}
 
@@ -185,7 +262,7 @@ func (p *BackupPrefs) parseDestinationBlock(raw string) {
 			}
 			continue
 		}
-		if trimmed == "};" || trimmed == "}" {
+		if trimmed == "};" || trimmed == "}," || trimmed == "}" {
 			depth--
 			if depth <= 0 {
 				break
@@ -210,3 +287,126 @@ func (p *BackupPrefs) parseDestinationBlock(raw string) {
 		}
 	}
 }
+
+// GetBackupPrefsFor reads the preferences like GetBackupPrefs, but takes
+// the per-destination fields (usage, encryption and backup dates) from the
+// destination with the given ID instead of the first one.
+func GetBackupPrefsFor(id string) (BackupPrefs, error) {
+	output, err := readPrefs()
+	if err != nil {
+		return BackupPrefs{}, err
+	}
+	return parseBackupPrefsFor(output, id)
+}
+
+func parseBackupPrefsFor(raw, id string) (BackupPrefs, error) {
+	prefs := parseBackupPrefs(raw)
+	for _, d := range parseDestinationPrefs(raw) {
+		if strings.EqualFold(d.ID, id) {
+			prefs.Encryption = d.Encryption
+			prefs.BytesUsed = d.BytesUsed
+			prefs.BytesAvailable = d.BytesAvailable
+			prefs.SnapshotDates = d.SnapshotDates
+			prefs.AttemptDates = d.AttemptDates
+			return prefs, nil
+		}
+	}
+	return BackupPrefs{}, fmt.Errorf("destination %s is not in the Time Machine preferences", id)
+}
+
+// DestinationPrefs holds per-destination data from the preferences plist.
+type DestinationPrefs struct {
+	ID             string
+	Encryption     string // LastKnownEncryptionState, e.g. "Encrypted" or "NotEncrypted"
+	QuotaGB        int64  // 0 when no quota is set
+	BytesUsed      int64
+	BytesAvailable int64
+	SnapshotDates  []time.Time
+	AttemptDates   []time.Time
+}
+
+// GetDestinationPrefs reads every entry of the plist's Destinations array.
+func GetDestinationPrefs() ([]DestinationPrefs, error) {
+	output, err := readPrefs()
+	if err != nil {
+		return nil, err
+	}
+	return parseDestinationPrefs(output), nil
+}
+
+// parseDestinationPrefs collects the top-level keys and the SnapshotDates
+// and AttemptDates arrays of each dictionary in the Destinations array,
+// skipping other nested arrays and dictionaries.
+func parseDestinationPrefs(raw string) []DestinationPrefs {
+	var dests []DestinationPrefs
+	var cur *DestinationPrefs
+	inDest := false
+	depth := 0  // 1 = inside a destination dictionary
+	array := "" // name of the date array being read at depth 2
+
+	for _, line := range strings.Split(raw, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !inDest {
+			if strings.HasPrefix(trimmed, "Destinations =") {
+				inDest = true
+			}
+			continue
+		}
+
+		clean := strings.TrimRight(trimmed, ";,")
+		opener, name := clean, ""
+		if parts := strings.SplitN(clean, " = ", 2); len(parts) == 2 {
+			name, opener = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		}
+		switch {
+		case opener == "{" || opener == "(":
+			depth++
+			if depth == 1 {
+				dests = append(dests, DestinationPrefs{})
+				cur = &dests[len(dests)-1]
+			} else if depth == 2 {
+				array = name
+			}
+			continue
+		case clean == "}" || clean == ")":
+			if depth == 0 {
+				return dests // end of the Destinations array
+			}
+			depth--
+			array = ""
+			continue
+		}
+
+		if depth == 2 && cur != nil && (array == "SnapshotDates" || array == "AttemptDates") {
+			if t, err := time.Parse(plistTimeLayout, strings.Trim(clean, "\"")); err == nil {
+				if array == "SnapshotDates" {
+					cur.SnapshotDates = append(cur.SnapshotDates, t)
+				} else {
+					cur.AttemptDates = append(cur.AttemptDates, t)
+				}
+			}
+			continue
+		}
+		if depth != 1 || cur == nil {
+			continue
+		}
+		parts := strings.SplitN(clean, " = ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		val := strings.Trim(strings.TrimSpace(parts[1]), "\"")
+		switch strings.TrimSpace(parts[0]) {
+		case "DestinationID":
+			cur.ID = val
+		case "LastKnownEncryptionState":
+			cur.Encryption = val
+		case "QuotaGB":
+			cur.QuotaGB, _ = strconv.ParseInt(val, 10, 64)
+		case "BytesUsed":
+			cur.BytesUsed, _ = strconv.ParseInt(val, 10, 64)
+		case "BytesAvailable":
+			cur.BytesAvailable, _ = strconv.ParseInt(val, 10, 64)
+		}
+	}
+	return dests
+}
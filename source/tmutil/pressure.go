@@ -0,0 +1,186 @@
+//
+// pressure.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// VolumePressure is the free space and local snapshot load of one APFS
+// volume.
+type VolumePressure struct {
+	MountPoint string
+	Size       int64 // bytes
+	Free       int64 // bytes available, as df reports it
+	Used       int64 // bytes in use, including data held only by snapshots
+	FreePct    int
+	Snapshots  int
+	Oldest     time.Time // zero without snapshots
+	Held       int64     // estimated bytes held by snapshots; -1 when not measured
+	Status     CheckStatus
+}
+
+// GetDiskPressure reports every mounted APFS volume's free space and local
+// snapshots. With measure set it also estimates the space the snapshots
+// hold as the volume's used space less what du finds on it, which reads
+// the whole volume and is slow.
+func GetDiskPressure(measure bool) ([]VolumePressure, error) {
+	vols, err := MountedVolumes()
+	if err != nil {
+		return nil, err
+	}
+	ctx := currentContext()
+	var out []VolumePressure
+	for _, v := range vols {
+		if err := cancelled(ctx); err != nil {
+			return nil, err
+		}
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(v, &st); err != nil || st.Blocks == 0 {
+			continue
+		}
+		p := VolumePressure{
+			MountPoint: v,
+			Size:       int64(st.Blocks) * int64(st.Bsize),
+			Free:       int64(st.Bavail) * int64(st.Bsize),
+			Used:       int64(st.Blocks-st.Bfree) * int64(st.Bsize),
+			FreePct:    int(st.Bavail * 100 / st.Blocks),
+			Held:       -1,
+		}
+		if snaps, err := GetLocalSnapshots(v); err == nil {
+			p.Snapshots = len(snaps)
+			for _, s := range snaps {
+				if s.Date != nil && (p.Oldest.IsZero() || s.Date.Before(p.Oldest)) {
+					p.Oldest = *s.Date
+				}
+			}
+		}
+		if measure && p.Snapshots > 0 {
+			if live, err := liveBytes(v); err == nil {
+				p.Held = max(p.Used-live, 0)
+			}
+		}
+		switch {
+		case p.FreePct < freeSpaceFail:
+			p.Status = CheckFail
+		case p.FreePct < freeSpaceWarn:
+			p.Status = CheckWarn
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// liveBytes returns the space used by the files on a volume or under a
+// folder, per du -skx.
+// du still prints a total when some folders are unreadable, so that total
+// is used; it then undercounts and the snapshot estimate runs high.
+func liveBytes(mountPoint string) (int64, error) {
+	output, err := exec.CommandContext(currentContext(), "du", "-skx", mountPoint).Output()
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		if err == nil {
+			err = fmt.Errorf("du printed nothing for %s", mountPoint)
+		}
+		return 0, err
+	}
+	kb, perr := strconv.ParseInt(fields[0], 10, 64)
+	if perr != nil {
+		return 0, perr
+	}
+	return kb * 1024, nil
+}
+
+// DiskPressure shows the free space on each APFS volume next to its local
+// snapshots and flags volumes low enough on space that macOS is thinning
+// them. It explains a full disk that Finder still shows space on: Finder
+// counts the snapshots' data as purgeable.
+// args[0] = "y" to estimate the space held by snapshots (optional;
+// "--measure" also accepted). This runs du over each volume, so it is slow.
+func DiskPressure(args []string) (string, error) {
+	measure := false
+	for i, a := range args {
+		if a == "--measure" {
+			measure = true
+			continue
+		}
+		if i == 0 && a != "" {
+			v, err := yesNo("measure", a)
+			if err != nil {
+				return "", err
+			}
+			measure = v
+		}
+	}
+	vols, err := GetDiskPressure(measure)
+	if err != nil {
+		return "", err
+	}
+	if len(vols) == 0 {
+		return "No mounted APFS volumes found.", nil
+	}
+	return formatDiskPressure(vols, measure), nil
+}
+
+func formatDiskPressure(vols []VolumePressure, measure bool) string {
+	rows := [][]string{{"Volume", "Free", "Size", "Snapshots", "Oldest", "Held", "State"}}
+	pressured := false
+	for _, v := range vols {
+		oldest, held := "-", "-"
+		if !v.Oldest.IsZero() {
+			oldest = FormatTimeShort(v.Oldest)
+		}
+		if v.Held >= 0 {
+			held = "~" + FormatBytesInt64(v.Held)
+		}
+		state := "ok"
+		switch v.Status {
+		case CheckFail:
+			state = "under pressure"
+			pressured = true
+		case CheckWarn:
+			state = "low"
+			pressured = true
+		}
+		rows = append(rows, []string{
+			v.MountPoint,
+			fmt.Sprintf("%s (%d%%)", FormatBytesInt64(v.Free), v.FreePct),
+			FormatBytesInt64(v.Size),
+			strconv.Itoa(v.Snapshots),
+			oldest, held, state,
+		})
+	}
+
+	var b strings.Builder
+	b.WriteString("Local Snapshot Disk Pressure\n")
+	b.WriteString(Rule(60) + "\n\n")
+	for _, line := range strings.Split(alignColumns(rows), "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+	b.WriteString("\nFree space is what df reports. Finder adds the space held by local\n")
+	b.WriteString("snapshots, which macOS counts as purgeable, so it can show room on a\n")
+	b.WriteString("disk that is in fact full.")
+	if !measure {
+		b.WriteString("\nAdd --measure to estimate the space the snapshots hold (slow).")
+	} else {
+		b.WriteString("\nHeld is used space less what du finds; unreadable folders push it up.")
+	}
+	if pressured {
+		fmt.Fprintf(&b, "\n\nA volume under %d%% free is where macOS starts thinning local snapshots\n", freeSpaceWarn)
+		b.WriteString("on its own. To free space now, thin or delete them from Snapshots →\nThin Snapshots.")
+	}
+	return b.String()
+}
@@ -0,0 +1,206 @@
+//
+// preview.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// Kinds of exclusion.
+const (
+	ExcludeFixed  = "fixed"  // a path in the preferences' SkipPaths (tmutil addexclusion -p)
+	ExcludeSticky = "sticky" // an item flagged with tmutil addexclusion, followed when moved
+)
+
+// ExcludedPath is one item Time Machine skips.
+type ExcludedPath struct {
+	Path string
+	Kind string // ExcludeFixed or ExcludeSticky
+	Size int64  // bytes, per du; -1 when the path is missing or unreadable
+}
+
+// BackupPreview is what the next backup is expected to skip and copy.
+type BackupPreview struct {
+	Excluded      []ExcludedPath
+	ExcludedBytes int64          // total size of the excluded paths that could be measured
+	VolumeUsed    int64          // used space on the startup volume
+	Changes       *CompareResult // changes since the latest backup; nil before the first one
+}
+
+// Included estimates the bytes the next backup copies: what changed since
+// the latest backup outside the exclusions or, before the first backup,
+// the startup volume's used space less the exclusions.
+func (p BackupPreview) Included() int64 {
+	if p.Changes == nil {
+		return max(p.VolumeUsed-p.ExcludedBytes, 0)
+	}
+	return totalSize(p.Changes.Added) + totalSize(p.Changes.Changed)
+}
+
+// GetExclusions lists the fixed-path exclusions from the Time Machine
+// preferences and the sticky ones Spotlight knows about. A leading "~" in
+// a fixed path is expanded to the current user's home. Either source may be
+// unavailable, in which case its exclusions are left out.
+func GetExclusions() ([]ExcludedPath, error) {
+	ctx := currentContext()
+	var out []ExcludedPath
+	seen := make(map[string]bool)
+	add := func(path, kind string) {
+		if path == "" || seen[path] {
+			return
+		}
+		seen[path] = true
+		out = append(out, ExcludedPath{Path: path, Kind: kind, Size: -1})
+	}
+
+	home, _ := os.UserHomeDir()
+	for _, key := range []string{"SkipPaths", "ExcludeByPath"} {
+		raw, err := exec.CommandContext(ctx, "defaults", "read", tmPlistDomain, key).Output()
+		if err != nil {
+			continue // the key is absent without exclusions
+		}
+		for _, p := range parseStringArray(string(raw)) {
+			if rest, ok := strings.CutPrefix(p, "~"); ok && home != "" {
+				p = filepath.Join(home, rest)
+			}
+			add(p, ExcludeFixed)
+		}
+	}
+	if raw, err := exec.CommandContext(ctx, "mdfind", "com_apple_backup_excludeItem = 'com.apple.backupd'").Output(); err == nil {
+		for _, p := range strings.Split(string(raw), "\n") {
+			add(strings.TrimSpace(p), ExcludeSticky)
+		}
+	}
+	if err := cancelled(ctx); err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+// parseStringArray parses a `defaults read` array of strings:
+//
+//	(
+//	    "~/Downloads",
+//	    "/Users/me/VMs"
+//	)
+func parseStringArray(raw string) []string {
+	var items []string
+	for _, line := range strings.Split(raw, "\n") {
+		s := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), ","))
+		if s == "" || s == "(" || s == ")" {
+			continue
+		}
+		items = append(items, strings.Trim(s, "\""))
+	}
+	return items
+}
+
+// GetBackupPreview measures the exclusions and compares the system with
+// the latest backup. Measuring runs du over every excluded path, so it is
+// slow for large ones.
+func GetBackupPreview() (BackupPreview, error) {
+	var p BackupPreview
+	excluded, err := GetExclusions()
+	if err != nil {
+		return p, err
+	}
+	ctx := currentContext()
+	for i, e := range excluded {
+		if err := cancelled(ctx); err != nil {
+			return p, err
+		}
+		if n, err := liveBytes(e.Path); err == nil {
+			excluded[i].Size = n
+			p.ExcludedBytes += n
+		}
+	}
+	p.Excluded = excluded
+
+	var st syscall.Statfs_t
+	if err := syscall.Statfs("/", &st); err == nil {
+		p.VolumeUsed = int64(st.Blocks-st.Bfree) * int64(st.Bsize)
+	}
+	if latest, err := LatestBackup(); err == nil && strings.TrimSpace(latest) != "" {
+		if r, err := GetCompare(nil); err == nil {
+			r.Added = outsideExclusions(r.Added, excluded)
+			r.Changed = outsideExclusions(r.Changed, excluded)
+			p.Changes = &r
+		}
+	}
+	return p, nil
+}
+
+// outsideExclusions drops the entries at or under an excluded path.
+func outsideExclusions(entries []CompareEntry, excluded []ExcludedPath) []CompareEntry {
+	var kept []CompareEntry
+	for _, e := range entries {
+		skip := false
+		for _, x := range excluded {
+			if e.Path == x.Path || strings.HasPrefix(e.Path, strings.TrimSuffix(x.Path, "/")+"/") {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// BackupPreviewReport shows, before a backup is started, which paths it
+// will skip and roughly how much it will copy, so exclusions can be
+// checked before a large first backup.
+func BackupPreviewReport() (string, error) {
+	p, err := GetBackupPreview()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("Backup Preview\n")
+	b.WriteString(Rule(60) + "\n\n")
+
+	if len(p.Excluded) == 0 {
+		b.WriteString("Nothing is excluded: the next backup includes everything.\n")
+	} else {
+		fmt.Fprintf(&b, "Excluded (%d, %s):\n", len(p.Excluded), FormatBytesInt64(p.ExcludedBytes))
+		rows := make([][]string, 0, len(p.Excluded))
+		for _, e := range p.Excluded {
+			size := "missing"
+			if e.Size >= 0 {
+				size = FormatBytesInt64(e.Size)
+			}
+			rows = append(rows, []string{size, e.Kind, e.Path})
+		}
+		for _, line := range strings.Split(alignColumns(rows), "\n") {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if p.Changes == nil {
+		fmt.Fprintf(&b, "First backup: about %s to copy (%s used on the startup volume, less exclusions).\n",
+			FormatBytesInt64(p.Included()), FormatBytesInt64(p.VolumeUsed))
+		b.WriteString("Other volumes included in backups add to this.")
+	} else {
+		fmt.Fprintf(&b, "Next backup: about %s to copy (%d added, %d changed since the latest backup).",
+			FormatBytesInt64(p.Included()), len(p.Changes.Added), len(p.Changes.Changed))
+	}
+	b.WriteString("\n\nTo change what is skipped, use Exclusions → Add or Remove Exclusion.")
+	return b.String(), nil
+}
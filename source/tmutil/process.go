@@ -0,0 +1,31 @@
+//
+// process.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// inGroup starts cmd, made with exec.CommandContext, in a process group of
+// its own, and makes cancelling the context kill the whole group. Killing
+// only cmd would leave what it started behind: the shell of a snapshot
+// hook, or a tmutil that forks, whose children keep running and hold the
+// output pipe open. Being in its own group, cmd no longer gets the
+// terminal's ctrl+c; the CLI cancels the context on SIGINT instead.
+func inGroup(cmd *exec.Cmd) *exec.Cmd {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		// A negative pid signals the group, whose id is cmd's pid.
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	return cmd
+}
@@ -0,0 +1,92 @@
+//
+// process_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// alive reports whether pid is running; a zombie, killed but not yet
+// reaped by whoever inherited it, is not.
+func alive(pid int) bool {
+	if syscall.Kill(pid, 0) != nil {
+		return false
+	}
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return true
+	}
+	// The state follows the command, which is in parentheses.
+	_, rest, _ := strings.Cut(string(stat), ") ")
+	return !strings.HasPrefix(rest, "Z")
+}
+
+func TestStreamCancelKeepsPartialOutput(t *testing.T) {
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "child.pid")
+	// A tmutil that forks a child, prints two lines and hangs.
+	script := "#!/bin/sh\nsleep 60 &\necho $! > " + ShellQuote(pidFile) + "\necho first\necho second\nwait\n"
+	fake := filepath.Join(dir, "tmutil")
+	if err := os.WriteFile(fake, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TMCLI_TMUTIL", fake)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	SetContext(ctx)
+	t.Cleanup(func() { SetContext(nil) })
+
+	start := time.Now()
+	output, err := runStream(func(line string) {
+		if line == "second" {
+			cancel()
+		}
+	}, "listbackups")
+
+	if output != "" {
+		t.Errorf("output = %q, want none alongside the error", output)
+	}
+	if !errors.Is(err, ErrCancelled) {
+		t.Fatalf("err = %v, want ErrCancelled", err)
+	}
+	if partial, ok := PartialOutput(err); !ok || partial != "first\nsecond" {
+		t.Errorf("PartialOutput = %q, %v; want the two lines printed", partial, ok)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancelling took %s", elapsed)
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(2 * time.Second); alive(pid); {
+		if time.Now().After(deadline) {
+			syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatalf("child %d of the cancelled tmutil survived", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
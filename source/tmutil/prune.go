@@ -0,0 +1,282 @@
+//
+// prune.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// PruneStep is what deleting the oldest Count backups together would free.
+type PruneStep struct {
+	Count  int
+	Newest string // the newest of the backups deleted
+	Frees  int64  // bytes freed
+}
+
+// PrunePlan is the space freed by deleting the oldest 1, 2, ... backups.
+type PrunePlan struct {
+	Steps   []PruneStep // one per backup, oldest first
+	Free    int64       // bytes free on the destination now; -1 if unknown
+	Skipped int         // directories that could not be read, so were not counted
+}
+
+// pruneCache holds the last plan with the backup list it was worked out
+// from. Walking every backup is slow, and the plan only changes when a
+// backup is added or deleted.
+var pruneCache struct {
+	sync.Mutex
+	key  string
+	plan PrunePlan
+}
+
+// fileKey identifies a file's data across backups. Hard-linked copies on
+// HFS+ destinations and unchanged files across APFS snapshots keep their
+// inode number; the size and modification time tell a file that changed
+// in place apart from the copy before it.
+type fileKey struct {
+	volume string // source volume directory within the backup
+	ino    uint64
+	size   int64
+	mtime  int64
+}
+
+// GetPrunePlan works out the space freed by deleting the oldest backups,
+// for every number of them. Data shared with a newer backup is only freed
+// once that backup goes too, so each step counts the files whose newest
+// copy is among the backups deleted, by their allocated blocks. This walks
+// every file of every backup, which is slow; the result is cached until the
+// backup list changes. Progress, which may be nil, gets a line per backup.
+func GetPrunePlan(progress func(string)) (PrunePlan, error) {
+	paths, err := listBackupPaths()
+	if err != nil {
+		return PrunePlan{}, err
+	}
+	if len(paths) == 0 {
+		return PrunePlan{}, ErrNoBackups
+	}
+	key := strings.Join(paths, "\n")
+	pruneCache.Lock()
+	if pruneCache.key == key {
+		plan := pruneCache.plan
+		plan.Steps = append([]PruneStep(nil), plan.Steps...)
+		pruneCache.Unlock()
+		return plan, nil
+	}
+	pruneCache.Unlock()
+
+	freed, skipped, err := freedByNewest(paths, progress)
+	if err != nil {
+		return PrunePlan{}, err
+	}
+	plan := PrunePlan{Free: -1, Skipped: skipped}
+	var total int64
+	for i, p := range paths {
+		total += freed[i]
+		plan.Steps = append(plan.Steps, PruneStep{Count: i + 1, Newest: p, Frees: total})
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(backupVolume(paths[0]), &st); err == nil {
+		plan.Free = int64(st.Bavail) * int64(st.Bsize)
+	}
+
+	pruneCache.Lock()
+	pruneCache.key, pruneCache.plan = key, plan
+	pruneCache.Unlock()
+	plan.Steps = append([]PruneStep(nil), plan.Steps...)
+	return plan, nil
+}
+
+// freedByNewest walks paths, oldest first, and returns for each backup the
+// bytes of the files whose newest copy it holds, with the number of
+// directories that could not be read.
+func freedByNewest(paths []string, progress func(string)) ([]int64, int, error) {
+	type fileData struct {
+		newest int
+		bytes  int64
+	}
+	seen := make(map[fileKey]fileData)
+	skipped := 0
+	ctx := currentContext()
+	for i, root := range paths {
+		if progress != nil {
+			progress(fmt.Sprintf("Reading backup %d of %d: %s", i+1, len(paths), filepath.Base(root)))
+		}
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err := cancelled(ctx); err != nil {
+				return err
+			}
+			if err != nil {
+				if path == root {
+					return err
+				}
+				if d != nil && d.IsDir() {
+					skipped++
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			st, ok := info.Sys().(*syscall.Stat_t)
+			if !ok {
+				return nil
+			}
+			rel, _ := filepath.Rel(root, path)
+			volume, _, _ := strings.Cut(rel, string(filepath.Separator))
+			k := fileKey{volume: volume, ino: uint64(st.Ino), size: info.Size(), mtime: info.ModTime().UnixNano()}
+			seen[k] = fileData{newest: i, bytes: int64(st.Blocks) * 512}
+			return nil
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	freed := make([]int64, len(paths))
+	for _, f := range seen {
+		freed[f.newest] += f.bytes
+	}
+	return freed, skipped, nil
+}
+
+// PruneSavings shows how much space deleting the oldest backups would free.
+// args[0] = number of rows to show (optional, default all; "--limit N" also
+// accepted)
+// args[1] = free space to aim for on the destination, e.g. "200G"
+// (optional; "--target SIZE" also accepted). The first row that reaches it
+// is marked.
+func PruneSavings(args []string) (string, error) {
+	return PruneSavingsStream(args, nil)
+}
+
+// PruneSavingsStream is PruneSavings reporting which backup it is reading
+// through progress (which may be nil).
+func PruneSavingsStream(args []string, progress func(string)) (string, error) {
+	var target string
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch a := args[i]; {
+		case a == "--target":
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("--target requires a size")
+			}
+			i++
+			target = args[i]
+		case strings.HasPrefix(a, "--target="):
+			target = strings.TrimPrefix(a, "--target=")
+		default:
+			rest = append(rest, a)
+		}
+	}
+	rest, n, err := parseLimit(rest)
+	if err != nil {
+		return "", err
+	}
+	if n == 0 && len(rest) > 0 && rest[0] != "" {
+		if n, err = limitValue(rest[0]); err != nil {
+			return "", err
+		}
+	}
+	if target == "" && len(rest) > 1 {
+		target = rest[1]
+	}
+	var want int64 = -1
+	if target != "" {
+		if want, err = parseTargetSize(target); err != nil {
+			return "", err
+		}
+	}
+
+	plan, err := GetPrunePlan(progress)
+	if err != nil {
+		return "", err
+	}
+	return formatPrunePlan(plan, n, want), nil
+}
+
+// parseTargetSize reads a size such as "200G", "1.5TB" or a plain number of
+// bytes.
+func parseTargetSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	if len(v) > 2 && strings.HasSuffix(v, "B") {
+		v = strings.TrimSuffix(v, "B")
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+		return n, nil
+	}
+	if n, ok := parseAbbrevSize(v); ok && n > 0 {
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid target %q: expected a size such as 200G", s)
+}
+
+// formatPrunePlan lays the plan out as a table of its first n steps (all
+// when n is 0), marking the first that brings free space to want (when not
+// negative).
+func formatPrunePlan(plan PrunePlan, n int, want int64) string {
+	reached := -1
+	if want >= 0 && plan.Free >= 0 {
+		for i, s := range plan.Steps {
+			if plan.Free+s.Frees >= want {
+				reached = i
+				break
+			}
+		}
+	}
+
+	rows := [][]string{{"Delete oldest", "Through", "Frees", "Free after", ""}}
+	for i, s := range plan.Steps {
+		if n > 0 && i >= n && i != reached {
+			continue
+		}
+		through := filepath.Base(s.Newest)
+		if d, err := parseBackupDate(s.Newest); err == nil {
+			through = FormatTimeShort(d.Local())
+		}
+		after, mark := "-", ""
+		if plan.Free >= 0 {
+			after = "~" + FormatBytesInt64(plan.Free+s.Frees)
+		}
+		if i == reached {
+			mark = "<- reaches " + FormatBytesInt64(want)
+		}
+		rows = append(rows, []string{strconv.Itoa(s.Count), through, "~" + FormatBytesInt64(s.Frees), after, mark})
+	}
+
+	var b strings.Builder
+	b.WriteString("Space Freed by Deleting the Oldest Backups\n")
+	b.WriteString(Rule(60) + "\n\n")
+	for _, line := range strings.Split(alignColumns(rows), "\n") {
+		b.WriteString(strings.TrimRight("  "+line, " ") + "\n")
+	}
+	if plan.Free >= 0 {
+		fmt.Fprintf(&b, "\nFree on the destination now: %s.", FormatBytesInt64(plan.Free))
+	}
+	if want >= 0 && plan.Free >= 0 && reached < 0 {
+		fmt.Fprintf(&b, "\nDeleting every backup would not bring free space to %s.", FormatBytesInt64(want))
+	}
+	b.WriteString("\nData still held by a newer backup is only freed with it, so each row\n")
+	b.WriteString("counts the files whose newest copy is among the backups deleted.")
+	if plan.Skipped > 0 {
+		fmt.Fprintf(&b, "\n%d folder(s) could not be read and are not counted.", plan.Skipped)
+	}
+	return b.String()
+}
@@ -0,0 +1,342 @@
+//
+// recent.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultRecentCount is how many backups Recent shows by default.
+const DefaultRecentCount = 5
+
+// RecentBackup is one completed backup in the recent view.
+type RecentBackup struct {
+	Path        string
+	Date        time.Time // zero when the path carries no date
+	Destination string    // destination name, or the volume the backup is on
+	UniqueSize  string    // tmutil uniquesize figure; empty unless requested
+}
+
+// GetRecentBackups returns the newest n completed backups, newest first.
+// With sizes set it also runs uniquesize on each one, which is slow.
+func GetRecentBackups(n int, sizes bool) ([]RecentBackup, error) {
+	entries, err := ListBackupEntries()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	if dests, err := ListDestinations(); err == nil {
+		for _, d := range dests {
+			if d.MountPoint != "" {
+				names[d.MountPoint] = d.Name
+			}
+		}
+	}
+
+	backups := make([]RecentBackup, 0, len(entries))
+	for _, e := range entries {
+		b := RecentBackup{Path: e.Path, Date: e.Date, Destination: e.Destination}
+		if name := names[b.Destination]; name != "" {
+			b.Destination = name
+		}
+		backups = append(backups, b)
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Date.After(backups[j].Date)
+	})
+	if len(backups) > n {
+		backups = backups[:n]
+	}
+
+	if sizes {
+		ctx := currentContext()
+		for i := range backups {
+			if err := cancelled(ctx); err != nil {
+				return nil, err
+			}
+			if size, err := UniqueSizeOf(backups[i].Path); err == nil {
+				backups[i].UniqueSize = size
+			}
+		}
+	}
+	return backups, nil
+}
+
+// backupVolume returns the /Volumes mount point a backup path lives on,
+// or the path's directory when it is elsewhere.
+func backupVolume(path string) string {
+	if rest, ok := strings.CutPrefix(path, "/Volumes/"); ok {
+		if name, _, _ := strings.Cut(rest, "/"); name != "" {
+			return "/Volumes/" + name
+		}
+	}
+	return filepath.Dir(path)
+}
+
+// Recent summarizes the most recent completed backups: when each was taken,
+// on which destination, and optionally how much data only it holds.
+// args[0] = number of backups (optional, default 5; "--limit N" also accepted)
+// args[1] = "y" to include each backup's unique size (optional; "--sizes"
+// also accepted). This runs uniquesize once per backup, so it is slow.
+func Recent(args []string) (string, error) {
+	sizes := false
+	var rest []string
+	for _, a := range args {
+		if a == "--sizes" {
+			sizes = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	rest, n, err := parseLimit(rest)
+	if err != nil {
+		return "", err
+	}
+	if n == 0 && len(rest) > 0 && rest[0] != "" {
+		if n, err = limitValue(rest[0]); err != nil {
+			return "", err
+		}
+	}
+	if n == 0 {
+		n = DefaultRecentCount
+	}
+	if len(rest) > 1 {
+		v, err := yesNo("unique sizes", rest[1])
+		if err != nil {
+			return "", err
+		}
+		sizes = sizes || v
+	}
+
+	backups, err := GetRecentBackups(n, sizes)
+	if err != nil {
+		return "", err
+	}
+	return formatRecent(backups, time.Now()), nil
+}
+
+func formatRecent(backups []RecentBackup, now time.Time) string {
+	var b strings.Builder
+	b.WriteString("Recent Backups\n")
+	b.WriteString(Rule(40) + "\n")
+	for _, r := range backups {
+		when := "unknown date"
+		if !r.Date.IsZero() {
+			when = FormatTime(r.Date)
+		}
+		fmt.Fprintf(&b, "\n  %-19s  %-16s", when, r.Destination)
+		if !r.Date.IsZero() && !RelativeTimes() {
+			fmt.Fprintf(&b, "  %s", FormatRelative(r.Date, now))
+		}
+		if r.UniqueSize != "" {
+			fmt.Fprintf(&b, "  [%s unique]", r.UniqueSize)
+		}
+		fmt.Fprintf(&b, "\n  %s\n", r.Path)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// DefaultRecentFilesBackups is how many backups RecentFiles looks through
+// by default, and DefaultRecentFilesLimit how many files it lists.
+const (
+	DefaultRecentFilesBackups = 3
+	DefaultRecentFilesLimit   = 50
+)
+
+// ChangedFile is a file a backup copied because it changed since the
+// backup before.
+type ChangedFile struct {
+	Match
+	Backup time.Time // date of the backup that first holds this version
+}
+
+// RecentFilesResult is what RecentFiles found.
+type RecentFilesResult struct {
+	Files   []ChangedFile // newest modification first, at most the limit asked for
+	Changed int           // files changed in all, before the limit
+	Bytes   int64         // their total size
+	Backups int           // backups looked through
+	Skipped int           // unreadable folders skipped
+}
+
+// GetRecentFiles finds the files changed in the newest n backups and
+// returns the limit most recently modified. A file counts against the
+// backup that first holds it when its modification time falls between
+// that backup's date and the date of the backup before it, so each
+// version is counted once, without comparing backups file by file. Hidden
+// and system folders such as .fseventsd are left out. Progress, which may
+// be nil, gets a running count of the directories read.
+func GetRecentFiles(n, limit int, progress func(string)) (RecentFilesResult, error) {
+	paths, err := listBackupPaths()
+	if err != nil {
+		return RecentFilesResult{}, err
+	}
+	if len(paths) == 0 {
+		return RecentFilesResult{}, ErrNoBackups
+	}
+	start := max(len(paths)-n, 0)
+	result := RecentFilesResult{Backups: len(paths) - start}
+	scan := &scanCounter{progress: progress}
+	ctx := currentContext()
+	for i := len(paths) - 1; i >= start; i-- {
+		root := paths[i]
+		taken, err := parseBackupDate(root)
+		if err != nil {
+			continue
+		}
+		var since time.Time // the first backup holds everything
+		if i > 0 {
+			since, _ = parseBackupDate(paths[i-1])
+		}
+		step := len(paths) - i
+		scan.step = fmt.Sprintf("Reading backup %d of %d (%d%%)", step, result.Backups, (step-1)*100/result.Backups)
+		scan.report(root)
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err := cancelled(ctx); err != nil {
+				return err
+			}
+			if err != nil {
+				if path == root {
+					return err
+				}
+				if d != nil && d.IsDir() {
+					result.Skipped++
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				if path != root && IsHiddenEntry(d.Name()) {
+					return filepath.SkipDir
+				}
+				scan.visit(path)
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			mod := info.ModTime()
+			if mod.After(taken) || !since.IsZero() && !mod.After(since) {
+				return nil
+			}
+			result.Changed++
+			result.Bytes += info.Size()
+			result.Files = append(result.Files, ChangedFile{Match: Match{Path: path, Snapshot: root, Size: info.Size(), Modified: &mod}, Backup: taken})
+			// Keep memory bounded on a backup full of changes.
+			if len(result.Files) > 2*limit {
+				result.Files = newestFiles(result.Files, limit)
+			}
+			return nil
+		})
+		if errors.Is(err, ErrCancelled) {
+			return RecentFilesResult{}, err
+		}
+		if err != nil {
+			result.Skipped++
+		}
+	}
+	result.Files = newestFiles(result.Files, limit)
+	return result, nil
+}
+
+// newestFiles sorts files by modification time, newest first, and keeps
+// the first limit.
+func newestFiles(files []ChangedFile, limit int) []ChangedFile {
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Modified.After(*files[j].Modified) })
+	if len(files) > limit {
+		files = files[:limit]
+	}
+	return files
+}
+
+// RecentFiles lists the files that changed most recently across the
+// newest backups: what recent backups actually copied.
+// args[0] = number of backups to look through (optional, default 3)
+// args[1] = number of files to list (optional, default 50; "--limit N" also
+// accepted)
+// It reads every file of those backups, so it can take a while.
+func RecentFiles(args []string) (string, error) {
+	return RecentFilesStream(args, nil)
+}
+
+// RecentFilesStream is RecentFiles reporting how many directories it has
+// read through progress (which may be nil).
+func RecentFilesStream(args []string, progress func(string)) (string, error) {
+	rest, limit, err := parseLimit(args)
+	if err != nil {
+		return "", err
+	}
+	n := DefaultRecentFilesBackups
+	if len(rest) > 0 && rest[0] != "" {
+		if n, err = limitValue(rest[0]); err != nil {
+			return "", err
+		}
+	}
+	if limit == 0 && len(rest) > 1 && rest[1] != "" {
+		if limit, err = limitValue(rest[1]); err != nil {
+			return "", err
+		}
+	}
+	if limit == 0 {
+		limit = DefaultRecentFilesLimit
+	}
+	result, err := GetRecentFiles(n, limit, progress)
+	if err != nil {
+		return "", err
+	}
+	return formatRecentFiles(result), nil
+}
+
+// formatRecentFiles lists the files under the backup that copied them,
+// newest backup first.
+func formatRecentFiles(r RecentFilesResult) string {
+	if r.Changed == 0 {
+		msg := fmt.Sprintf("No changed files found in the last %d backup(s).", r.Backups)
+		if r.Skipped > 0 {
+			msg += fmt.Sprintf("\n# Skipped %d unreadable folder(s); run with sudo or grant Full Disk Access to read them", r.Skipped)
+		}
+		return msg
+	}
+	files := append([]ChangedFile(nil), r.Files...)
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Backup.After(files[j].Backup) })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d file(s) changed in the last %d backup(s), %s in all", r.Changed, r.Backups, FormatBytesInt64(r.Bytes))
+	if len(files) < r.Changed {
+		fmt.Fprintf(&b, "; the %d most recently modified:", len(files))
+	} else {
+		b.WriteString(":")
+	}
+	var last time.Time
+	for _, f := range files {
+		if !f.Backup.Equal(last) {
+			last = f.Backup
+			fmt.Fprintf(&b, "\n\nBacked up %s\n", FormatTimeShort(f.Backup))
+		} else {
+			b.WriteString("\n")
+		}
+		b.WriteString(f.datedString())
+	}
+	if r.Skipped > 0 {
+		fmt.Fprintf(&b, "\n\n# Skipped %d unreadable folder(s); run with sudo or grant Full Disk Access to read them", r.Skipped)
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+//
+// redact.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// redactMode masks identifying details in output, for screenshots and logs
+// shared when asking for help.
+var redactMode atomic.Bool
+
+// SetRedact turns redaction on or off.
+func SetRedact(on bool) {
+	redactMode.Store(on)
+}
+
+// Redacting reports whether redaction is on.
+func Redacting() bool {
+	return redactMode.Load()
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`\b[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{8}([0-9A-Fa-f]{4})\b`)
+	// urlPattern captures a URL's scheme and its authority, which holds the
+	// user name and host. Escape sequences end it, so styled text in the
+	// TUI keeps its styles.
+	urlPattern = regexp.MustCompile(`\b([a-z][a-z0-9+.-]*://)([^/\s"'\x1b]+)`)
+)
+
+// hostPattern matches this Mac's host and computer names as whole words;
+// they appear in backup paths and destination details. It is nil when the
+// names are unknown.
+var hostPattern = sync.OnceValue(func() *regexp.Regexp {
+	var names []string
+	if h, err := os.Hostname(); err == nil {
+		names = append(names, h, strings.TrimSuffix(h, ".local"))
+	}
+	if out, err := exec.Command("scutil", "--get", "ComputerName").Output(); err == nil {
+		names = append(names, strings.TrimSpace(string(out)))
+	}
+	var alts []string
+	for _, n := range names {
+		if n != "" {
+			alts = append(alts, regexp.QuoteMeta(n))
+		}
+	}
+	if len(alts) == 0 {
+		return nil
+	}
+	// Longest first, so "mac.local" is masked whole before "mac".
+	sort.Slice(alts, func(i, j int) bool { return len(alts[i]) > len(alts[j]) })
+	return regexp.MustCompile(`\b(` + strings.Join(alts, "|") + `)\b`)
+})
+
+// mask replaces each character of s with '*', keeping its width.
+func mask(s string) string {
+	return strings.Repeat("*", len([]rune(s)))
+}
+
+// Redact masks destination and snapshot UUIDs (all but their last four
+// digits, so entries can still be told apart), the user and host in
+// network URLs, and this Mac's host and computer names. It returns s
+// unchanged unless redaction is on. Every mask is as wide as what it
+// hides, so tables and frames stay aligned.
+func Redact(s string) string {
+	if !Redacting() {
+		return s
+	}
+	s = uuidPattern.ReplaceAllString(s, "XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXX$1")
+	s = urlPattern.ReplaceAllStringFunc(s, func(u string) string {
+		m := urlPattern.FindStringSubmatch(u)
+		return m[1] + mask(m[2])
+	})
+	if re := hostPattern(); re != nil {
+		s = re.ReplaceAllStringFunc(s, mask)
+	}
+	return s
+}
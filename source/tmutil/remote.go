@@ -0,0 +1,118 @@
+//
+// remote.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// remote is the Mac that tmutil, defaults and mount run on, as ssh
+// addresses it ("user@mac"); "" runs them here, the default.
+var remote = struct {
+	sync.Mutex
+	host string
+}{}
+
+// SetHost makes subsequent tmutil, defaults and mount calls run on host
+// over SSH; "" runs them locally again. tmcli cannot answer a password
+// prompt, so host must accept key-based logins.
+func SetHost(host string) {
+	remote.Lock()
+	remote.host = host
+	remote.Unlock()
+}
+
+// RemoteHost returns the host set with SetHost, or "" when running locally.
+func RemoteHost() string {
+	remote.Lock()
+	defer remote.Unlock()
+	return remote.host
+}
+
+// sshOptions keep ssh from prompting, which would hang a command with no
+// terminal to answer it, and bound how long an unreachable host takes.
+var sshOptions = []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=10"}
+
+// sshExitCode is the status ssh exits with for its own errors, such as a
+// refused connection or a rejected key, as opposed to the remote
+// command's.
+const sshExitCode = 255
+
+// hostCommand returns the command that runs name with args on the Mac
+// being managed: directly, or through ssh on the remote host, with each
+// argument quoted for the remote shell.
+func hostCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	host := RemoteHost()
+	if host == "" {
+		return inGroup(exec.CommandContext(ctx, name, args...))
+	}
+	line := make([]string, 0, len(args)+1)
+	line = append(line, ShellQuote(name))
+	for _, a := range args {
+		line = append(line, ShellQuote(a))
+	}
+	sshArgs := append(append([]string(nil), sshOptions...), host, "--", strings.Join(line, " "))
+	return inGroup(exec.CommandContext(ctx, "ssh", sshArgs...))
+}
+
+// tmutilCommand is hostCommand for tmutil. TMCLI_TMUTIL names a local
+// program, so a remote host always runs its own tmutil.
+func tmutilCommand(ctx context.Context, args ...string) *exec.Cmd {
+	if RemoteHost() != "" {
+		return hostCommand(ctx, "tmutil", args...)
+	}
+	return hostCommand(ctx, binary(), args...)
+}
+
+// mountTable returns mount(8) output from the Mac being managed.
+func mountTable() (string, error) {
+	output, err := hostCommand(currentContext(), "mount").Output()
+	return string(output), err
+}
+
+// SSHError is returned when ssh itself fails to run a command on the
+// remote host, so tmutil never ran; failures of tmutil on the host are
+// TmutilErrors as they are locally.
+type SSHError struct {
+	Host   string
+	Output string // ssh's message, trimmed
+	Err    error
+}
+
+func (e *SSHError) Error() string {
+	if e.Output != "" {
+		return fmt.Sprintf("ssh to %s failed: %s", e.Host, firstLine(e.Output))
+	}
+	return fmt.Sprintf("ssh to %s failed: %v", e.Host, e.Err)
+}
+
+func (e *SSHError) Unwrap() error {
+	return e.Err
+}
+
+// sshFailure returns an SSHError when err is ssh failing to reach the
+// remote host, or nil when tmcli runs locally or the remote command ran.
+func sshFailure(output string, err error) error {
+	host := RemoteHost()
+	if host == "" || err == nil {
+		return nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() != sshExitCode {
+		return nil
+	}
+	return &SSHError{Host: host, Output: strings.TrimSpace(output), Err: err}
+}
@@ -11,113 +11,418 @@
 package tmutil
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 )
 
 // backupPathDateLayout is the date format embedded in backup path names.
 const backupPathDateLayout = "2006-01-02-150405"
 
-// defaultFindLimit is the default number of backup snapshots to search.
-const defaultFindLimit = 5
+// DefaultFindLimit is the default number of backup snapshots FindFile searches.
+const DefaultFindLimit = 5
 
 // Restore restores files from a backup.
 func Restore(args []string) (string, error) {
+	return RestoreStream(args, nil)
+}
+
+// RestoreStream restores files from a backup, passing each line of
+// `tmutil restore -v` output to progress as it arrives (progress may be
+// nil), and returns a summary of the files and bytes now at the
+// destination plus any errors.
+func RestoreStream(args []string, progress func(string)) (string, error) {
 	if len(args) < 2 {
 		return "", fmt.Errorf("source and destination paths are required")
 	}
+	// Where each source lands depends on whether the destination is an
+	// existing directory, so decide before the restore creates it.
+	targets := restoreTargets(args[:len(args)-1], args[len(args)-1])
+	start := time.Now()
 	cmdArgs := append([]string{"restore", "-v"}, args...)
-	return run(cmdArgs...)
+	output, err := runStream(progress, cmdArgs...)
+	if err != nil {
+		return "", err
+	}
+	elapsed := time.Since(start).Round(time.Second)
+
+	var problems []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		l := strings.ToLower(line)
+		if strings.Contains(l, "error") || strings.Contains(l, "failed") || strings.Contains(l, "denied") {
+			problems = append(problems, line)
+		}
+	}
+	var files, bytes int64
+	for _, t := range targets {
+		f, b, _ := measureTree(t, 0)
+		files += f
+		bytes += b
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Restored %s file(s), %s to %s in %s", formatCount(files), FormatBytesInt64(bytes), args[len(args)-1], FormatDuration(elapsed))
+	if len(problems) > 0 {
+		fmt.Fprintf(&b, "\n\n%d problem(s):", len(problems))
+		for _, p := range problems {
+			fmt.Fprintf(&b, "\n  %s", p)
+		}
+	}
+	return b.String(), nil
+}
+
+// restoreTargets returns where tmutil restore puts each source: inside
+// dest when it is an existing directory, else at dest itself.
+func restoreTargets(sources []string, dest string) []string {
+	if info, err := os.Stat(dest); err != nil || !info.IsDir() {
+		return []string{dest}
+	}
+	targets := make([]string, len(sources))
+	for i, src := range sources {
+		targets[i] = filepath.Join(dest, filepath.Base(filepath.Clean(src)))
+	}
+	return targets
 }
 
 // FindFile searches for a filename/pattern across recent backup snapshots.
+// Each match is annotated with its size when it can be read.
 // args[0] = filename or glob pattern (required)
 // args[1] = max number of backups to search (optional, default 5)
+// args[2] = "y" to sort matches by size, largest first (optional; "--sort=size" also accepted)
+// With "--json" the FindFileResult is emitted as JSON instead.
 func FindFile(args []string) (string, error) {
+	return FindFileStream(args, nil)
+}
+
+// FindFileStream is FindFile reporting a running count of the directories
+// searched through progress (which may be nil).
+func FindFileStream(args []string, progress func(string)) (string, error) {
+	list, err := FindFileList(args, progress)
+	if err != nil {
+		return "", err
+	}
+	return list.String(), nil
+}
+
+// FindFileList is FindFileStream returning the matches with their sizes,
+// so they can be re-sorted by size. With "--json" the JSON is its header.
+func FindFileList(args []string, progress func(string)) (SizedList, error) {
+	asJSON := false
+	var rest []string
+	for _, a := range args {
+		if a == "--json" {
+			asJSON = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	result, err := findFiles(rest, progress)
+	if err != nil {
+		return SizedList{}, err
+	}
+	if asJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return SizedList{}, err
+		}
+		return SizedList{Header: string(data)}, nil
+	}
+	return result.SizedList(), nil
+}
+
+// Match is one FindFile hit.
+type Match struct {
+	Path     string     `json:"path"`
+	Snapshot string     `json:"snapshot"`           // backup the match was found in
+	Date     *time.Time `json:"date,omitempty"`     // date of that backup, if parseable
+	Size     int64      `json:"size"`               // -1 if unreadable or not a regular file
+	Modified *time.Time `json:"modified,omitempty"` // modification time, if readable
+}
+
+// FindFileResult is the structured outcome of a FindFile search.
+type FindFileResult struct {
+	Pattern  string   `json:"pattern"`
+	Matches  []Match  `json:"matches"`
+	Errors   []string `json:"errors"`   // snapshots that could not be scanned
+	Skipped  int      `json:"skipped"`  // unreadable folders skipped
+	Ignored  int      `json:"ignored"`  // items left out by the ignore patterns
+	Searched int      `json:"searched"` // number of backups searched
+	Backups  PageInfo `json:"backups"`  // backups searched out of those available
+
+	// The modification date filter, as given (YYYY-MM-DD, inclusive).
+	ModifiedAfter  string `json:"modifiedAfter,omitempty"`
+	ModifiedBefore string `json:"modifiedBefore,omitempty"`
+}
+
+// filtered reports whether the search was limited by modification date.
+func (r FindFileResult) filtered() bool {
+	return r.ModifiedAfter != "" || r.ModifiedBefore != ""
+}
+
+// FindFiles performs the FindFile search and returns structured results.
+// It takes the same arguments as FindFile, without "--json".
+func FindFiles(args []string) (FindFileResult, error) {
+	return findFiles(args, nil)
+}
+
+func findFiles(args []string, progress func(string)) (FindFileResult, error) {
+	bySize := false
+	var after, before string
+	var pos []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case (args[i] == "--after" || args[i] == "--before") && i+1 < len(args):
+			if args[i] == "--after" {
+				after = args[i+1]
+			} else {
+				before = args[i+1]
+			}
+			i++
+		case strings.HasPrefix(args[i], "--after="):
+			after = strings.TrimPrefix(args[i], "--after=")
+		case strings.HasPrefix(args[i], "--before="):
+			before = strings.TrimPrefix(args[i], "--before=")
+		case args[i] == "--sort=size":
+			bySize = true
+		case args[i] == "--sort" && i+1 < len(args) && args[i+1] == "size":
+			bySize = true
+			i++
+		case args[i] == "--sort" || strings.HasPrefix(args[i], "--sort="):
+			return FindFileResult{}, fmt.Errorf("unsupported sort %q (only --sort=size)", strings.Join(args[i:], " "))
+		default:
+			pos = append(pos, args[i])
+		}
+	}
+	args = pos
 	if len(args) == 0 || args[0] == "" {
-		return "", fmt.Errorf("filename or pattern is required")
+		return FindFileResult{}, fmt.Errorf("filename or pattern is required")
 	}
-	pattern := args[0]
-	limit := defaultFindLimit
+	result := FindFileResult{Pattern: args[0], Matches: []Match{}, Errors: []string{}}
+	limit := DefaultFindLimit
 	if len(args) > 1 && args[1] != "" {
 		n, err := strconv.Atoi(args[1])
 		if err == nil && n > 0 {
 			limit = n
 		}
 	}
+	if len(args) > 2 {
+		v, err := yesNo("sort by size", args[2])
+		if err != nil {
+			return FindFileResult{}, err
+		}
+		bySize = bySize || v
+	}
+	if len(args) > 3 && args[3] != "" {
+		after = args[3]
+	}
+	if len(args) > 4 && args[4] != "" {
+		before = args[4]
+	}
+	// Like FindByDate, the dates are whole local days: from midnight on
+	// the after date to midnight after the before date.
+	var from, until time.Time
+	if after = strings.TrimSpace(after); after != "" {
+		t, err := time.ParseInLocation(dayLayout, after, time.Local)
+		if err != nil {
+			return FindFileResult{}, fmt.Errorf("invalid modified-after date %q: expected YYYY-MM-DD", after)
+		}
+		from = t
+	}
+	if before = strings.TrimSpace(before); before != "" {
+		t, err := time.ParseInLocation(dayLayout, before, time.Local)
+		if err != nil {
+			return FindFileResult{}, fmt.Errorf("invalid modified-before date %q: expected YYYY-MM-DD", before)
+		}
+		until = t.AddDate(0, 0, 1)
+	}
+	if !from.IsZero() && !until.IsZero() && !from.Before(until) {
+		return FindFileResult{}, fmt.Errorf("modified-after date %s is later than modified-before date %s", after, before)
+	}
+	result.ModifiedAfter, result.ModifiedBefore = after, before
 
 	backups, err := listBackupPaths()
 	if err != nil {
-		return "", err
+		return FindFileResult{}, err
 	}
 	reverseStrings(backups)
+	available := len(backups)
 	if len(backups) > limit {
 		backups = backups[:limit]
 	}
+	result.Searched = len(backups)
+	result.Backups = PageInfo{Total: available, Returned: len(backups), Truncated: len(backups) < available}
 
-	var results []string
-	for _, bp := range backups {
-		matches, walkErr := findInBackup(bp, pattern)
+	scan := &scanCounter{progress: progress}
+	for i, bp := range backups {
+		scan.step = fmt.Sprintf("Searching backup %d of %d (%d%%)", i+1, len(backups), i*100/len(backups))
+		scan.report(bp)
+		found, n, left, walkErr := findInBackup(bp, result.Pattern, scan)
+		result.Skipped += n
+		result.Ignored += left
+		if errors.Is(walkErr, ErrCancelled) {
+			return FindFileResult{}, walkErr
+		}
 		if walkErr != nil {
-			results = append(results, fmt.Sprintf("# Error scanning %s: %v", bp, walkErr))
-			continue
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", bp, walkErr))
+		}
+		var date *time.Time
+		if t, err := parseBackupDate(bp); err == nil {
+			date = &t
+		}
+		for _, path := range found {
+			m := Match{Path: path, Snapshot: bp, Date: date}
+			m.Size, m.Modified = statMatch(path)
+			if result.filtered() && (m.Modified == nil || m.Modified.Before(from) || !until.IsZero() && !m.Modified.Before(until)) {
+				continue
+			}
+			result.Matches = append(result.Matches, m)
 		}
-		results = append(results, matches...)
 	}
+	if bySize {
+		sort.SliceStable(result.Matches, func(i, j int) bool { return result.Matches[i].Size > result.Matches[j].Size })
+	}
+	return result, nil
+}
 
-	if len(results) == 0 {
-		return fmt.Sprintf("No matches for %q in the last %d backup(s).", pattern, len(backups)), nil
+// statMatch returns the size of the regular file at path, or -1, and its
+// modification time, or nil, tolerating errors such as broken symlinks.
+func statMatch(path string) (int64, *time.Time) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return -1, nil
 	}
+	mod := info.ModTime()
+	if !info.Mode().IsRegular() {
+		return -1, &mod
+	}
+	return info.Size(), &mod
+}
 
-	matchCount := 0
-	for _, r := range results {
-		if !strings.HasPrefix(r, "#") {
-			matchCount++
+// String renders the match path followed by its size in parentheses, if known.
+func (m Match) String() string {
+	if m.Size < 0 {
+		return m.Path
+	}
+	return fmt.Sprintf("%s  (%s)", m.Path, FormatBytesInt64(m.Size))
+}
+
+// datedString is String with the modification time added, e.g.
+// "/path  (1.2 MB, modified 2026-03-01 09:30)".
+func (m Match) datedString() string {
+	if m.Modified == nil {
+		return m.String()
+	}
+	when := "modified " + FormatTimeShort(m.Modified.Local())
+	if m.Size < 0 {
+		return fmt.Sprintf("%s  (%s)", m.Path, when)
+	}
+	return fmt.Sprintf("%s  (%s, %s)", m.Path, FormatBytesInt64(m.Size), when)
+}
+
+// String formats the result as FindFile prints it: a header, one match per
+// line, then "#" lines for scan errors and skipped folders.
+func (r FindFileResult) String() string {
+	return r.SizedList().String()
+}
+
+// SizedList is the result as String formats it, with each match a row
+// carrying its size.
+func (r FindFileResult) SizedList() SizedList {
+	modified := ""
+	switch {
+	case r.ModifiedAfter != "" && r.ModifiedBefore != "":
+		modified = fmt.Sprintf(" modified %s to %s", r.ModifiedAfter, r.ModifiedBefore)
+	case r.ModifiedAfter != "":
+		modified = " modified since " + r.ModifiedAfter
+	case r.ModifiedBefore != "":
+		modified = " modified by " + r.ModifiedBefore
+	}
+	if len(r.Matches) == 0 && len(r.Errors) == 0 && r.Skipped == 0 {
+		msg := fmt.Sprintf("No matches for %q%s in the last %d backup(s).", r.Pattern, modified, r.Searched)
+		if r.Ignored > 0 {
+			msg += fmt.Sprintf("\n# Left out %d item(s) matching .tmcliignore", r.Ignored)
+		}
+		return SizedList{Header: msg}
+	}
+	list := SizedList{Header: fmt.Sprintf("Found %d match(es) for %q%s across %d backup(s):", len(r.Matches), r.Pattern, modified, r.Searched)}
+	for _, m := range r.Matches {
+		line := m.String()
+		if r.filtered() {
+			// Show the dates the filter matched on.
+			line = m.datedString()
 		}
+		list.Rows = append(list.Rows, SizedRow{Line: line, Size: m.Size})
 	}
-	header := fmt.Sprintf("Found %d match(es) for %q across %d backup(s):\n",
-		matchCount, pattern, len(backups))
-	return header + strings.Join(results, "\n"), nil
+	var notes []string
+	for _, e := range r.Errors {
+		notes = append(notes, "# Error scanning "+e)
+	}
+	if r.Skipped > 0 {
+		notes = append(notes, fmt.Sprintf("# Skipped %d unreadable folder(s); run with sudo or grant Full Disk Access to search them", r.Skipped))
+	}
+	if r.Ignored > 0 {
+		notes = append(notes, fmt.Sprintf("# Left out %d item(s) matching .tmcliignore", r.Ignored))
+	}
+	list.Footer = strings.Join(notes, "\n")
+	return list
 }
 
 // FindByDate lists backup snapshots within a date range.
 // args[0] = start date YYYY-MM-DD (required)
 // args[1] = end date YYYY-MM-DD (optional; defaults to today)
+// args[2] = show only the newest N matches (optional; "--limit N" also accepted)
 func FindByDate(args []string) (string, error) {
+	args, limit, err := parseLimit(args)
+	if err != nil {
+		return "", err
+	}
+	if limit == 0 && len(args) > 2 && args[2] != "" {
+		if limit, err = limitValue(args[2]); err != nil {
+			return "", err
+		}
+	}
 	if len(args) == 0 || args[0] == "" {
 		return "", fmt.Errorf("start date (YYYY-MM-DD) is required")
 	}
-	startDate, err := time.Parse("2006-01-02", args[0])
+	// Backup names carry local wall-clock time, so the range is midnight
+	// to midnight local time as well.
+	startDate, err := time.ParseInLocation("2006-01-02", args[0], time.Local)
 	if err != nil {
 		return "", fmt.Errorf("invalid start date %q: expected YYYY-MM-DD", args[0])
 	}
 	endDate := time.Now()
+	until := endDate // exclusive upper bound
 	if len(args) > 1 && args[1] != "" {
-		endDate, err = time.Parse("2006-01-02", args[1])
+		endDate, err = time.ParseInLocation("2006-01-02", args[1], time.Local)
 		if err != nil {
 			return "", fmt.Errorf("invalid end date %q: expected YYYY-MM-DD", args[1])
 		}
-		endDate = endDate.Add(24*time.Hour - time.Second)
+		// AddDate rather than 24h, which is not a whole day across a DST change.
+		until = endDate.AddDate(0, 0, 1)
 	}
 
-	backups, err := listBackupPaths()
+	backups, err := ListBackupEntries()
 	if err != nil {
 		return "", err
 	}
 
 	var matches []string
-	for _, bp := range backups {
-		t, parseErr := parseBackupDate(bp)
-		if parseErr != nil {
+	for _, e := range backups {
+		if e.Date.IsZero() {
 			continue
 		}
-		if (t.Equal(startDate) || t.After(startDate)) && (t.Equal(endDate) || t.Before(endDate)) {
-			matches = append(matches, bp)
+		if !e.Date.Before(startDate) && e.Date.Before(until) {
+			matches = append(matches, e.Path)
 		}
 	}
 
@@ -130,51 +435,328 @@ func FindByDate(args []string) (string, error) {
 		len(matches),
 		startDate.Format("2006-01-02"),
 		endDate.Format("2006-01-02"))
+	if limit > 0 && len(matches) > limit {
+		header = fmt.Sprintf("Found %d backup(s) between %s and %s (showing newest %d):\n",
+			len(matches),
+			startDate.Format("2006-01-02"),
+			endDate.Format("2006-01-02"), limit)
+		matches = matches[len(matches)-limit:]
+	}
 	return header + strings.Join(matches, "\n"), nil
 }
 
 // BrowseBackup lists the contents of a backup snapshot directory.
 // args[0] = backup path (required)
 // args[1] = subdirectory within the backup (optional)
+// args[2] = "y" to compute directory sizes recursively (optional; "--recursive" also accepted)
+// args[3] = sort order: name (default, directories first), size or time (optional; "--sort=<order>" also accepted)
+// Hidden and system entries are left out unless "--all" (or "-a") is given.
 func BrowseBackup(args []string) (string, error) {
+	return BrowseBackupStream(args, nil)
+}
+
+// BrowseBackupStream is BrowseBackup reporting how many directories it has
+// sized, and how many it has scanned within them, through progress (which
+// may be nil).
+func BrowseBackupStream(args []string, progress func(string)) (string, error) {
+	list, err := BrowseBackupList(args, progress)
+	if err != nil {
+		return "", err
+	}
+	return list.String(), nil
+}
+
+// BrowseBackupList is BrowseBackupStream returning the listing with each
+// entry's size, so it can be re-sorted by size.
+func BrowseBackupList(args []string, progress func(string)) (SizedList, error) {
+	recursive, all := false, false
+	order := SortName
+	var pos []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--recursive" || a == "-r":
+			recursive = true
+		case a == "--all" || a == "-a":
+			all = true
+		case a == "--sort" && i+1 < len(args):
+			i++
+			o, err := ParseSortOrder(args[i])
+			if err != nil {
+				return SizedList{}, err
+			}
+			order = o
+		case strings.HasPrefix(a, "--sort="):
+			o, err := ParseSortOrder(strings.TrimPrefix(a, "--sort="))
+			if err != nil {
+				return SizedList{}, err
+			}
+			order = o
+		default:
+			pos = append(pos, a)
+		}
+	}
+	args = pos
 	if len(args) == 0 || args[0] == "" {
-		return "", fmt.Errorf("backup path is required")
+		return SizedList{}, fmt.Errorf("backup path is required")
 	}
 	dir := args[0]
 	if len(args) > 1 && args[1] != "" {
 		dir = filepath.Join(args[0], args[1])
 	}
+	if len(args) > 2 {
+		v, err := yesNo("directory sizes", args[2])
+		if err != nil {
+			return SizedList{}, err
+		}
+		recursive = recursive || v
+	}
+	if len(args) > 3 && args[3] != "" {
+		o, err := ParseSortOrder(args[3])
+		if err != nil {
+			return SizedList{}, err
+		}
+		order = o
+	}
 
-	entries, err := os.ReadDir(dir)
+	entries, hidden, err := ReadBackupDir(dir, all)
 	if err != nil {
-		return "", fmt.Errorf("cannot read %s: %w", dir, err)
+		return SizedList{}, err
+	}
+	var total int64
+	dirs, sized := 0, 0
+	for _, e := range entries {
+		if e.Dir {
+			dirs++
+		}
+	}
+	scan := &scanCounter{progress: progress}
+	for i := range entries {
+		e := &entries[i]
+		if e.Dir && recursive {
+			sized++
+			scan.step = fmt.Sprintf("Sizing %d of %d (%d%%)", sized, dirs, (sized-1)*100/dirs)
+			scan.report(filepath.Join(dir, e.Name))
+			e.Size = treeSize(filepath.Join(dir, e.Name), scan)
+			if err := cancelled(currentContext()); err != nil {
+				return SizedList{}, err
+			}
+		}
+		if e.Size > 0 {
+			total += e.Size
+		}
 	}
+	SortDirEntries(entries, order)
 
+	list := SizedList{Header: fmt.Sprintf("Contents of %s\n%s\n", dir, Rule(60))}
+	for _, e := range entries {
+		switch {
+		case e.Dir && !recursive:
+			list.Rows = append(list.Rows, SizedRow{Line: fmt.Sprintf("  %-40s  %s", e.Name+"/", "<dir>"), Size: -1})
+		case e.Dir:
+			list.Rows = append(list.Rows, SizedRow{Line: fmt.Sprintf("  %-40s  %s", e.Name+"/", FormatBytesInt64(e.Size)), Size: e.Size})
+		case e.Size >= 0:
+			list.Rows = append(list.Rows, SizedRow{Line: fmt.Sprintf("  %-40s  %s", e.Name, FormatBytesInt64(e.Size)), Size: e.Size})
+		default:
+			list.Rows = append(list.Rows, SizedRow{Line: "  " + e.Name, Size: -1})
+		}
+	}
 	var b strings.Builder
-	fmt.Fprintf(&b, "Contents of %s\n", dir)
-	b.WriteString(strings.Repeat("─", 60) + "\n\n")
-	for _, entry := range entries {
-		info, infoErr := entry.Info()
-		if entry.IsDir() {
-			fmt.Fprintf(&b, "  %-40s  %s\n", entry.Name()+"/", "<dir>")
-		} else if infoErr == nil {
-			fmt.Fprintf(&b, "  %-40s  %s\n", entry.Name(), FormatBytesInt64(info.Size()))
-		} else {
-			fmt.Fprintf(&b, "  %s\n", entry.Name())
-		}
-	}
-	fmt.Fprintf(&b, "\n%d item(s)", len(entries))
-	return b.String(), nil
+	if recursive {
+		fmt.Fprintf(&b, "\n%d item(s), %s total", len(entries), FormatBytesInt64(total))
+	} else {
+		fmt.Fprintf(&b, "\n%d item(s), %s in files (directories not included)", len(entries), FormatBytesInt64(total))
+	}
+	if hidden > 0 {
+		fmt.Fprintf(&b, "\n%d hidden, system or ignored item(s) not shown; add --all to show them", hidden)
+	}
+	if recursive && pathLayout(dir) == LayoutHFS {
+		b.WriteString("\nThis HFS+ backup hard-links files unchanged since the backup before it;\nsizes count them in full, though they take no extra space.")
+	}
+	list.Footer = b.String()
+	return list, nil
+}
+
+// DirEntry is one entry of a backup directory listing.
+type DirEntry struct {
+	Name    string
+	Dir     bool
+	Size    int64 // file size; 0 for directories unless sized; -1 if unreadable
+	ModTime time.Time
+}
+
+// ReadBackupDir lists dir, directories first and then by name. Unless all
+// is set it leaves out hidden and system entries (see IsHiddenEntry) and
+// those the ignore patterns match (see SetIgnorePatterns), and returns how
+// many it left out.
+func ReadBackupDir(dir string, all bool) ([]DirEntry, int, error) {
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot read %s: %w", dir, err)
+	}
+	root := ""
+	if !all {
+		root = backupRoot(dir)
+	}
+	entries := make([]DirEntry, 0, len(list))
+	hidden := 0
+	for _, e := range list {
+		if !all && (IsHiddenEntry(e.Name()) || root != "" && ignoredIn(root, filepath.Join(dir, e.Name()), e.IsDir())) {
+			hidden++
+			continue
+		}
+		entry := DirEntry{Name: e.Name(), Dir: e.IsDir(), Size: -1}
+		if info, infoErr := e.Info(); infoErr == nil {
+			entry.ModTime = info.ModTime()
+			entry.Size = info.Size()
+		}
+		if entry.Dir {
+			entry.Size = 0
+		}
+		entries = append(entries, entry)
+	}
+	SortDirEntries(entries, SortName)
+	return entries, hidden, nil
+}
+
+// systemEntries are names that volumes in a backup carry for the system's
+// own use and that do not start with a dot.
+var systemEntries = map[string]bool{
+	"Icon\r":                    true, // custom folder icon
+	"System Volume Information": true,
+	"$RECYCLE.BIN":              true,
+	"lost+found":                true,
+}
+
+// IsHiddenEntry reports whether a directory entry is hidden or system
+// clutter rather than the user's own data: dot-files and folders such as
+// .DocumentRevisions-V100, .Spotlight-V100 and .fseventsd, and the names in
+// systemEntries.
+func IsHiddenEntry(name string) bool {
+	return strings.HasPrefix(name, ".") || systemEntries[name]
+}
+
+// SortOrder selects how directory listings are sorted.
+type SortOrder string
+
+const (
+	SortName SortOrder = "name" // directories first, then by name
+	SortSize SortOrder = "size" // largest first
+	SortTime SortOrder = "time" // most recently modified first
+)
+
+// Next returns the order after o, for cycling through the orders.
+func (o SortOrder) Next() SortOrder {
+	switch o {
+	case SortName:
+		return SortSize
+	case SortSize:
+		return SortTime
+	}
+	return SortName
+}
+
+// ParseSortOrder parses "name", "size" or "time".
+func ParseSortOrder(s string) (SortOrder, error) {
+	switch o := SortOrder(strings.ToLower(strings.TrimSpace(s))); o {
+	case SortName, SortSize, SortTime:
+		return o, nil
+	}
+	return "", fmt.Errorf("unknown sort order %q (use name, size or time)", s)
+}
+
+// SortDirEntries sorts entries in place by order.
+func SortDirEntries(entries []DirEntry, order SortOrder) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch order {
+		case SortSize:
+			if a.Size != b.Size {
+				return a.Size > b.Size
+			}
+		case SortTime:
+			if !a.ModTime.Equal(b.ModTime) {
+				return a.ModTime.After(b.ModTime)
+			}
+		default:
+			if a.Dir != b.Dir {
+				return a.Dir
+			}
+		}
+		return a.Name < b.Name
+	})
 }
 
 // --- internal helpers ---
 
-// listBackupPaths calls tmutil listbackups and returns the paths as a slice.
+// listBackupPaths returns the completed backup paths, oldest first, from
+// the backup cache when it is fresh. Callers get their own copy.
 func listBackupPaths() ([]string, error) {
+	backupCache.Lock()
+	defer backupCache.Unlock()
+	if backupCache.paths == nil || time.Since(backupCache.at) > backupCacheTTL {
+		paths, err := readBackupPaths()
+		if err != nil {
+			return nil, err
+		}
+		backupCache.paths, backupCache.at = paths, time.Now()
+	}
+	return append([]string(nil), backupCache.paths...), nil
+}
+
+// backupCache holds the last listbackups result so that a sequence of
+// operations does not shell out (slowly, on network destinations) each time.
+var backupCache struct {
+	sync.Mutex
+	paths []string
+	at    time.Time
+}
+
+// backupCacheTTL is how long a cached backup list is reused.
+const backupCacheTTL = 30 * time.Second
+
+// InvalidateBackupCache discards the cached backup list, so the next
+// lookup runs tmutil listbackups again.
+func InvalidateBackupCache() {
+	backupCache.Lock()
+	backupCache.paths = nil
+	backupCache.Unlock()
+}
+
+// readBackupPaths calls tmutil listbackups and returns the paths as a slice.
+func readBackupPaths() ([]string, error) {
 	output, err := run("listbackups")
 	if err != nil {
 		return nil, err
 	}
+	return splitBackupPaths(output)
+}
+
+// streamBackupPaths runs listbackups like readBackupPaths, reporting the
+// number of backups listed as tmutil prints them, and caches the result.
+func streamBackupPaths(progress func(string)) ([]string, error) {
+	found := 0
+	output, err := runStream(func(line string) {
+		if strings.TrimSpace(line) != "" {
+			found++
+			progress(fmt.Sprintf("Listing backups: %d found so far", found))
+		}
+	}, "listbackups")
+	if err != nil {
+		return nil, err
+	}
+	paths, err := splitBackupPaths(output)
+	if err != nil {
+		return nil, err
+	}
+	backupCache.Lock()
+	backupCache.paths, backupCache.at = append([]string(nil), paths...), time.Now()
+	backupCache.Unlock()
+	return paths, nil
+}
+
+// splitBackupPaths reads listbackups output, one path per line.
+func splitBackupPaths(output string) ([]string, error) {
 	lines := strings.Split(strings.TrimSpace(output), "\n")
 	var paths []string
 	for _, l := range lines {
@@ -184,37 +766,304 @@ func listBackupPaths() ([]string, error) {
 		}
 	}
 	if len(paths) == 0 {
-		return nil, fmt.Errorf("no backups found")
+		return nil, ErrNoBackups
 	}
 	return paths, nil
 }
 
-// parseBackupDate extracts the date from the last path component of a backup path.
+// backupForDate returns the newest backup taken on the given day
+// (YYYY-MM-DD), resolved through ListBackupEntries.
+func backupForDate(date string) (string, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return "", fmt.Errorf("invalid backup date %q: expected YYYY-MM-DD", date)
+	}
+	backups, err := ListBackupEntries()
+	if err != nil {
+		return "", err
+	}
+	found := ""
+	for _, e := range backups {
+		if e.Date.IsZero() {
+			continue
+		}
+		if e.Date.Year() == day.Year() && e.Date.YearDay() == day.YearDay() {
+			found = e.Path // listbackups is oldest first, so keep the last
+		}
+	}
+	if found == "" {
+		return "", fmt.Errorf("no backup found on %s", date)
+	}
+	return found, nil
+}
+
+// parseBackupDate extracts the date from the last path component of a backup
+// path. Time Machine names backups in local time, so the result is in the
+// local zone, like local snapshot dates.
 func parseBackupDate(backupPath string) (time.Time, error) {
 	base := filepath.Base(backupPath)
-	return time.Parse(backupPathDateLayout, base)
+	switch pathLayout(backupPath) {
+	case LayoutAPFS:
+		// APFS destinations name snapshots 2026-03-14-081130.backup.
+		base = strings.TrimSuffix(base, ".backup")
+	case LayoutHFS:
+		// HFS+ names them by the date alone; the Latest link beside
+		// them is not a backup.
+		if base == "Latest" {
+			return time.Time{}, fmt.Errorf("%s is a link to the latest backup", backupPath)
+		}
+	}
+	return time.ParseInLocation(backupPathDateLayout, base, time.Local)
+}
+
+// IsBackupPath reports whether path is an existing item inside a backup:
+// an absolute path below a directory named for a backup's date, such as
+// .../2026-03-14-081130.backup/Macintosh HD/Users/me/file.txt.
+func IsBackupPath(path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	dir := filepath.Dir(filepath.Clean(path))
+	inBackup := false
+	for d := dir; d != filepath.Dir(d); d = filepath.Dir(d) {
+		if _, err := parseBackupDate(d); err == nil {
+			inBackup = true
+			break
+		}
+	}
+	if !inBackup {
+		return false
+	}
+	_, err := os.Lstat(path)
+	return err == nil
 }
 
-// findInBackup walks a backup snapshot looking for entries matching a glob pattern.
-func findInBackup(backupPath, pattern string) ([]string, error) {
+// findInBackup walks a backup snapshot looking for entries matching a glob
+// pattern. Unreadable subdirectories (typically protected folders) are
+// skipped and counted rather than aborting the walk; only an unreadable
+// snapshot root is an error. Items the ignore patterns leave out (see
+// SetIgnorePatterns) are neither searched nor matched, and are counted.
+func findInBackup(backupPath, pattern string, scan *scanCounter) ([]string, int, int, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, 0, 0, err
+	}
 	var matches []string
+	skipped, left := 0, 0
+	ctx := currentContext()
 	err := filepath.WalkDir(backupPath, func(path string, d fs.DirEntry, err error) error {
+		if err := cancelled(ctx); err != nil {
+			return err
+		}
 		if err != nil {
+			if path == backupPath {
+				return err
+			}
 			if d != nil && d.IsDir() {
+				skipped++
 				return filepath.SkipDir
 			}
 			return nil
 		}
-		matched, matchErr := filepath.Match(pattern, d.Name())
-		if matchErr != nil {
-			return matchErr
+		if path != backupPath && ignoredIn(backupPath, path, d.IsDir()) {
+			left++
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
 		}
-		if matched {
+		if d.IsDir() {
+			scan.visit(path)
+		}
+		if matched, _ := filepath.Match(pattern, d.Name()); matched {
 			matches = append(matches, path)
 		}
 		return nil
 	})
-	return matches, err
+	return matches, skipped, left, err
+}
+
+// RestoreFileCount returns the number of entries a restore of args[0] is
+// expected to report, for estimating its remaining time.
+func RestoreFileCount(args []string) int {
+	if len(args) == 0 {
+		return 0
+	}
+	count := 0
+	ctx := currentContext()
+	filepath.WalkDir(args[0], func(_ string, _ fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return filepath.SkipAll
+		}
+		if err == nil {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// restoreMeasureBudget bounds how long MeasureRestore walks a source
+// directory; a confirmation should not keep the user waiting on a huge tree.
+const restoreMeasureBudget = 3 * time.Second
+
+// RestoreSize is what restoring a directory copies, as far as it was
+// measured.
+type RestoreSize struct {
+	Files    int64
+	Bytes    int64
+	Complete bool  // false when measuring stopped at restoreMeasureBudget
+	Free     int64 // bytes free on the destination's volume; -1 if unknown
+}
+
+// MeasureRestore counts the files and bytes under src, stopping after
+// restoreMeasureBudget, and reads the free space where dest is or would be
+// created.
+func MeasureRestore(src, dest string) RestoreSize {
+	s := RestoreSize{Free: -1}
+	s.Files, s.Bytes, s.Complete = measureTree(src, restoreMeasureBudget)
+	// The destination may not exist yet; its nearest parent tells the volume.
+	for p := filepath.Clean(dest); ; p = filepath.Dir(p) {
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(p, &st); err == nil {
+			s.Free = int64(st.Bavail) * int64(st.Bsize)
+			break
+		}
+		if p == filepath.Dir(p) {
+			break
+		}
+	}
+	return s
+}
+
+// measureTree counts the regular files under root and their bytes,
+// stopping after budget (0 for no limit); complete is false when it
+// stopped early.
+func measureTree(root string, budget time.Duration) (files, bytes int64, complete bool) {
+	complete = true
+	ctx := currentContext()
+	deadline := time.Now().Add(budget)
+	filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if ctx.Err() != nil || budget > 0 && time.Now().After(deadline) {
+			complete = false
+			return filepath.SkipAll
+		}
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		files++
+		if info, err := d.Info(); err == nil {
+			bytes += info.Size()
+		}
+		return nil
+	})
+	return files, bytes, complete
+}
+
+// RestoreQuestion asks before restoring a directory, with its size, e.g.
+// "This will restore 4.2 GB / 12,000 files to /Users/me. Proceed?", since a
+// large restore started by mistake can fill the destination disk. It is ""
+// for a single file, which needs no confirmation. A tree too large to
+// measure quickly is confirmed without its full size.
+// args[0] = source path, args[1] = destination path.
+func RestoreQuestion(args []string) string {
+	if len(args) < 2 {
+		return ""
+	}
+	if info, err := os.Stat(args[0]); err != nil || !info.IsDir() {
+		return ""
+	}
+	s := MeasureRestore(args[0], args[1])
+	var b strings.Builder
+	if s.Complete {
+		fmt.Fprintf(&b, "This will restore %s / %s file(s) to %s. Proceed?", FormatBytesInt64(s.Bytes), formatCount(s.Files), args[1])
+	} else {
+		fmt.Fprintf(&b, "This will restore the folder %s to %s. Proceed?\n\n", args[0], args[1])
+		fmt.Fprintf(&b, "Its size was not measured: it holds more than %s / %s file(s), counted in %s before giving up.",
+			FormatBytesInt64(s.Bytes), formatCount(s.Files), FormatDuration(restoreMeasureBudget))
+	}
+	switch {
+	case s.Free >= 0 && s.Bytes > s.Free:
+		fmt.Fprintf(&b, "\n\nThe destination has only %s free: the restore will not fit.", FormatBytesInt64(s.Free))
+	case s.Free >= 0:
+		fmt.Fprintf(&b, "\n\nThe destination has %s free.", FormatBytesInt64(s.Free))
+	}
+	return b.String() + " (y/N)"
+}
+
+// treeSize returns the total size of the regular files under path.
+func treeSize(path string, scan *scanCounter) int64 {
+	var total int64
+	ctx := currentContext()
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return filepath.SkipAll
+		}
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			scan.visit(p)
+		}
+		if d.Type().IsRegular() {
+			if info, infoErr := d.Info(); infoErr == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
+
+// scanProgressInterval is how often a scanCounter reports, so that walking
+// a large snapshot does not flood the UI with a line per directory.
+const scanProgressInterval = 100 * time.Millisecond
+
+// scanCounter counts the directories a long walk has visited and reports
+// the running total, with the current step and path, through progress.
+// A nil counter or progress func ignores visits.
+type scanCounter struct {
+	progress func(string)
+	step     string // e.g. "Searching backup 2 of 5 (20%)"
+	dirs     int64
+	last     time.Time
+}
+
+// visit counts one directory, reporting it if the interval has passed.
+func (c *scanCounter) visit(path string) {
+	if c == nil {
+		return
+	}
+	c.dirs++
+	if time.Since(c.last) >= scanProgressInterval {
+		c.report(path)
+	}
+}
+
+// report sends the current count and path.
+func (c *scanCounter) report(path string) {
+	if c == nil || c.progress == nil {
+		return
+	}
+	c.last = time.Now()
+	line := fmt.Sprintf("%s directories scanned: %s", formatCount(c.dirs), path)
+	if c.step != "" {
+		line = c.step + " • " + line
+	}
+	c.progress(line)
+}
+
+// formatCount formats n with thousands separators, e.g. 1240 -> "1,240".
+func formatCount(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
 }
 
 // reverseStrings reverses a string slice in place.
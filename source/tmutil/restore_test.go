@@ -0,0 +1,163 @@
+//
+// restore_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // the zones below, whatever the host has installed
+)
+
+// zoneBackups are backups around midnight and around New York's DST
+// changes in 2026: clocks skip 02:00-03:00 on March 8 and repeat
+// 01:00-02:00 on November 1, a 25-hour day.
+const zoneBackups = `/Volumes/Backup/2026-03-07-235959.backup
+/Volumes/Backup/2026-03-08-000000.backup
+/Volumes/Backup/2026-03-08-033000.backup
+/Volumes/Backup/2026-03-08-235959.backup
+/Volumes/Backup/2026-03-09-000000.backup
+/Volumes/Backup/2026-10-31-235959.backup
+/Volumes/Backup/2026-11-01-013000.backup
+/Volumes/Backup/2026-11-01-233000.backup
+/Volumes/Backup/2026-11-02-000000.backup
+`
+
+func loadZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestFindByDateZones(t *testing.T) {
+	tests := []struct {
+		zone, from, to string
+		want           []string // backup names, oldest first
+	}{
+		// Midnight belongs to the day it starts; the end date is
+		// inclusive, the midnight after it is not.
+		{"Asia/Kolkata", "2026-03-08", "2026-03-08", []string{"2026-03-08-000000", "2026-03-08-033000", "2026-03-08-235959"}},
+		{"Asia/Kolkata", "2026-03-07", "2026-03-08", []string{"2026-03-07-235959", "2026-03-08-000000", "2026-03-08-033000", "2026-03-08-235959"}},
+		{"Asia/Kolkata", "2026-03-09", "2026-03-09", []string{"2026-03-09-000000"}},
+		// A 23-hour day: a day of 24 hours would take in the midnight after.
+		{"America/New_York", "2026-03-08", "2026-03-08", []string{"2026-03-08-000000", "2026-03-08-033000", "2026-03-08-235959"}},
+		// A 25-hour day: a day of 24 hours would leave out 23:30.
+		{"America/New_York", "2026-11-01", "2026-11-01", []string{"2026-11-01-013000", "2026-11-01-233000"}},
+		{"America/New_York", "2026-10-31", "2026-11-01", []string{"2026-10-31-235959", "2026-11-01-013000", "2026-11-01-233000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone+" "+tt.from+" "+tt.to, func(t *testing.T) {
+			useTmutil(t, map[string]string{"listbackups": zoneBackups})
+			useZone(t, loadZone(t, tt.zone))
+			out, err := FindByDate([]string{tt.from, tt.to})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, line := range strings.Split(out, "\n")[1:] {
+				got = append(got, strings.TrimSuffix(strings.TrimPrefix(line, "/Volumes/Backup/"), ".backup"))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("FindByDate(%s, %s) found %q, want %q\n%s", tt.from, tt.to, got, tt.want, out)
+			}
+		})
+	}
+}
+
+func TestParseBackupDateLocal(t *testing.T) {
+	ny := loadZone(t, "America/New_York")
+	useZone(t, ny)
+	tests := []struct {
+		path string
+		want time.Time
+	}{
+		{"/Volumes/Backup/2026-03-14-081130.backup", time.Date(2026, 3, 14, 8, 11, 30, 0, ny)},
+		{"/Volumes/Backup/2026-03-08-000000.backup", time.Date(2026, 3, 8, 0, 0, 0, 0, ny)},
+		{"/Volumes/Backup/2026-03-08-033000.backup", time.Date(2026, 3, 8, 3, 30, 0, 0, ny)},
+		{"/Volumes/Backup/2026-11-01-233000.backup", time.Date(2026, 11, 1, 23, 30, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		got, err := parseBackupDate(tt.path)
+		if err != nil {
+			t.Errorf("parseBackupDate(%q): %v", tt.path, err)
+			continue
+		}
+		if !got.Equal(tt.want) || got.Location() != ny {
+			t.Errorf("parseBackupDate(%q) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+// useCopyingTmutil makes tmutil restore -v copy like cp -R, printing a
+// line per file and a few that name no file.
+func useCopyingTmutil(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := `#!/bin/sh
+[ "$1" = restore ] || exit 0
+shift 2
+echo "Restoring from backup..."
+find "$1" -type f
+cp -R "$1" "$2"
+echo "Done."
+`
+	if err := os.WriteFile(filepath.Join(dir, "tmutil"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TMCLI_TMUTIL", filepath.Join(dir, "tmutil"))
+}
+
+// writeTree creates files under root, each of the given size.
+func writeTree(t *testing.T, root string, size int, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRestoreSummaryMeasuresDestination(t *testing.T) {
+	useCopyingTmutil(t)
+	src := filepath.Join(t.TempDir(), "Documents")
+	writeTree(t, src, 1500, "a.txt", "b.txt", "sub/c.txt")
+
+	t.Run("into an existing directory", func(t *testing.T) {
+		dest := t.TempDir()
+		writeTree(t, dest, 9000, "unrelated.txt") // already there: not restored
+		out, err := Restore([]string{src, dest})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "Restored 3 file(s), 4.5 KB to " + dest + " in "; !strings.HasPrefix(out, want) {
+			t.Errorf("summary = %q, want it to start %q", out, want)
+		}
+	})
+	t.Run("to a new path", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "Restored")
+		out, err := Restore([]string{src, dest})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "Restored 3 file(s), 4.5 KB to " + dest + " in "; !strings.HasPrefix(out, want) {
+			t.Errorf("summary = %q, want it to start %q", out, want)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+//
+// rotate.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RotationEntry is one destination in a disk rotation.
+type RotationEntry struct {
+	Dest       DestInfo
+	LastBackup time.Time // zero when it has never completed a backup
+	Known      bool      // false when the preferences could not be read
+	Current    bool      // the destination Time Machine last backed up to
+}
+
+// GetRotation returns every configured destination with the time of its
+// last completed backup, read from the preferences plist so disconnected
+// disks are included.
+func GetRotation() ([]RotationEntry, error) {
+	dests, err := ListDestinations()
+	if err != nil {
+		return nil, err
+	}
+	last := make(map[string]time.Time)
+	prefs, prefsErr := GetDestinationPrefs()
+	if prefsErr == nil {
+		for _, p := range prefs {
+			if n := len(p.SnapshotDates); n > 0 {
+				last[strings.ToUpper(p.ID)] = p.SnapshotDates[n-1]
+			}
+		}
+	}
+	var current string
+	for _, d := range dests {
+		if d.LastDestinationID != "" {
+			current = d.LastDestinationID
+		}
+	}
+	entries := make([]RotationEntry, 0, len(dests))
+	for _, d := range dests {
+		entries = append(entries, RotationEntry{
+			Dest:       d,
+			LastBackup: last[strings.ToUpper(d.ID)],
+			Known:      prefsErr == nil,
+			Current:    current != "" && strings.EqualFold(d.ID, current),
+		})
+	}
+	return entries, nil
+}
+
+// Rotate helps alternate backups between several disks.
+// args[0] = destination ID (optional); back up to that destination now
+// Without an ID it lists each destination with the age of its last backup
+// and names the one most overdue. Starting a backup requires root. The
+// backup is a one-off: tmutil can only change the destination automatic
+// backups use by replacing all the others, so that is left to Time Machine.
+func Rotate(args []string) (string, error) {
+	if len(args) > 0 && args[0] != "" {
+		return backupTo(args[0])
+	}
+	entries, err := GetRotation()
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "No destinations configured.", nil
+	}
+	return formatRotation(entries, time.Now()), nil
+}
+
+func formatRotation(entries []RotationEntry, now time.Time) string {
+	var b strings.Builder
+	b.WriteString("Backup Disk Rotation\n")
+	b.WriteString(Rule(40) + "\n\n")
+
+	var due *RotationEntry
+	for i := range entries {
+		e := &entries[i]
+		mark := " "
+		if e.Current {
+			mark = "*"
+		}
+		name := e.Dest.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		conn := "not connected"
+		if e.Dest.MountPoint != "" {
+			conn = "connected"
+		}
+		age := "last backup unknown"
+		if e.Known {
+			age = backupAge(e.LastBackup, now)
+		}
+		fmt.Fprintf(&b, "%s %-24s %-28s %s\n", mark, name, age, conn)
+		if e.Dest.ID != "" {
+			fmt.Fprintf(&b, "  %-24s %s\n", "", e.Dest.ID)
+		}
+		// The next disk in the rotation is the longest-unused one other
+		// than the disk Time Machine used last.
+		if e.Known && !e.Current && (due == nil || e.LastBackup.Before(due.LastBackup)) {
+			due = e
+		}
+	}
+	b.WriteString("\n* = most recent destination")
+
+	if len(entries) > 1 && due != nil {
+		fmt.Fprintf(&b, "\n\n%s was %s.", rotationName(due.Dest), backupAge(due.LastBackup, now))
+		if due.Dest.MountPoint == "" {
+			b.WriteString(" Connect it, then run")
+		} else {
+			b.WriteString(" Run")
+		}
+		fmt.Fprintf(&b, " 'sudo tmcli rotate %s' to back up to it now.", due.Dest.ID)
+	}
+	return b.String()
+}
+
+// backupAge describes how long ago a destination was last backed up.
+func backupAge(t, now time.Time) string {
+	if t.IsZero() {
+		return "never backed up"
+	}
+	return "last backed up " + FormatRelative(t, now)
+}
+
+func rotationName(d DestInfo) string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.ID
+}
+
+// backupTo starts a backup to one destination, leaving the others (and
+// automatic rotation) configured as they are.
+func backupTo(id string) (string, error) {
+	dest, err := findDestination(id)
+	if err != nil {
+		return "", err
+	}
+	if !isNetwork(dest) {
+		if ok, why := destinationReachable(dest); !ok {
+			return "", fmt.Errorf("backup destination %s is unreachable: %s", rotationName(dest), why)
+		}
+	}
+	output, err := run("startbackup", "--destination", dest.ID)
+	if err != nil {
+		return "", err
+	}
+	if output == "" {
+		return fmt.Sprintf("Backup to %s started.", rotationName(dest)), nil
+	}
+	return output, nil
+}
@@ -0,0 +1,85 @@
+//
+// sized.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SizedList is a report whose rows each carry a size, such as a backup
+// directory listing, kept apart from the lines around them so the rows can
+// be re-sorted by size after the report is made.
+type SizedList struct {
+	Header string     // lines above the rows
+	Rows   []SizedRow // in the order the command listed them
+	Footer string     // lines below the rows, such as a total
+	Ranked bool       // rows are numbered "   1. " in the order shown
+}
+
+// SizedRow is one row of a SizedList.
+type SizedRow struct {
+	Line string
+	Size int64 // bytes; -1 when the row has no size, e.g. an unsized directory
+}
+
+// String renders the list as the command prints it.
+func (l SizedList) String() string {
+	var parts []string
+	if l.Header != "" {
+		parts = append(parts, l.Header)
+	}
+	for i, r := range l.Rows {
+		if l.Ranked {
+			parts = append(parts, fmt.Sprintf("%4d. %s", i+1, r.Line))
+			continue
+		}
+		parts = append(parts, r.Line)
+	}
+	if l.Footer != "" {
+		parts = append(parts, l.Footer)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Sortable reports whether sorting by size can change the order of the
+// rows: there are at least two, and at least one has a size.
+func (l SizedList) Sortable() bool {
+	if len(l.Rows) < 2 {
+		return false
+	}
+	for _, r := range l.Rows {
+		if r.Size >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SortedBySize returns a copy of l with its rows sorted by size, largest
+// first when desc is set. Rows without a size stay last either way, in
+// their original order.
+func (l SizedList) SortedBySize(desc bool) SizedList {
+	rows := append([]SizedRow(nil), l.Rows...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i].Size, rows[j].Size
+		switch {
+		case a < 0 || b < 0:
+			return a >= 0 && b < 0
+		case desc:
+			return a > b
+		}
+		return a < b
+	})
+	l.Rows = rows
+	return l
+}
@@ -10,27 +10,249 @@
 
 package tmutil
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
 
-// LocalSnapshot creates a new local snapshot.
-func LocalSnapshot() (string, error) {
-	output, err := run("localsnapshot")
-	if err != nil {
+// SnapshotHooks are shell commands run around LocalSnapshot, e.g. to
+// quiesce a database while the snapshot is taken. They come from
+// config.json and run with tmcli's privileges.
+type SnapshotHooks struct {
+	Pre  string `json:"pre,omitempty"`  // runs first; failing cancels the snapshot
+	Post string `json:"post,omitempty"` // runs after the snapshot, even a failed one, e.g. to resume the database
+}
+
+var snapshotHooks SnapshotHooks
+
+// SetSnapshotHooks sets the commands LocalSnapshot runs around tmutil.
+func SetSnapshotHooks(h SnapshotHooks) {
+	snapshotHooks = h
+}
+
+// runHook runs a snapshot hook with sh -c, telling it which one it is in
+// TMCLI_HOOK, and returns its trimmed combined output.
+func runHook(stage, command string, env ...string) (string, error) {
+	ctx := currentContext()
+	cmd := inGroup(exec.CommandContext(ctx, "sh", "-c", command))
+	cmd.Env = append(append(os.Environ(), "TMCLI_HOOK="+stage), env...)
+	output, err := cmd.CombinedOutput()
+	if err := cancelled(ctx); err != nil {
 		return "", err
 	}
-	if output == "" {
-		return "Local snapshot created.", nil
+	return strings.TrimSpace(string(output)), err
+}
+
+// hookReport shows a hook's command and its indented output.
+func hookReport(stage, command, output string) string {
+	s := fmt.Sprintf("%s-snapshot hook: %s", stage, command)
+	if output != "" {
+		s += "\n  " + strings.ReplaceAll(output, "\n", "\n  ")
 	}
-	return output, nil
+	return s
+}
+
+// LocalSnapshot creates a new local snapshot, running the snapshot hooks
+// around it when set. A failing pre hook cancels the snapshot. The post
+// hook runs whether or not tmutil succeeded, with TMCLI_SNAPSHOT set to
+// created or failed; if it fails, so does LocalSnapshot, though the
+// snapshot stays.
+func LocalSnapshot() (string, error) {
+	var report []string
+	h := snapshotHooks
+	if h.Pre != "" {
+		out, err := runHook("pre", h.Pre)
+		if err != nil {
+			return "", fmt.Errorf("%s\npre-snapshot hook failed, so no snapshot was taken: %w", hookReport("Pre", h.Pre, out), err)
+		}
+		report = append(report, hookReport("Pre", h.Pre, out))
+	}
+	output, snapErr := run("localsnapshot")
+	state := "created"
+	if snapErr != nil {
+		state = "failed"
+	} else {
+		if output == "" {
+			output = "Local snapshot created."
+		}
+		report = append(report, output)
+	}
+	if h.Post == "" {
+		if snapErr != nil {
+			return "", snapErr
+		}
+		return strings.Join(report, "\n"), nil
+	}
+	out, err := runHook("post", h.Post, "TMCLI_SNAPSHOT="+state)
+	report = append(report, hookReport("Post", h.Post, out))
+	switch {
+	case snapErr != nil:
+		return "", fmt.Errorf("%w\n%s", snapErr, strings.Join(report, "\n"))
+	case err != nil:
+		return "", fmt.Errorf("%s\npost-snapshot hook failed after the snapshot was taken: %w", strings.Join(report, "\n"), err)
+	}
+	return strings.Join(report, "\n"), nil
+}
+
+// LocalSnapshotInfo describes one local APFS snapshot.
+type LocalSnapshotInfo struct {
+	Identifier string     `json:"identifier"`
+	Date       *time.Time `json:"date"` // nil if the identifier has no parseable date
 }
 
 // ListLocalSnapshots lists local snapshots for a mount point.
+// With "--all" (or a mount point of "all") it lists the snapshots of every
+// mounted APFS volume, grouped by volume with counts.
+// With "--limit N" only the newest N snapshots are listed.
+// With "--json" it emits a ListPage of {identifier, date} objects instead, or
+// with "--all" an array of {mountPoint, snapshots} objects.
 func ListLocalSnapshots(args []string) (string, error) {
+	asJSON, all := false, false
+	var flags []string
+	for _, a := range args {
+		switch a {
+		case "--json":
+			asJSON = true
+		case "--all":
+			all = true
+		default:
+			flags = append(flags, a)
+		}
+	}
+	rest, limit, err := parseLimit(flags)
+	if err != nil {
+		return "", err
+	}
 	mountPoint := "/"
-	if len(args) > 0 && args[0] != "" {
-		mountPoint = args[0]
+	if len(rest) > 0 && rest[0] != "" {
+		mountPoint = rest[0]
+	}
+	if mountPoint == "all" {
+		all = true
+	}
+	if all {
+		return listAllLocalSnapshots(asJSON)
 	}
-	return run("listlocalsnapshots", mountPoint)
+	if !asJSON && limit == 0 {
+		return run("listlocalsnapshots", mountPoint)
+	}
+
+	snaps, err := GetLocalSnapshots(mountPoint)
+	if err != nil {
+		return "", err
+	}
+	if asJSON {
+		return marshalPage(snaps, limit)
+	}
+	header := fmt.Sprintf("Snapshots for disk %s:", mountPoint)
+	if len(snaps) > limit {
+		header = fmt.Sprintf("Snapshots for disk %s (newest %d of %d):", mountPoint, limit, len(snaps))
+		snaps = snaps[len(snaps)-limit:]
+	}
+	lines := []string{header}
+	for _, s := range snaps {
+		lines = append(lines, s.Identifier)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// VolumeLocalSnapshots holds the local snapshots of one mounted volume.
+type VolumeLocalSnapshots struct {
+	MountPoint string              `json:"mountPoint"`
+	Snapshots  []LocalSnapshotInfo `json:"snapshots"`
+}
+
+// GetAllLocalSnapshots returns the local snapshots of every mounted APFS
+// volume. Volumes that cannot be queried are skipped.
+func GetAllLocalSnapshots() ([]VolumeLocalSnapshots, error) {
+	vols, err := MountedVolumes()
+	if err != nil {
+		return nil, err
+	}
+	var all []VolumeLocalSnapshots
+	for _, v := range vols {
+		snaps, err := GetLocalSnapshots(v)
+		if err != nil {
+			continue
+		}
+		if snaps == nil {
+			snaps = []LocalSnapshotInfo{}
+		}
+		all = append(all, VolumeLocalSnapshots{MountPoint: v, Snapshots: snaps})
+	}
+	return all, nil
+}
+
+func listAllLocalSnapshots(asJSON bool) (string, error) {
+	all, err := GetAllLocalSnapshots()
+	if err != nil {
+		return "", err
+	}
+	if asJSON {
+		if all == nil {
+			all = []VolumeLocalSnapshots{}
+		}
+		data, err := json.MarshalIndent(all, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var b strings.Builder
+	total := 0
+	for _, v := range all {
+		total += len(v.Snapshots)
+		fmt.Fprintf(&b, "%s (%d snapshot(s))\n", v.MountPoint, len(v.Snapshots))
+		for _, s := range v.Snapshots {
+			fmt.Fprintf(&b, "  %s\n", s.Identifier)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d snapshot(s) on %d volume(s)", total, len(all))
+	return b.String(), nil
+}
+
+// GetLocalSnapshots returns the local snapshots for a mount point.
+func GetLocalSnapshots(mountPoint string) ([]LocalSnapshotInfo, error) {
+	output, err := run("listlocalsnapshots", mountPoint)
+	if err != nil {
+		return nil, err
+	}
+	return parseLocalSnapshots(output), nil
+}
+
+// parseLocalSnapshots parses listlocalsnapshots output, skipping the
+// "Snapshots for disk /:" header.
+func parseLocalSnapshots(raw string) []LocalSnapshotInfo {
+	var snaps []LocalSnapshotInfo
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasSuffix(line, ":") {
+			continue
+		}
+		snap := LocalSnapshotInfo{Identifier: line}
+		if t, err := parseSnapshotDate(line); err == nil {
+			snap.Date = &t
+		}
+		snaps = append(snaps, snap)
+	}
+	return snaps
+}
+
+// parseSnapshotDate extracts the local time from a snapshot identifier such
+// as "com.apple.TimeMachine.2026-02-07-143022.local" or a bare
+// "2026-02-07-143022" date as printed by listlocalsnapshotdates.
+func parseSnapshotDate(id string) (time.Time, error) {
+	s := strings.TrimPrefix(id, "com.apple.TimeMachine.")
+	s = strings.TrimSuffix(s, ".local")
+	return time.ParseInLocation(backupPathDateLayout, s, time.Local)
 }
 
 // ListLocalSnapshotDates lists local snapshot dates for a mount point.
@@ -39,7 +261,78 @@ func ListLocalSnapshotDates(args []string) (string, error) {
 	if len(args) > 0 && args[0] != "" {
 		mountPoint = args[0]
 	}
-	return run("listlocalsnapshotdates", mountPoint)
+	output, err := run("listlocalsnapshotdates", mountPoint)
+	if err != nil {
+		return "", err
+	}
+	return reformatSnapshotDates(output), nil
+}
+
+// reformatSnapshotDates shows tmutil's snapshot dates (2026-03-14-081130)
+// in the configured time format. With the default format, and for lines
+// that are not dates, tmutil's output is left as it is.
+func reformatSnapshotDates(raw string) string {
+	if !customTimeFormat() {
+		return raw
+	}
+	lines := strings.Split(raw, "\n")
+	for i, l := range lines {
+		if t, err := time.ParseInLocation(backupPathDateLayout, strings.TrimSpace(l), time.Local); err == nil {
+			lines[i] = FormatTime(t)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// VolumeSnapshots holds the local snapshot count for one mounted volume.
+type VolumeSnapshots struct {
+	MountPoint string
+	Count      int
+}
+
+// MountedVolumes returns the mount points of local APFS volumes, parsed from
+// mount(8) output such as "/dev/disk3s1s1 on / (apfs, sealed, local, ...)".
+func MountedVolumes() ([]string, error) {
+	output, err := mountTable()
+	if err != nil {
+		return nil, err
+	}
+	return parseMountOutput(output), nil
+}
+
+func parseMountOutput(raw string) []string {
+	var vols []string
+	for _, line := range strings.Split(raw, "\n") {
+		on := strings.Index(line, " on ")
+		open := strings.LastIndex(line, " (")
+		if on < 0 || open < on {
+			continue
+		}
+		opts := line[open+2:]
+		if !strings.HasPrefix(opts, "apfs") || !strings.Contains(opts, "local") {
+			continue
+		}
+		vols = append(vols, line[on+4:open])
+	}
+	return vols
+}
+
+// CountLocalSnapshots returns the number of local snapshots on each mounted
+// APFS volume. Volumes that cannot be queried are skipped.
+func CountLocalSnapshots() ([]VolumeSnapshots, error) {
+	vols, err := MountedVolumes()
+	if err != nil {
+		return nil, err
+	}
+	var counts []VolumeSnapshots
+	for _, v := range vols {
+		output, err := run("listlocalsnapshotdates", v)
+		if err != nil {
+			continue
+		}
+		counts = append(counts, VolumeSnapshots{MountPoint: v, Count: len(parseLocalSnapshots(output))})
+	}
+	return counts, nil
 }
 
 // DeleteLocalSnapshots deletes local snapshots for a mount point or date.
@@ -57,7 +350,162 @@ func DeleteLocalSnapshots(args []string) (string, error) {
 	return output, nil
 }
 
+// keepSnapshotsArgs reads KeepLocalSnapshots' arguments.
+func keepSnapshotsArgs(args []string) (mountPoint string, keep int, dryRun bool, err error) {
+	var pos []string
+	for _, a := range args {
+		if a == "--dry-run" {
+			dryRun = true
+			continue
+		}
+		pos = append(pos, a)
+	}
+	if len(pos) < 2 || pos[0] == "" || pos[1] == "" {
+		return "", 0, false, fmt.Errorf("mount point and number of snapshots to keep are required")
+	}
+	if keep, err = strconv.Atoi(pos[1]); err != nil || keep < 1 {
+		return "", 0, false, fmt.Errorf("invalid number to keep %q: expected 1 or more (deletelocalsnapshots deletes them all)", pos[1])
+	}
+	if len(pos) > 2 {
+		v, err := yesNo("dry run", pos[2])
+		if err != nil {
+			return "", 0, false, err
+		}
+		dryRun = dryRun || v
+	}
+	return pos[0], keep, dryRun, nil
+}
+
+// planKeepSnapshots splits the local snapshots on mountPoint into the
+// newest keep, newest first, and the older ones to delete, oldest first.
+// Snapshots whose identifier has no date are always kept, and counted.
+func planKeepSnapshots(mountPoint string, keep int) (kept, older []LocalSnapshotInfo, undated int, err error) {
+	snaps, err := GetLocalSnapshots(mountPoint)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var dated []LocalSnapshotInfo
+	for _, s := range snaps {
+		if s.Date == nil {
+			undated++
+			continue
+		}
+		dated = append(dated, s)
+	}
+	sort.SliceStable(dated, func(i, j int) bool { return dated[i].Date.After(*dated[j].Date) })
+	if len(dated) <= keep {
+		return dated, nil, undated, nil
+	}
+	kept, older = dated[:keep], dated[keep:]
+	reverseSnapshots(older)
+	return kept, older, undated, nil
+}
+
+func reverseSnapshots(s []LocalSnapshotInfo) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// snapshotDateArg is the date deletelocalsnapshots takes for a snapshot,
+// e.g. "2026-02-07-143022".
+func snapshotDateArg(s LocalSnapshotInfo) string {
+	return strings.TrimSuffix(strings.TrimPrefix(s.Identifier, "com.apple.TimeMachine."), ".local")
+}
+
+// KeepLocalSnapshots keeps the newest local snapshots on a volume and
+// deletes the rest, one date at a time, reporting each.
+// args[0] = mount point (required)
+// args[1] = number of snapshots to keep (required, at least 1)
+// args[2] = "y" to only show what would be deleted (optional; "--dry-run"
+// also accepted)
+func KeepLocalSnapshots(args []string) (string, error) {
+	mountPoint, keep, dryRun, err := keepSnapshotsArgs(args)
+	if err != nil {
+		return "", err
+	}
+	kept, older, undated, err := planKeepSnapshots(mountPoint, keep)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if len(older) == 0 {
+		fmt.Fprintf(&b, "%d dated local snapshot(s) on %s; nothing to delete to keep %d.", len(kept), mountPoint, keep)
+	} else if dryRun {
+		fmt.Fprintf(&b, "Dry run: keeping the newest %d of %d local snapshot(s) on %s would delete %d:\n", len(kept), len(kept)+len(older), mountPoint, len(older))
+		for _, s := range older {
+			fmt.Fprintf(&b, "  %s\n", FormatTimeShort(*s.Date))
+		}
+		b.WriteString("Nothing was deleted.")
+	}
+	if undated > 0 {
+		fmt.Fprintf(&b, "\n%d snapshot(s) without a date in their name are kept.", undated)
+	}
+	if len(older) == 0 || dryRun {
+		return strings.TrimPrefix(b.String(), "\n"), nil
+	}
+
+	dates := make([]string, len(older))
+	for i, s := range older {
+		dates[i] = snapshotDateArg(s)
+	}
+	report, err := runBatch("Deleted", "older snapshot(s)", dates, func(date string) error {
+		_, err := run("deletelocalsnapshots", date)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Kept the newest %d local snapshot(s) on %s.\n%s%s", len(kept), mountPoint, report, b.String()), nil
+}
+
+// KeepSnapshotsQuestion asks before KeepLocalSnapshots deletes anything,
+// listing the snapshots it would delete; it is "" for a dry run.
+func KeepSnapshotsQuestion(args []string) string {
+	mountPoint, keep, dryRun, err := keepSnapshotsArgs(args)
+	if err != nil || dryRun {
+		return ""
+	}
+	kept, older, _, err := planKeepSnapshots(mountPoint, keep)
+	if err != nil {
+		return fmt.Sprintf("Keep the newest %d snapshot(s) on %s and delete the rest?\n\nThe snapshots could not be listed: %v (y/N)", keep, mountPoint, err)
+	}
+	if len(older) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Keep the newest %d of %d local snapshot(s) on %s and delete these %d?\n\n", len(kept), len(kept)+len(older), mountPoint, len(older))
+	for _, s := range older {
+		fmt.Fprintf(&b, "  %s\n", FormatTimeShort(*s.Date))
+	}
+	b.WriteString("\nDeleted snapshots cannot be recovered. (y/N)")
+	return b.String()
+}
+
+// ThinUrgencies names the urgency levels thinlocalsnapshots accepts, from
+// 1 (low) to 4 (critical).
+var ThinUrgencies = []string{"low", "medium", "high", "critical"}
+
+// ParseUrgency maps an urgency name (low, medium, high, critical) or number
+// (1-4) to the number tmutil expects.
+func ParseUrgency(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range ThinUrgencies {
+		if s == name {
+			return i + 1, nil
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= len(ThinUrgencies) {
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid urgency %q: expected %s or 1-4", s, strings.Join(ThinUrgencies, ", "))
+}
+
 // ThinLocalSnapshots thins local snapshots for a mount point.
+// args[0] = mount point (required)
+// args[1] = purge amount in bytes (optional)
+// args[2] = urgency: low, medium, high, critical or 1-4 (optional; needs a
+// purge amount)
 func ThinLocalSnapshots(args []string) (string, error) {
 	if len(args) == 0 || args[0] == "" {
 		return "", fmt.Errorf("mount point is required")
@@ -67,7 +515,16 @@ func ThinLocalSnapshots(args []string) (string, error) {
 		cmdArgs = append(cmdArgs, args[1]) // purge amount
 	}
 	if len(args) > 2 && args[2] != "" {
-		cmdArgs = append(cmdArgs, args[2]) // urgency
+		// tmutil reads its arguments by position, so an urgency alone
+		// would be taken for the purge amount.
+		if len(cmdArgs) < 3 {
+			return "", fmt.Errorf("urgency requires a purge amount")
+		}
+		u, err := ParseUrgency(args[2])
+		if err != nil {
+			return "", err
+		}
+		cmdArgs = append(cmdArgs, strconv.Itoa(u))
 	}
 	output, err := run(cmdArgs...)
 	if err != nil {
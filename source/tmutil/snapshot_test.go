@@ -0,0 +1,87 @@
+//
+// snapshot_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"strings"
+	"testing"
+)
+
+// snapshotIDs returns the dates deletelocalsnapshots takes for snaps, in
+// order.
+func snapshotIDs(snaps []LocalSnapshotInfo) []string {
+	ids := make([]string, len(snaps))
+	for i, s := range snaps {
+		ids[i] = snapshotDateArg(s)
+	}
+	return ids
+}
+
+func TestKeepSnapshotsArgsRejectsZero(t *testing.T) {
+	for _, keep := range []string{"0", "-1", "two"} {
+		if _, _, _, err := keepSnapshotsArgs([]string{"/", keep}); err == nil {
+			t.Errorf("keepSnapshotsArgs accepted keep=%s", keep)
+		}
+	}
+	if _, err := KeepLocalSnapshots([]string{"/", "0"}); err == nil {
+		t.Error("KeepLocalSnapshots accepted keep=0, which would delete every snapshot")
+	}
+}
+
+// TestPlanKeepSnapshots uses a listing with an undated snapshot, out of
+// date order.
+func TestPlanKeepSnapshots(t *testing.T) {
+	useFixtures(t, "undated")
+	tests := []struct {
+		keep        int
+		kept, older string
+	}{
+		{1, "2026-03-14-091244", "2026-03-13-201502 2026-03-14-081130"},
+		{2, "2026-03-14-091244 2026-03-14-081130", "2026-03-13-201502"},
+		{3, "2026-03-14-091244 2026-03-14-081130 2026-03-13-201502", ""},
+		{10, "2026-03-14-091244 2026-03-14-081130 2026-03-13-201502", ""},
+	}
+	for _, tt := range tests {
+		kept, older, undated, err := planKeepSnapshots("/", tt.keep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(snapshotIDs(kept), " "); got != tt.kept {
+			t.Errorf("keep %d: kept %q, want %q", tt.keep, got, tt.kept)
+		}
+		if got := strings.Join(snapshotIDs(older), " "); got != tt.older {
+			t.Errorf("keep %d: deletes %q, want %q", tt.keep, got, tt.older)
+		}
+		if undated != 1 {
+			t.Errorf("keep %d: %d undated snapshot(s), want 1", tt.keep, undated)
+		}
+	}
+}
+
+func TestKeepLocalSnapshots(t *testing.T) {
+	useFixtures(t, "undated")
+	tests := []struct {
+		args   []string
+		golden string
+	}{
+		{[]string{"/", "1", "--dry-run"}, "keeplocalsnapshots-dryrun.golden"},
+		{[]string{"/", "1", "y"}, "keeplocalsnapshots-dryrun.golden"}, // the TUI's dry-run field
+		{[]string{"/", "3"}, "keeplocalsnapshots-nothing.golden"},
+		{[]string{"/", "2"}, "keeplocalsnapshots.golden"},
+	}
+	for _, tt := range tests {
+		got, err := KeepLocalSnapshots(tt.args)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.args, err)
+		}
+		golden(t, tt.golden, got+"\n")
+	}
+}
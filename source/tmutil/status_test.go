@@ -0,0 +1,116 @@
+//
+// status_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"testing"
+	"time"
+)
+
+// statusNow is when the status fixtures are rendered: half an hour into
+// the backup status.txt describes.
+var statusNow = time.Date(2026, 3, 14, 9, 42, 44, 0, time.UTC)
+
+func TestParseStatusInfo(t *testing.T) {
+	for _, name := range []string{"status", "status-idle", "status-preparing", "status-quoted"} {
+		t.Run(name, func(t *testing.T) {
+			goldenJSON(t, name+".json", parseStatusInfo(fixture(t, name+".txt")))
+		})
+	}
+}
+
+// TestParseQuotedStatus checks that ;, =, braces, escaped quotes and a
+// line break inside a quoted value neither end the entry nor start new
+// ones.
+func TestParseQuotedStatus(t *testing.T) {
+	raw := fixture(t, "status-quoted.txt")
+	fields := parseFields(raw)
+	if want := "/Volumes/Backup; \"Jo's\" = {Disk}\n(second line)"; fields["DestinationMountPoint"] != want {
+		t.Errorf("DestinationMountPoint = %q, want %q", fields["DestinationMountPoint"], want)
+	}
+	for key, want := range map[string]string{"BackupPhase": "Copying", "Percent": "0.4215", "Running": "1", "Stopping": "0"} {
+		if fields[key] != want {
+			t.Errorf("%s = %q, want %q", key, fields[key], want)
+		}
+	}
+	if _, ok := fields["Jo's"]; ok {
+		t.Error("a key was read from inside the quoted value")
+	}
+	progress := parseProgress(raw)
+	for key, want := range map[string]string{"_raw_Percent": "0.4215", "bytes": "22176524288", "totalFiles": "412800"} {
+		if progress[key] != want {
+			t.Errorf("Progress %s = %q, want %q", key, progress[key], want)
+		}
+	}
+}
+
+func TestFormatStatus(t *testing.T) {
+	for _, name := range []string{"status", "status-preparing"} {
+		t.Run(name, func(t *testing.T) {
+			useFixtures(t, "")
+			golden(t, name+".golden", formatStatus(fixture(t, name+".txt"), nil, statusNow))
+		})
+	}
+}
+
+func TestFormatIdleStatus(t *testing.T) {
+	useFixtures(t, "")
+	golden(t, "status-idle.golden", formatIdleStatus(fixture(t, "status-idle.txt"), nil))
+}
+
+func TestParseDestinationInfo(t *testing.T) {
+	goldenJSON(t, "destinationinfo.json", parseDestinationInfo(fixture(t, "destinationinfo.txt")))
+}
+
+func TestParseDestinations(t *testing.T) {
+	for _, name := range []string{"destinationinfo-multi", "destinationinfo-network"} {
+		t.Run(name, func(t *testing.T) {
+			goldenJSON(t, name+".json", parseDestinations(fixture(t, name+".txt")))
+		})
+	}
+}
+
+// TestParseNetworkDestination checks that a URL keeps its port, as only
+// the first colon on a line ends the key, and that Last Destination ID
+// stays with the block it is printed in.
+func TestParseNetworkDestination(t *testing.T) {
+	dests := parseDestinations(fixture(t, "destinationinfo-network.txt"))
+	if len(dests) != 2 {
+		t.Fatalf("got %d destinations, want 2: %+v", len(dests), dests)
+	}
+	nas, disk := dests[0], dests[1]
+	if nas.URL != "afp://backup@nas.local:548/TimeMachine" || nas.MountPoint != "/Volumes/TimeMachine" || !isNetwork(nas) {
+		t.Errorf("network destination = %+v", nas)
+	}
+	if nas.LastDestinationID != "" {
+		t.Errorf("network destination took Last Destination ID %q from the next block", nas.LastDestinationID)
+	}
+	if disk.URL != "" || disk.LastDestinationID != nas.ID || isNetwork(disk) {
+		t.Errorf("local destination = %+v", disk)
+	}
+}
+
+func TestRotationCurrent(t *testing.T) {
+	useFixtures(t, "network")
+	entries, err := GetRotation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if want := e.Dest.Name == "Office NAS"; e.Current != want {
+			t.Errorf("%s: Current = %v, want %v", e.Dest.Name, e.Current, want)
+		}
+	}
+}
+
+func TestParseBackupPrefs(t *testing.T) {
+	goldenJSON(t, "prefs-multi.json", parseBackupPrefs(fixture(t, "prefs-multi.plist")))
+}
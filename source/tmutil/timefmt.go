@@ -0,0 +1,141 @@
+//
+// timefmt.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTimeFormat is the layout timestamps are displayed in unless the
+// user configures another.
+const DefaultTimeFormat = "2006-01-02 15:04:05"
+
+// timeFormats are the named layouts SetTimeFormat accepts besides a Go
+// reference-time layout.
+var timeFormats = map[string]string{
+	"default": DefaultTimeFormat,
+	"iso":     "2006-01-02T15:04:05Z07:00",
+	"rfc1123": time.RFC1123,
+	"us":      "01/02/2006 15:04:05",
+	"eu":      "02.01.2006 15:04:05",
+}
+
+var timeFormat = struct {
+	sync.RWMutex
+	layout   string
+	relative bool
+}{layout: DefaultTimeFormat}
+
+// TimeFormatNames returns the named formats SetTimeFormat accepts.
+func TimeFormatNames() []string {
+	names := make([]string, 0, len(timeFormats))
+	for name := range timeFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetTimeFormat sets how timestamps are displayed. format is one of
+// TimeFormatNames or a Go layout such as "Jan 2 15:04"; empty keeps the
+// default. clock12 shows hours on a 12-hour clock with AM/PM.
+func SetTimeFormat(format string, clock12 bool) error {
+	layout := DefaultTimeFormat
+	if format != "" {
+		if named, ok := timeFormats[strings.ToLower(format)]; ok {
+			layout = named
+		} else {
+			// A layout with no reference fields formats as itself.
+			probe := time.Date(2001, 11, 23, 9, 8, 7, 0, time.UTC)
+			if probe.Format(format) == format {
+				return fmt.Errorf("invalid time format %q: expected one of %s or a Go layout such as \"Jan 2 15:04\"",
+					format, strings.Join(TimeFormatNames(), ", "))
+			}
+			layout = format
+		}
+	}
+	if clock12 {
+		layout = twelveHour(layout)
+	}
+	timeFormat.Lock()
+	timeFormat.layout = layout
+	timeFormat.Unlock()
+	return nil
+}
+
+// twelveHour converts a layout's 24-hour field to a 12-hour one, adding
+// AM/PM after the time when the layout has no marker of its own.
+func twelveHour(layout string) string {
+	i := strings.Index(layout, "15")
+	if i < 0 {
+		return layout
+	}
+	layout = layout[:i] + "03" + layout[i+2:]
+	if strings.Contains(layout, "PM") || strings.Contains(layout, "pm") {
+		return layout
+	}
+	// The time ends after its last :04 or :05 field.
+	end := i + 2
+	for _, field := range []string{":04", ":05"} {
+		if j := strings.Index(layout[end:], field); j >= 0 {
+			end += j + len(field)
+		}
+	}
+	return layout[:end] + " PM" + layout[end:]
+}
+
+// customTimeFormat reports whether a format other than the default is set.
+func customTimeFormat() bool {
+	timeFormat.RLock()
+	defer timeFormat.RUnlock()
+	return timeFormat.layout != DefaultTimeFormat || timeFormat.relative
+}
+
+// SetRelativeTimes switches FormatTime between absolute timestamps and
+// relative ones such as "2 hours ago".
+func SetRelativeTimes(on bool) {
+	timeFormat.Lock()
+	timeFormat.relative = on
+	timeFormat.Unlock()
+}
+
+// RelativeTimes reports whether timestamps are shown relative to now.
+func RelativeTimes() bool {
+	timeFormat.RLock()
+	defer timeFormat.RUnlock()
+	return timeFormat.relative
+}
+
+// FormatTime renders a timestamp in the configured display format, or
+// relative to now when relative times are on.
+func FormatTime(t time.Time) string {
+	timeFormat.RLock()
+	defer timeFormat.RUnlock()
+	if timeFormat.relative {
+		return FormatRelative(t, time.Now())
+	}
+	return t.Format(timeFormat.layout)
+}
+
+// FormatTimeShort renders a timestamp like FormatTime but without seconds,
+// for approximate times such as the next scheduled backup.
+func FormatTimeShort(t time.Time) string {
+	timeFormat.RLock()
+	defer timeFormat.RUnlock()
+	if timeFormat.relative {
+		return FormatRelative(t, time.Now())
+	}
+	return t.Format(strings.Replace(timeFormat.layout, ":05", "", 1))
+}
@@ -11,36 +11,303 @@
 package tmutil
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 )
 
 const tmutilTimeLayout = "2006-01-02 15:04:05 -0700"
 
+// ErrNoBackups is returned when no completed backups are available.
+var ErrNoBackups = errors.New("no backups found")
+
+// ErrCancelled is returned when a command's context is cancelled.
+var ErrCancelled = errors.New("cancelled")
+
+// ErrTimeout is returned when a command's context passes its deadline. A
+// timeout is a kind of cancellation, so errors.Is(ErrTimeout, ErrCancelled)
+// holds and walks stop for either.
+var ErrTimeout error = timeoutError{}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string        { return "timed out" }
+func (timeoutError) Is(target error) bool { return target == ErrCancelled }
+
+// IncompleteError is returned when a command is cancelled or times out
+// after it printed some output, which Output keeps so that what was
+// printed is not lost. It unwraps to ErrCancelled or ErrTimeout.
+type IncompleteError struct {
+	Output string
+	Err    error
+}
+
+func (e *IncompleteError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *IncompleteError) Unwrap() error {
+	return e.Err
+}
+
+// incomplete returns err, the reason a command stopped, as an
+// IncompleteError when the command printed output before it stopped.
+func incomplete(output string, err error) error {
+	if output = strings.TrimSpace(output); output == "" {
+		return err
+	}
+	return &IncompleteError{Output: output, Err: err}
+}
+
+// PartialOutput returns the output a cancelled or timed-out command
+// printed before it stopped, if err carries any.
+func PartialOutput(err error) (string, bool) {
+	var inc *IncompleteError
+	if errors.As(err, &inc) {
+		return inc.Output, true
+	}
+	return "", false
+}
+
+// ErrNoPermission is returned when a read needs root or Full Disk Access.
+var ErrNoPermission = errors.New("insufficient permissions")
+
+// ErrNotConfigured is returned when the Time Machine preferences do not
+// exist: Time Machine has never been set up on this Mac.
+var ErrNotConfigured = errors.New("Time Machine has never been configured on this Mac")
+
+// IsPermissionError reports whether err comes from missing privileges:
+// ErrNoPermission, a permission error from the file system, or tmutil
+// asking for root or Full Disk Access.
+func IsPermissionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrNoPermission) || errors.Is(err, fs.ErrPermission) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"permission denied", "operation not permitted", "not privileged",
+		"must be run as root", "requires root", "full disk access"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstLine returns s up to its first newline.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// cmdContext is the context that tmutil invocations and backup walks run
+// under. The TUI replaces it for each command so the command can be
+// cancelled; cancelling it kills a running tmutil process. base is what
+// it returns to, which the CLI cancels on ctrl+c.
+var cmdContext = struct {
+	sync.Mutex
+	ctx  context.Context
+	base context.Context
+}{ctx: context.Background(), base: context.Background()}
+
+// SetContext makes subsequent commands run under ctx. A nil ctx restores
+// the base context (see SetBaseContext).
+func SetContext(ctx context.Context) {
+	cmdContext.Lock()
+	if ctx == nil {
+		ctx = cmdContext.base
+	}
+	cmdContext.ctx = ctx
+	cmdContext.Unlock()
+}
+
+// SetBaseContext makes ctx, such as one cancelled on ctrl+c, the context
+// commands run under until SetContext replaces it, and the one SetContext
+// restores. The default is never cancelled.
+func SetBaseContext(ctx context.Context) {
+	cmdContext.Lock()
+	cmdContext.base, cmdContext.ctx = ctx, ctx
+	cmdContext.Unlock()
+}
+
+// BaseContext returns the context set with SetBaseContext, for a command
+// to derive its own from.
+func BaseContext() context.Context {
+	cmdContext.Lock()
+	defer cmdContext.Unlock()
+	return cmdContext.base
+}
+
+func currentContext() context.Context {
+	cmdContext.Lock()
+	defer cmdContext.Unlock()
+	return cmdContext.ctx
+}
+
+// cancelled returns ErrCancelled, or ErrTimeout past the deadline, once
+// ctx is done, for use inside walks.
+func cancelled(ctx context.Context) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return ErrTimeout
+	case ctx.Err() != nil:
+		return ErrCancelled
+	}
+	return nil
+}
+
+// IsRoot reports whether tmcli is running with root privileges.
+func IsRoot() bool {
+	return os.Geteuid() == 0
+}
+
+// binary returns the tmutil executable to run. TMCLI_TMUTIL overrides it,
+// e.g. with testdata/fake-tmutil to replay canned output off macOS.
+func binary() string {
+	if path := os.Getenv("TMCLI_TMUTIL"); path != "" {
+		return path
+	}
+	return "tmutil"
+}
+
+// TmutilError is returned when tmutil fails. Its message is tmutil's own
+// output followed by the underlying error, and it unwraps to that error
+// (typically an *exec.ExitError).
+type TmutilError struct {
+	Args     []string // tmutil arguments, without the binary
+	ExitCode int      // tmutil's exit status; -1 if it did not run or exit
+	Output   string   // combined stdout and stderr, trimmed
+	Err      error
+}
+
+func (e *TmutilError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Output, e.Err)
+}
+
+func (e *TmutilError) Unwrap() error {
+	return e.Err
+}
+
+// newTmutilError wraps err from running tmutil with args.
+func newTmutilError(args []string, output string, err error) *TmutilError {
+	code := -1
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code = exitErr.ExitCode()
+	}
+	return &TmutilError{Args: args, ExitCode: code, Output: output, Err: err}
+}
+
+// ExitCode returns the tmutil exit status carried by err, if any.
+func ExitCode(err error) (int, bool) {
+	var te *TmutilError
+	if errors.As(err, &te) && te.ExitCode > 0 {
+		return te.ExitCode, true
+	}
+	return 0, false
+}
+
 func run(args ...string) (string, error) {
-	cmd := exec.Command("tmutil", args...)
+	return runCtx(currentContext(), args...)
+}
+
+// runCtx is run under an explicit context.
+func runCtx(ctx context.Context, args ...string) (string, error) {
+	start := time.Now()
+	cmd := tmutilCommand(ctx, args...)
 	output, err := cmd.CombinedOutput()
+	logCommand(args, start, err)
+	if err := cancelled(ctx); err != nil {
+		return "", incomplete(string(output), err)
+	}
+	if sshErr := sshFailure(string(output), err); sshErr != nil {
+		return "", sshErr
+	}
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+		return "", newTmutilError(args, strings.TrimSpace(string(output)), err)
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
+// runStream runs tmutil, passing each line of combined output to onLine as
+// it is produced (onLine may be nil), and returns the full trimmed output.
+func runStream(onLine func(string), args ...string) (string, error) {
+	start := time.Now()
+	ctx := currentContext()
+	cmd := tmutilCommand(ctx, args...)
+	cmd.WaitDelay = time.Second // don't wait on children holding the pipe
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	if err := cmd.Start(); err != nil {
+		logCommand(args, start, err)
+		if sshErr := sshFailure("", err); sshErr != nil {
+			return "", sshErr
+		}
+		return "", newTmutilError(args, "", err)
+	}
+
+	var lines []string
+	done := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			lines = append(lines, line)
+			if onLine != nil {
+				onLine(line)
+			}
+		}
+		io.Copy(io.Discard, pr)
+		close(done)
+	}()
+
+	err := cmd.Wait()
+	pw.Close()
+	<-done
+	logCommand(args, start, err)
+
+	output := strings.TrimSpace(strings.Join(lines, "\n"))
+	if err := cancelled(ctx); err != nil {
+		return "", incomplete(output, err)
+	}
+	if sshErr := sshFailure(output, err); sshErr != nil {
+		return "", sshErr
+	}
+	if err != nil {
+		return "", newTmutilError(args, output, err)
+	}
+	return output, nil
+}
+
 // StatusInfo holds structured status data from tmutil.
 type StatusInfo struct {
-	Running       bool
-	Phase         string
-	Destination   string
-	StartedAt     time.Time
-	Percent       float64
-	TimeRemaining float64 // seconds
-	BytesCopied   int64
-	TotalBytes    int64
-	FilesCopied   int64
-	TotalFiles    int64
+	Running       bool      `json:"running"`
+	Phase         string    `json:"phase"`
+	Destination   string    `json:"destination"`
+	StartedAt     time.Time `json:"startedAt"`
+	Percent       float64   `json:"percent"`
+	TimeRemaining float64   `json:"timeRemaining"` // seconds
+	BytesCopied   int64     `json:"bytesCopied"`
+	TotalBytes    int64     `json:"totalBytes"`
+	FilesCopied   int64     `json:"filesCopied"`
+	TotalFiles    int64     `json:"totalFiles"`
+	HasProgress   bool      `json:"hasProgress"` // false when tmutil omitted the Progress block (e.g. preparing, finishing)
 }
 
 // GetStatus returns structured backup status information.
@@ -64,9 +331,8 @@ func parseStatusInfo(raw string) StatusInfo {
 		info.StartedAt, _ = time.Parse(tmutilTimeLayout, v)
 	}
 
-	if v, ok := progress["Percent"]; ok {
-		info.Percent, _ = strconv.ParseFloat(v, 64)
-	}
+	info.HasProgress = len(progress) > 0
+	info.Percent, _ = statusPercent(fields, progress)
 	if v, ok := progress["TimeRemaining"]; ok {
 		info.TimeRemaining, _ = strconv.ParseFloat(v, 64)
 	}
@@ -90,65 +356,147 @@ func parseStatusInfo(raw string) StatusInfo {
 	return info
 }
 
+// statusPercent returns the fraction of the backup done, from 0 to 1. It
+// is read from the Progress dictionary, or the top-level Percent when that
+// is missing; tmutil reports -1 while it does not know.
+func statusPercent(fields, progress map[string]string) (float64, bool) {
+	v, ok := progress["Percent"]
+	if !ok {
+		v, ok = fields["Percent"]
+	}
+	if !ok || len(progress) == 0 {
+		return 0, false
+	}
+	pct, err := strconv.ParseFloat(v, 64)
+	if err != nil || pct < 0 {
+		return 0, false
+	}
+	return pct, true
+}
+
+// parseFields reads the top-level `key = value;` entries of tmutil status
+// output. Nested dictionaries, such as Progress, are left out; see
+// parseProgress.
 func parseFields(raw string) map[string]string {
 	fields := make(map[string]string)
-	lines := strings.Split(raw, "\n")
-	inProgress := false
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "Progress =") {
-			inProgress = true
-			continue
-		}
-		if inProgress {
-			if trimmed == "};" {
-				inProgress = false
-			}
-			continue
-		}
-		if trimmed == "{" || trimmed == "}" || trimmed == "" {
-			continue
-		}
-		trimmed = strings.TrimSuffix(trimmed, ";")
-		parts := strings.SplitN(trimmed, " = ", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			value = strings.Trim(value, "\"")
+	for key, value := range parseDict(raw) {
+		if !strings.HasPrefix(value, "{") {
 			fields[key] = value
 		}
 	}
 	return fields
 }
 
+// parseProgress reads the entries of the Progress dictionary in tmutil
+// status output.
 func parseProgress(raw string) map[string]string {
-	progress := make(map[string]string)
-	lines := strings.Split(raw, "\n")
-	inProgress := false
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "Progress =") {
-			inProgress = true
-			continue
-		}
-		if inProgress {
-			if trimmed == "};" {
-				break
+	return parseDict(parseDict(raw)["Progress"])
+}
+
+// parseDict reads the entries of the first dictionary in raw, which is in
+// the old-style plist form tmutil prints:
+//
+//	{
+//	    BackupPhase = Copying;
+//	    DestinationMountPoint = "/Volumes/Backup; Mine";
+//	    Progress =     {
+//	        bytes = 22176524288;
+//	    };
+//	}
+//
+// Quotes are tracked, so a quoted key or value may hold ;, =, braces,
+// escaped quotes or line breaks. Quoted strings are unquoted; a nested
+// dictionary is returned as its raw text, braces included.
+func parseDict(raw string) map[string]string {
+	fields := make(map[string]string)
+	open := strings.IndexByte(raw, '{')
+	if open < 0 {
+		return fields
+	}
+	depth, quoted, escaped := 0, false, false
+	entry := open + 1
+	for i := open + 1; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case escaped:
+			escaped = false
+		case quoted:
+			switch c {
+			case '\\':
+				escaped = true
+			case '"':
+				quoted = false
 			}
-			if trimmed == "{" {
-				continue
+		case c == '"':
+			quoted = true
+		case c == '{':
+			depth++
+		case c == '}' && depth == 0:
+			return fields
+		case c == '}':
+			depth--
+		case c == ';' && depth == 0:
+			if key, value, ok := splitEntry(raw[entry:i]); ok {
+				fields[key] = value
 			}
-			trimmed = strings.TrimSuffix(trimmed, ";")
-			parts := strings.SplitN(trimmed, " = ", 2)
-			if len(parts) == 2 {
-				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				value = strings.Trim(value, "\"")
-				progress[key] = value
+			entry = i + 1
+		}
+	}
+	return fields
+}
+
+// splitEntry splits a `key = value` entry at its first = outside quotes,
+// and unquotes both sides.
+func splitEntry(entry string) (string, string, bool) {
+	quoted, escaped := false, false
+	for i := 0; i < len(entry); i++ {
+		c := entry[i]
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == '=' && !quoted:
+			key := unquotePlist(strings.TrimSpace(entry[:i]))
+			value := strings.TrimSpace(entry[i+1:])
+			if !strings.HasPrefix(value, "{") {
+				value = unquotePlist(value)
 			}
+			return key, value, key != ""
+		}
+	}
+	return "", "", false
+}
+
+// unquotePlist removes the quotes around a plist string and resolves its
+// backslash escapes; an unquoted string is returned as is.
+func unquotePlist(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		default:
+			b.WriteByte(s[i])
 		}
 	}
-	return progress
+	return b.String()
 }
 
 // FormatDuration formats a time.Duration in human-readable uptime style.
@@ -194,38 +542,205 @@ func FormatDuration(d time.Duration) string {
 	return strings.Join(parts, ", ")
 }
 
-// FormatBytesInt64 formats a byte count as a human-readable string.
+// byteUnits are the decimal units byte counts are shown in, smallest first.
+var byteUnits = []struct {
+	size float64
+	name string
+}{
+	{1e3, "KB"}, {1e6, "MB"}, {1e9, "GB"}, {1e12, "TB"}, {1e15, "PB"}, {1e18, "EB"},
+}
+
+// FormatBytesInt64 formats a byte count as a human-readable string. It
+// picks the largest unit that keeps the figure at 1.0 or more; a value that
+// would round up to 1000.0 moves to the next unit, so 999,960 bytes is
+// "1.0 MB" rather than "1000.0 KB". Negative counts, such as a shrinking
+// backup, keep their sign.
 func FormatBytesInt64(n int64) string {
-	f := float64(n)
-	switch {
-	case f >= 1e12:
-		return fmt.Sprintf("%.1f TB", f/1e12)
-	case f >= 1e9:
-		return fmt.Sprintf("%.1f GB", f/1e9)
-	case f >= 1e6:
-		return fmt.Sprintf("%.1f MB", f/1e6)
-	case f >= 1e3:
-		return fmt.Sprintf("%.1f KB", f/1e3)
-	default:
+	f, sign := float64(n), ""
+	if f < 0 {
+		f, sign = -f, "-"
+	}
+	if f < byteUnits[0].size {
 		return fmt.Sprintf("%d B", n)
 	}
+	for i, u := range byteUnits {
+		v := f / u.size
+		if math.Round(v*10) < 10000 || i == len(byteUnits)-1 {
+			return fmt.Sprintf("%s%.1f %s", sign, v, u.name)
+		}
+	}
+	return ""
 }
 
+// formatBytes formats a byte count given as a string, such as a tmutil
+// status field, exactly as FormatBytesInt64 does; anything that is not a
+// number is returned unchanged.
 func formatBytes(s string) string {
-	n, err := strconv.ParseFloat(s, 64)
+	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		return s
 	}
+	// Clamp before converting: a float beyond int64 has no defined int64.
+	f = math.Round(max(min(f, math.MaxInt64), math.MinInt64))
+	if f >= math.MaxInt64 {
+		return FormatBytesInt64(math.MaxInt64)
+	}
+	return FormatBytesInt64(int64(f))
+}
+
+// DefaultOutputWidth is the width formatters fit long labels to when the
+// terminal width is not known.
+const DefaultOutputWidth = 100
+
+var outputWidth atomic.Int64
+
+// SetOutputWidth sets the width formatters fit long labels to, such as a
+// network destination's URL; 0 restores DefaultOutputWidth.
+func SetOutputWidth(w int) {
+	outputWidth.Store(int64(w))
+}
+
+// OutputWidth returns the width set with SetOutputWidth.
+func OutputWidth() int {
+	if w := outputWidth.Load(); w > 0 {
+		return int(w)
+	}
+	return DefaultOutputWidth
+}
+
+// TruncateMiddle shortens s to at most width characters by replacing its
+// middle with an ellipsis. The end keeps the larger share, since that is
+// where a path's file name or a URL's share name is.
+func TruncateMiddle(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n <= width {
+		return s
+	}
+	dots := "…"
+	if ASCII() {
+		dots = "..."
+	}
+	keep := width - utf8.RuneCountInString(dots)
+	if keep < 2 {
+		return string([]rune(s)[:max(width, 0)])
+	}
+	r := []rune(s)
+	head := keep / 3
+	return string(r[:head]) + dots + string(r[n-(keep-head):])
+}
+
+// FormatRelative describes t relative to now in the largest whole unit:
+// "just now", "5 minutes ago", "3 hours ago", "2 days ago", or for a time
+// still to come "in 20 minutes".
+func FormatRelative(t, now time.Time) string {
+	d := now.Sub(t)
+	suffix, prefix := " ago", ""
+	if d < 0 {
+		d, suffix, prefix = -d, "", "in "
+	}
 	switch {
-	case n >= 1e12:
-		return fmt.Sprintf("%.1f TB", n/1e12)
-	case n >= 1e9:
-		return fmt.Sprintf("%.1f GB", n/1e9)
-	case n >= 1e6:
-		return fmt.Sprintf("%.1f MB", n/1e6)
-	case n >= 1e3:
-		return fmt.Sprintf("%.1f KB", n/1e3)
-	default:
-		return fmt.Sprintf("%.0f B", n)
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return prefix + plural(int(d.Minutes()), "minute") + suffix
+	case d < 24*time.Hour:
+		return prefix + plural(int(d.Hours()), "hour") + suffix
+	}
+	return prefix + plural(int(d.Hours())/24, "day") + suffix
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// ShellQuote quotes an argument for a POSIX shell so an echoed command
+// line can be pasted back into a terminal. Arguments made only of
+// characters the shell leaves alone are returned unchanged.
+func ShellQuote(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range arg {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@%+,", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// ShellJoin quotes each argument with ShellQuote and joins them with spaces.
+func ShellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = ShellQuote(a)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// ShellSplit splits a command line into arguments as a POSIX shell would,
+// undoing ShellJoin: whitespace separates arguments except inside single
+// or double quotes, and a backslash escapes the next character outside
+// single quotes (inside double quotes, only $, `, ", \ and newline). It
+// expands nothing.
+func ShellSplit(line string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune // ' or " while inside quotes
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("$`\"\\\n", r) {
+				cur.WriteRune('\\')
+			}
+			if r != '\n' {
+				cur.WriteRune(r)
+			}
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inArg = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	switch {
+	case quote != 0:
+		return nil, fmt.Errorf("unterminated %c quote in %s", quote, line)
+	case escaped:
+		return nil, fmt.Errorf("trailing backslash in %s", line)
+	}
+	if inArg {
+		args = append(args, cur.String())
 	}
+	return args, nil
 }
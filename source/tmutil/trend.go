@@ -0,0 +1,204 @@
+//
+// trend.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package tmutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// trendBackups is how many recent backups the size trend covers.
+	trendBackups = 30
+	// trendStableShare is the growth, as a share of the space used, below
+	// which the trend counts as stable; trendStableBytes is used instead
+	// when the space used is unknown.
+	trendStableShare = 0.01
+	trendStableBytes = 1 << 30
+	// A backup that adds runawayFactor times the usual amount, and at least
+	// runawayMin, is pointed out as a possible runaway.
+	runawayFactor = 3
+	runawayMin    = 1 << 30
+)
+
+// SizeTrend is how the space used by backups changed over recent backups.
+// Time Machine keeps no history of the space used, so the trend is built
+// from the drift between backups: data added less data removed.
+type SizeTrend struct {
+	Entries []DriftEntry // the backups covered, oldest first
+	Used    int64        // space the destination's backups use now; 0 when unknown
+}
+
+// Net is the growth an entry's backup brought: data added less removed.
+func (d DriftEntry) Net() int64 {
+	return d.Added - d.Removed
+}
+
+// Growth is the net growth over all the backups covered.
+func (t SizeTrend) Growth() int64 {
+	var g int64
+	for _, e := range t.Entries {
+		g += e.Net()
+	}
+	return g
+}
+
+// Direction is "growing", "shrinking" or "stable".
+func (t SizeTrend) Direction() string {
+	g := t.Growth()
+	limit := float64(trendStableBytes)
+	if t.Used > 0 {
+		limit = float64(t.Used) * trendStableShare
+	}
+	switch {
+	case float64(g) >= limit:
+		return "growing"
+	case float64(-g) >= limit:
+		return "shrinking"
+	}
+	return "stable"
+}
+
+// Runaway returns the latest backup's growth and the usual growth per
+// backup, and whether the latest stands out as far above the usual.
+func (t SizeTrend) Runaway() (latest, usual int64, ok bool) {
+	n := len(t.Entries)
+	if n < 3 {
+		return 0, 0, false
+	}
+	nets := make([]int64, 0, n-1)
+	for _, e := range t.Entries[:n-1] {
+		nets = append(nets, max(e.Net(), 0))
+	}
+	sort.Slice(nets, func(i, j int) bool { return nets[i] < nets[j] })
+	usual = nets[len(nets)/2]
+	latest = t.Entries[n-1].Net()
+	return latest, usual, latest >= runawayMin && latest >= runawayFactor*usual
+}
+
+// GetSizeTrend reads the drift of a machine directory's recent backups and
+// the space its destination uses now.
+func GetSizeTrend(machineDir string) (SizeTrend, error) {
+	var t SizeTrend
+	entries, err := GetDrift(machineDir)
+	if err != nil {
+		return t, err
+	}
+	if len(entries) > trendBackups {
+		entries = entries[len(entries)-trendBackups:]
+	}
+	t.Entries = entries
+
+	// The destination holding the machine directory, by mount point.
+	var id string
+	if dests, err := ListDestinations(); err == nil {
+		for _, d := range dests {
+			if d.MountPoint != "" && strings.HasPrefix(machineDir, strings.TrimSuffix(d.MountPoint, "/")+"/") {
+				id = d.ID
+			}
+		}
+	}
+	if prefs, err := GetDestinationPrefs(); err == nil {
+		for _, p := range prefs {
+			if id != "" && strings.EqualFold(p.ID, id) || id == "" && len(prefs) == 1 {
+				t.Used = p.BytesUsed
+			}
+		}
+	}
+	return t, nil
+}
+
+// SizeTrendReport shows whether the backups are growing, shrinking or
+// stable, with a sparkline of the growth, to spot runaway growth such as a
+// large new folder being backed up.
+// args[0] = machine directory (optional; defaults to this Mac's)
+func SizeTrendReport(args []string) (string, error) {
+	dir := ""
+	if len(args) > 0 {
+		dir = strings.TrimSpace(args[0])
+	}
+	if dir == "" {
+		d, err := MachineDirectory()
+		if err != nil {
+			return "", err
+		}
+		if dir = strings.TrimSpace(d); dir == "" {
+			return "", fmt.Errorf("no machine directory found; give the path of one")
+		}
+	}
+	t, err := GetSizeTrend(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(t.Entries) == 0 {
+		return "No trend to report; the machine directory needs at least two backups.", nil
+	}
+	return formatSizeTrend(t), nil
+}
+
+func formatSizeTrend(t SizeTrend) string {
+	var b strings.Builder
+	b.WriteString("Backup Size Trend\n")
+	b.WriteString(Rule(60) + "\n\n")
+
+	first, last := t.Entries[0], t.Entries[len(t.Entries)-1]
+	fmt.Fprintf(&b, "Last %d backup(s), %s to %s\n\n", len(t.Entries), driftWhen(first.Start), driftWhen(last.End))
+
+	// The sparkline follows the running total, so it rises and falls with
+	// the space used.
+	totals := make([]int64, 0, len(t.Entries)+1)
+	var total int64
+	totals = append(totals, 0)
+	for _, e := range t.Entries {
+		total += e.Net()
+		totals = append(totals, total)
+	}
+	fmt.Fprintf(&b, "  %s  %s\n\n", Sparkline(totals), signedBytes(t.Growth()))
+
+	trend := fmt.Sprintf("Trend: %s, %s", t.Direction(), signedBytes(t.Growth()))
+	if span := last.End.Sub(first.Start); !first.Start.IsZero() && !last.End.IsZero() && span > 0 {
+		trend += " in " + FormatDuration(span.Round(time.Hour))
+	}
+	if t.Used > 0 {
+		trend += fmt.Sprintf(" (%.1f%% of the %s used on the destination)",
+			100*float64(t.Growth())/float64(t.Used), FormatBytesInt64(t.Used))
+	}
+	b.WriteString(trend + ".\n")
+	if latest, usual, ok := t.Runaway(); ok {
+		fmt.Fprintf(&b, "\n! The latest backup grew by %s, against a usual %s. A large new folder may\n  be backed up: check it with Browse → Compare, or exclude it.\n",
+			FormatBytesInt64(latest), FormatBytesInt64(usual))
+	}
+
+	b.WriteString("\n")
+	recent := t.Entries
+	if len(recent) > 10 {
+		recent = recent[len(recent)-10:]
+	}
+	rows := [][]string{{"Backup", "Growth", "Added", "Removed"}}
+	for _, e := range recent {
+		rows = append(rows, []string{driftWhen(e.End), signedBytes(e.Net()), FormatBytesInt64(e.Added), FormatBytesInt64(e.Removed)})
+	}
+	for _, line := range strings.Split(alignColumns(rows), "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+	b.WriteString("\nGrowth is the data a backup added less the data it removed, from calculatedrift.")
+	return b.String()
+}
+
+// signedBytes formats a size change with its sign, e.g. "+1.2 GB".
+func signedBytes(n int64) string {
+	if n < 0 {
+		return "-" + FormatBytesInt64(-n)
+	}
+	return "+" + FormatBytesInt64(n)
+}
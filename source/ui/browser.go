@@ -0,0 +1,328 @@
+//
+// browser.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// browserSelectMsg carries the path marked for restore.
+type browserSelectMsg struct {
+	path string
+}
+
+// browserExitMsg signals that the user left the browser.
+type browserExitMsg struct{}
+
+// BrowserModel navigates backup snapshots like a file manager: the top
+// level lists completed backups, enter descends into a directory, and
+// backspace goes back up. The highlighted entry can be marked for restore.
+// It can be used standalone (CLI) or embedded in the TUI.
+type BrowserModel struct {
+	version    string
+	backups    []string // newest first
+	stack      []string // directories descended into; empty = backup list
+	cursors    []int    // cursor to restore when returning to each level
+	entries    []tmutil.DirEntry
+	order      tmutil.SortOrder // sort order of directory listings
+	showHidden bool             // list hidden and system entries too
+	hidden     int              // entries of the current directory left out as hidden
+	cursor     int
+	offset     int
+	loading    bool
+	err        error
+	Selected   string // path marked for restore (standalone mode)
+	width      int
+	height     int
+	altScreen  bool // true when embedded in the full TUI
+}
+
+// NewBrowserModel creates a backup browser.
+func NewBrowserModel(version string, altScreen bool) BrowserModel {
+	return BrowserModel{version: version, altScreen: altScreen, loading: true, order: tmutil.SortName}
+}
+
+// Init loads the backup list.
+func (m BrowserModel) Init() tea.Cmd {
+	return loadBackups
+}
+
+// current returns the directory being shown, or "" at the backup list.
+func (m BrowserModel) current() string {
+	if len(m.stack) == 0 {
+		return ""
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// count returns the number of rows at the current level.
+func (m BrowserModel) count() int {
+	if len(m.stack) == 0 {
+		return len(m.backups)
+	}
+	return len(m.entries)
+}
+
+// highlighted returns the full path of the highlighted row.
+func (m BrowserModel) highlighted() string {
+	if m.count() == 0 {
+		return ""
+	}
+	if len(m.stack) == 0 {
+		return m.backups[m.cursor]
+	}
+	return filepath.Join(m.current(), m.entries[m.cursor].Name)
+}
+
+// readDir loads the entries of dir in the current sort order, leaving out
+// hidden ones unless they are shown.
+func (m BrowserModel) readDir(dir string) BrowserModel {
+	m.err = nil
+	m.entries = nil
+	entries, hidden, err := tmutil.ReadBackupDir(dir, m.showHidden)
+	m.hidden = hidden
+	if err != nil {
+		m.err = err
+		return m
+	}
+	tmutil.SortDirEntries(entries, m.order)
+	m.entries = entries
+	return m
+}
+
+func (m BrowserModel) exit() tea.Cmd {
+	if m.altScreen {
+		return func() tea.Msg { return browserExitMsg{} }
+	}
+	return tea.Quit
+}
+
+// Update handles messages.
+func (m BrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case backupListMsg:
+		m.loading = false
+		m.err = msg.err
+		m.backups = msg.paths
+		reverseInPlace(m.backups)
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.updateKeys(msg)
+	}
+	return m, nil
+}
+
+func (m BrowserModel) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch k := msg.String(); {
+	case k == "ctrl+c", bindings.is(k, actQuit):
+		return m, tea.Quit
+	case k == "esc":
+		return m, m.exit()
+	case bindings.is(k, actUp):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case bindings.is(k, actDown):
+		if m.cursor < m.count()-1 {
+			m.cursor++
+		}
+	case bindings.is(k, actPageUp):
+		m.cursor -= m.pageSize()
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+	case k != " " && bindings.is(k, actPageDown): // space restores
+		m.cursor += m.pageSize()
+		if m.cursor > m.count()-1 {
+			m.cursor = m.count() - 1
+		}
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+	case bindings.is(k, actSelect), bindings.is(k, actRight), k == "l":
+		if m.count() == 0 {
+			return m, nil
+		}
+		if len(m.stack) > 0 && !m.entries[m.cursor].Dir {
+			return m, nil
+		}
+		next := m.highlighted()
+		m.cursors = append(m.cursors, m.cursor)
+		m.stack = append(m.stack, next)
+		m.cursor, m.offset = 0, 0
+		m = m.readDir(next)
+	case bindings.is(k, actBack), bindings.is(k, actLeft), k == "h":
+		if len(m.stack) == 0 {
+			return m, m.exit()
+		}
+		m.stack = m.stack[:len(m.stack)-1]
+		m.cursor = m.cursors[len(m.cursors)-1]
+		m.cursors = m.cursors[:len(m.cursors)-1]
+		m.offset = 0
+		if len(m.stack) == 0 {
+			m.err = nil
+			m.entries = nil
+		} else {
+			m = m.readDir(m.current())
+		}
+	case k == "s":
+		m.order = m.order.Next()
+		if len(m.stack) > 0 {
+			tmutil.SortDirEntries(m.entries, m.order)
+			m.cursor, m.offset = 0, 0
+		}
+	case k == ".":
+		m.showHidden = !m.showHidden
+		if len(m.stack) > 0 {
+			m.cursor, m.offset = 0, 0
+			m = m.readDir(m.current())
+		}
+	case k == "r", k == " ":
+		if len(m.stack) == 0 {
+			// At the backup list, r reloads it from tmutil.
+			tmutil.InvalidateBackupCache()
+			m.loading = true
+			m.cursor, m.offset = 0, 0
+			return m, loadBackups
+		}
+		if m.count() == 0 {
+			return m, nil
+		}
+		path := m.highlighted()
+		if !m.altScreen {
+			m.Selected = path
+			return m, tea.Quit
+		}
+		return m, func() tea.Msg { return browserSelectMsg{path: path} }
+	}
+
+	page := m.pageSize()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+page {
+		m.offset = m.cursor - page + 1
+	}
+	return m, nil
+}
+
+func (m BrowserModel) pageSize() int {
+	return pageSize(m.height, 14)
+}
+
+// View renders the browser.
+func (m BrowserModel) View() string {
+	return tmutil.ToASCII(tmutil.Redact(m.render()))
+}
+
+func (m BrowserModel) render() string {
+	body := m.renderBody()
+	help := "↑/↓: navigate • enter: open • backspace: up • r: restore • s: sort • .: hidden files • esc: back • " + bindings.help(actQuit) + ": quit"
+	if len(m.stack) == 0 {
+		help = "↑/↓: navigate • enter: open backup • r: reload • esc: back • " + bindings.help(actQuit) + ": quit"
+	}
+
+	if m.altScreen {
+		var b strings.Builder
+		content := lipgloss.JoinVertical(lipgloss.Center, "Browse Backups", m.version)
+		b.WriteString(headerStyle(m.height).Render(content))
+		b.WriteString("\n\n")
+		b.WriteString(frameStyle(m.height).Render(body))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render(help))
+		return place(m.width, m.height, b.String())
+	}
+
+	return body + "\n\n" + help
+}
+
+func (m BrowserModel) renderBody() string {
+	if m.loading {
+		return "Loading backups..."
+	}
+
+	var b strings.Builder
+	if len(m.stack) == 0 {
+		b.WriteString("Backups\n")
+	} else {
+		fmt.Fprintf(&b, "%s  %s\n", m.current(), helpStyle.Render("(by "+string(m.order)+")"))
+	}
+	b.WriteString(tmutil.Rule(60) + "\n")
+
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		return b.String()
+	}
+	if m.count() == 0 {
+		if len(m.stack) == 0 {
+			b.WriteString("No backups found.")
+		} else if m.hidden > 0 {
+			fmt.Fprintf(&b, "(only %d hidden item(s); press . to show them)", m.hidden)
+		} else {
+			b.WriteString("(empty directory)")
+		}
+		return b.String()
+	}
+
+	end := m.offset + m.pageSize()
+	if end > m.count() {
+		end = m.count()
+	}
+	for i := m.offset; i < end; i++ {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		if len(m.stack) == 0 {
+			p := m.backups[i]
+			date := ""
+			if t, err := tmutil.BackupDate(p); err == nil {
+				date = tmutil.FormatTime(t)
+			}
+			fmt.Fprintf(&b, "%s%-19s  %s\n", prefix, date, filepath.Base(p))
+			continue
+		}
+		e := m.entries[i]
+		switch {
+		case e.Dir:
+			fmt.Fprintf(&b, "%s%-40s  %s\n", prefix, e.Name+"/", "<dir>")
+		case e.Size < 0:
+			fmt.Fprintf(&b, "%s%-40s  %s\n", prefix, e.Name, "?")
+		default:
+			fmt.Fprintf(&b, "%s%-40s  %s\n", prefix, e.Name, tmutil.FormatBytesInt64(e.Size))
+		}
+	}
+	fmt.Fprintf(&b, "\n%d item(s)", m.count())
+	if m.hidden > 0 {
+		fmt.Fprintf(&b, ", %d hidden", m.hidden)
+	}
+	return b.String()
+}
+
+// reverseInPlace reverses a string slice in place.
+func reverseInPlace(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,85 @@
+//
+// busy.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"strings"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// busyRun is a command held back because a backup started, e.g. on
+// schedule, before it ran.
+type busyRun struct {
+	cmd  Command
+	args []string
+	info tmutil.StatusInfo
+	back viewState // where esc returns
+}
+
+// checkBusy holds back a command with WhenRunning while a backup is
+// running, offering the monitor instead. It reports whether it did.
+// A ReadOnly command is never held.
+func (m Model) checkBusy(cmd Command, args []string) (Model, bool) {
+	if cmd.WhenRunning == "" || cmd.ReadOnly {
+		return m, false
+	}
+	info, running := tmutil.BackupRunning()
+	if !running {
+		return m, false
+	}
+	back := commandView
+	switch {
+	case m.view == inputView || m.view == guideView && m.guide.fromForm:
+		back = inputView
+	case m.view == guideView && m.guide.fromSaved:
+		back = savedView
+	}
+	m.busy = busyRun{cmd: cmd, args: args, info: info, back: back}
+	m.view = busyView
+	return m, true
+}
+
+func (m Model) updateBusy(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch k := msg.String(); {
+	case k == "m", bindings.is(k, actSelect):
+		m.monitor = NewMonitorModel(m.version, true)
+		m.view = monitorView
+		return m, m.monitor.Init()
+	case k == "a":
+		return m.launch(m.busy.cmd, m.busy.args)
+	case k == "esc", bindings.is(k, actBack):
+		m.view = m.busy.back
+		if m.view == inputView {
+			return m, m.input.Init()
+		}
+		return m, nil
+	case k == "ctrl+c", bindings.is(k, actQuit):
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) renderBusy() string {
+	var b strings.Builder
+	b.WriteString(m.renderTitle(m.busy.cmd.Title))
+	b.WriteString("\n\n")
+
+	body := "A backup is already running: " + tmutil.StopProgress(m.busy.info) + ".\n\n" +
+		wordWrap(m.busy.cmd.WhenRunning+" Watch the running backup in the monitor instead?", 60)
+	b.WriteString(frameStyle(m.height).Render(body))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(bindings.key(actSelect) + "/m: open monitor • a: " + strings.ToLower(m.busy.cmd.Title) + " anyway • " + bindings.backQuitHelp()))
+
+	return place(m.width, m.height, b.String())
+}
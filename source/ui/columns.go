@@ -0,0 +1,95 @@
+//
+// columns.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxMenuColumns = 3
+	minMenuRows    = 4 // don't split menus shorter than this
+	menuColumnGap  = 4
+	menuChrome     = 12 // frame border, padding and margin around a menu
+)
+
+// menuColumns returns how many columns a menu of count items, each up to
+// itemWidth wide, is laid out in at the current terminal width, and the
+// number of rows per column. Narrow terminals and short menus get one
+// column.
+func (m Model) menuColumns(count, itemWidth int) (cols, rows int) {
+	cols = (m.width - menuChrome + menuColumnGap) / (itemWidth + menuColumnGap)
+	if cols > maxMenuColumns {
+		cols = maxMenuColumns
+	}
+	for cols > 1 && (count+cols-1)/cols < minMenuRows {
+		cols--
+	}
+	if cols < 1 {
+		cols = 1
+	}
+	return cols, (count + cols - 1) / cols
+}
+
+// layoutColumns arranges lines column-major (down, then across) into
+// columns of rows lines, each padded to width.
+func layoutColumns(lines []string, rows, width int) string {
+	if rows <= 0 || len(lines) <= rows {
+		return strings.Join(lines, "\n") + "\n"
+	}
+	var b strings.Builder
+	for r := 0; r < rows; r++ {
+		for i := r; i < len(lines); i += rows {
+			line := lines[i]
+			if i+rows < len(lines) {
+				line += strings.Repeat(" ", max(0, width+menuColumnGap-lipgloss.Width(line)))
+			}
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// moveAcross moves cursor one column left (dir -1) or right (dir 1) within
+// the first count items of a menu with rows rows per column. Cursors on the
+// trailing fixed items (Back, Quit, ...) don't move.
+func moveAcross(cursor, count, rows, dir int) int {
+	if cursor >= count {
+		return cursor
+	}
+	if next := cursor + dir*rows; next >= 0 && next < count {
+		return next
+	}
+	return cursor
+}
+
+// categoryMenuWidth is the width of the widest category menu line.
+func (m Model) categoryMenuWidth() int {
+	w := 0
+	for _, cat := range m.categories {
+		w = max(w, len(fmt.Sprintf("  [%s] %s", cat.Hotkey, cat.Title)))
+	}
+	return w
+}
+
+// commandMenuWidth is the width of the widest command menu line in cat,
+// including room for the root marker.
+func commandMenuWidth(cat Category) int {
+	w := len("  [" + bindings.key(actBack) + "] Back")
+	for _, cmd := range cat.Commands {
+		w = max(w, len(fmt.Sprintf("  [%s] %s", cmd.Hotkey, cmd.Title)))
+	}
+	return w + 2
+}
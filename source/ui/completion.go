@@ -0,0 +1,110 @@
+//
+// completion.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"tmcli/tmutil"
+)
+
+// CompletionScript returns a shell completion script for bash or zsh.
+// Subcommands are completed statically; arguments are completed by calling
+// back into `tmcli __complete <command> <arg index>` so destination IDs and
+// mount points reflect the live system.
+func CompletionScript(shell string) (string, error) {
+	var ids []string
+	for _, cmd := range AllCommands() {
+		ids = append(ids, cmd.ID)
+		ids = append(ids, cmd.Aliases...)
+	}
+	words := strings.Join(append([]string{"tui", "help", "completion"}, ids...), " ")
+
+	switch shell {
+	case "bash":
+		return fmt.Sprintf(bashCompletion, words), nil
+	case "zsh":
+		return fmt.Sprintf(zshCompletion, words), nil
+	}
+	return "", fmt.Errorf("unsupported shell %q (expected bash or zsh)", shell)
+}
+
+const bashCompletion = `# tmcli bash completion
+# Install: tmcli completion bash > /usr/local/etc/bash_completion.d/tmcli
+_tmcli() {
+    local cur=${COMP_WORDS[COMP_CWORD]}
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=( $(compgen -W "%s" -- "$cur") )
+        return
+    fi
+    local IFS=$'\n'
+    COMPREPLY=( $(compgen -W "$(tmcli __complete "${COMP_WORDS[1]}" $((COMP_CWORD - 2)) 2>/dev/null)" -- "$cur") )
+    if [ ${#COMPREPLY[@]} -eq 0 ]; then
+        COMPREPLY=( $(compgen -f -- "$cur") )
+    fi
+}
+complete -F _tmcli tmcli
+`
+
+const zshCompletion = `#compdef tmcli
+# tmcli zsh completion
+# Install: tmcli completion zsh > "${fpath[1]}/_tmcli"
+_tmcli() {
+    if (( CURRENT == 2 )); then
+        compadd -- %s
+        return
+    fi
+    local -a cands
+    cands=("${(@f)$(tmcli __complete ${words[2]} $((CURRENT - 3)) 2>/dev/null)}")
+    if [[ -n ${cands[1]} ]]; then
+        compadd -- $cands
+    else
+        _files
+    fi
+}
+compdef _tmcli tmcli
+`
+
+// CompletionCandidates returns completion candidates for the argument at
+// argIndex of the command with the given ID. It returns nil when the
+// argument has no dynamic source, letting the shell fall back to files.
+func CompletionCandidates(id string, argIndex int) []string {
+	cmd := FindCommand(id)
+	if cmd == nil || argIndex < 0 || argIndex >= len(cmd.Inputs) {
+		return nil
+	}
+	if inp := cmd.Inputs[argIndex]; inp.Toggle {
+		return []string{inp.Flag}
+	}
+	if choices := cmd.Inputs[argIndex].Choices; choices != nil {
+		var values []string
+		for _, c := range choices {
+			if c.Value != "" {
+				values = append(values, c.Value)
+			}
+		}
+		return values
+	}
+	switch cmd.Inputs[argIndex].Complete {
+	case completeDestinationID:
+		ids, err := tmutil.DestinationIDs()
+		if err != nil {
+			return nil
+		}
+		return ids
+	case completeMountPoint:
+		vols, _ := filepath.Glob("/Volumes/*")
+		return append([]string{"/"}, vols...)
+	}
+	return nil
+}
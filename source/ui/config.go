@@ -0,0 +1,459 @@
+//
+// config.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"tmcli/tmutil"
+)
+
+// Config holds user settings read from config.json in the config
+// directory. Every field is optional.
+type Config struct {
+	TimeFormat string `json:"timeFormat,omitempty"` // named format (default, iso, us, eu, rfc1123) or a Go layout
+	Clock      string `json:"clock,omitempty"`      // "12h" or "24h" (default)
+
+	// RelativeTimes shows timestamps as "2h ago"; toggled with t in the TUI.
+	RelativeTimes bool `json:"relativeTimes,omitempty"`
+
+	// Keys rebinds TUI actions, e.g. {"quit": ["x"], "back": ["esc"]}.
+	Keys map[string][]string `json:"keys,omitempty"`
+
+	// Log sets how much of the command log is kept.
+	Log *LogConfig `json:"log,omitempty"`
+
+	// SortMenus lists categories and commands alphabetically by title
+	// instead of in their curated groups; --sort does the same.
+	SortMenus bool `json:"sortMenus,omitempty"`
+
+	// Dashboard opens the TUI on the dashboard instead of the main menu.
+	Dashboard bool `json:"dashboard,omitempty"`
+
+	// Timeouts sets the time limit of commands by name, e.g.
+	// {"verifychecksums": "2h", "status": "10s"}; "0" removes a limit.
+	Timeouts map[string]string `json:"timeouts,omitempty"`
+
+	// SnapshotHooks are shell commands run before and after localsnapshot.
+	// They run arbitrary commands, as root under sudo, so they are ignored
+	// when others can write config.json.
+	SnapshotHooks *tmutil.SnapshotHooks `json:"snapshotHooks,omitempty"`
+}
+
+func configPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// LoadConfig reads config.json; a missing file yields the defaults.
+// TMCLI_TIME_FORMAT and TMCLI_CLOCK override the file.
+func LoadConfig() (Config, error) {
+	cfg, err := readConfig()
+	if err != nil {
+		return cfg, err
+	}
+	if v := os.Getenv("TMCLI_TIME_FORMAT"); v != "" {
+		cfg.TimeFormat = v
+	}
+	if v := os.Getenv("TMCLI_CLOCK"); v != "" {
+		cfg.Clock = v
+	}
+	return cfg, nil
+}
+
+// readConfig reads config.json alone, without environment overrides.
+func readConfig() (Config, error) {
+	var cfg Config
+	path, err := configPath()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// saveRelativeTimes records the relative-times preference in config.json,
+// keeping the file's other settings.
+func saveRelativeTimes(on bool) error {
+	cfg, err := readConfig()
+	if err != nil {
+		return err
+	}
+	cfg.RelativeTimes = on
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// checkHookConfig refuses hooks from a config.json that anyone but its
+// owner can change, since they run as whoever runs tmcli.
+func checkHookConfig() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm()&0o022 != 0 {
+		return fmt.Errorf("snapshot hooks: %s is writable by others; run chmod go-w on it to use them", path)
+	}
+	return nil
+}
+
+// ApplyConfig loads the configuration and applies it to the formatters.
+func ApplyConfig() error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	var clock12 bool
+	switch strings.ToLower(cfg.Clock) {
+	case "", "24h", "24":
+	case "12h", "12":
+		clock12 = true
+	default:
+		return fmt.Errorf("invalid clock %q: expected 12h or 24h", cfg.Clock)
+	}
+	km, err := newKeyMap(cfg.Keys)
+	if err != nil {
+		return err
+	}
+	bindings = km
+	tmutil.SetRelativeTimes(cfg.RelativeTimes)
+	if cfg.SortMenus {
+		SetSortMenus(true)
+	}
+	SetStartDashboard(cfg.Dashboard)
+	if err := setTimeouts(cfg.Timeouts); err != nil {
+		return err
+	}
+	if err := tmutil.SetTimeFormat(cfg.TimeFormat, clock12); err != nil {
+		return err
+	}
+	patterns, err := loadIgnorePatterns()
+	if err != nil {
+		return err
+	}
+	tmutil.SetIgnorePatterns(patterns)
+	if cfg.SnapshotHooks != nil {
+		if err := checkHookConfig(); err != nil {
+			return err
+		}
+		tmutil.SetSnapshotHooks(*cfg.SnapshotHooks)
+	}
+	return nil
+}
+
+// configSetting is one line of tmcli config: a resolved setting and where
+// its value came from.
+type configSetting struct {
+	name, value, source string
+}
+
+// ConfigReport lists the effective configuration and the source of each
+// value: the default, config.json, an environment variable, a flag among
+// the global flags given (e.g. "--sort"), or the terminal. A config.json
+// that cannot be read is reported, and the defaults are shown instead.
+func ConfigReport(flags []string) (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	file := path
+	cfg, err := readConfig()
+	if err != nil {
+		file += "  (ignored: " + err.Error() + ")"
+	} else if _, err := os.Stat(path); err != nil {
+		file += "  (not found; defaults apply)"
+	}
+	inFile := func(set bool) string {
+		if set {
+			return "config.json"
+		}
+		return "default"
+	}
+	onOff := func(on bool) string {
+		if on {
+			return "on"
+		}
+		return "off"
+	}
+	given := func(flag string) bool { return slices.Contains(flags, flag) }
+
+	var s []configSetting
+	timeFormat := configSetting{"timeFormat", "default", inFile(cfg.TimeFormat != "")}
+	if cfg.TimeFormat != "" {
+		timeFormat.value = cfg.TimeFormat
+	}
+	if v := os.Getenv("TMCLI_TIME_FORMAT"); v != "" {
+		timeFormat.value, timeFormat.source = v, "TMCLI_TIME_FORMAT"
+	}
+	clock := configSetting{"clock", "24h", inFile(cfg.Clock != "")}
+	if cfg.Clock != "" {
+		clock.value = cfg.Clock
+	}
+	if v := os.Getenv("TMCLI_CLOCK"); v != "" {
+		clock.value, clock.source = v, "TMCLI_CLOCK"
+	}
+	s = append(s, timeFormat, clock,
+		configSetting{"relativeTimes", onOff(cfg.RelativeTimes), inFile(cfg.RelativeTimes)})
+
+	sortSetting := configSetting{"sortMenus", onOff(cfg.SortMenus), inFile(cfg.SortMenus)}
+	if given("--sort") {
+		sortSetting.value, sortSetting.source = "on", "--sort"
+	}
+	keys := configSetting{"keys", "defaults", "default"}
+	if len(cfg.Keys) > 0 {
+		names := make([]string, 0, len(cfg.Keys))
+		for a := range cfg.Keys {
+			names = append(names, a)
+		}
+		slices.Sort(names)
+		keys.value, keys.source = "rebound: "+strings.Join(names, ", "), "config.json"
+	}
+	s = append(s, sortSetting,
+		configSetting{"dashboard", onOff(cfg.Dashboard), inFile(cfg.Dashboard)}, keys)
+
+	logSetting := configSetting{"log", "off", "default"}
+	switch {
+	case given("--log"):
+		logSetting.value, logSetting.source = "on", "--log"
+	case os.Getenv("TMCLI_LOG") != "":
+		logSetting.value, logSetting.source = "on", "TMCLI_LOG"
+	}
+	s = append(s, logSetting)
+	if r, err := cfg.Log.retention(); err == nil {
+		age := "none"
+		if r.MaxAge > 0 {
+			age = cfg.Log.MaxAge
+		}
+		set := cfg.Log != nil
+		s = append(s,
+			configSetting{"log.maxSizeMB", fmt.Sprint(r.MaxBytes >> 20), inFile(set && cfg.Log.MaxSizeMB > 0)},
+			configSetting{"log.maxAge", age, inFile(set && cfg.Log.MaxAge != "")},
+			configSetting{"log.keep", fmt.Sprint(r.Keep), inFile(set && cfg.Log.Keep > 0)})
+	} else {
+		s = append(s, configSetting{"log.*", "ignored: " + err.Error(), "config.json"})
+	}
+
+	compact := configSetting{"compact", fmt.Sprintf("below %d lines", CompactHeight), "default"}
+	if given("--compact") {
+		compact.value, compact.source = "always", "--compact"
+	}
+	ascii := configSetting{"ascii", onOff(tmutil.ASCII()), "default"}
+	switch {
+	case given("--ascii"):
+		ascii.source = "--ascii"
+	case os.Getenv("TMCLI_ASCII") == "1" || os.Getenv("TMCLI_ASCII") == "0":
+		ascii.source = "TMCLI_ASCII"
+	case tmutil.DetectASCII():
+		ascii.source = "terminal"
+	}
+	redact := configSetting{"redact", onOff(tmutil.Redacting()), "default"}
+	switch {
+	case given("--redact"):
+		redact.source = "--redact"
+	case os.Getenv("TMCLI_REDACT") == "1":
+		redact.source = "TMCLI_REDACT"
+	}
+	lang := configSetting{"language", tmutil.LocaleFromEnv(), "default"}
+	for _, key := range []string{"TMCLI_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); v != "" && v != "C" && v != "POSIX" {
+			lang.source = key
+			break
+		}
+	}
+	pre := configSetting{"snapshotHooks.pre", "none", "default"}
+	post := configSetting{"snapshotHooks.post", "none", "default"}
+	if h := cfg.SnapshotHooks; h != nil {
+		disabled := ""
+		if checkHookConfig() != nil {
+			disabled = " (disabled: file writable by others)"
+		}
+		if h.Pre != "" {
+			pre.value, pre.source = h.Pre, "config.json"+disabled
+		}
+		if h.Post != "" {
+			post.value, post.source = h.Post, "config.json"+disabled
+		}
+	}
+	s = append(s, pre, post)
+	tmutilBin := configSetting{"tmutil", "tmutil", "default"}
+	if v := os.Getenv("TMCLI_TMUTIL"); v != "" {
+		tmutilBin.value, tmutilBin.source = v, "TMCLI_TMUTIL"
+	}
+	s = append(s, compact, ascii, redact, lang, tmutilBin)
+
+	var b strings.Builder
+	b.WriteString("tmcli Configuration\n")
+	b.WriteString(tmutil.Rule(60) + "\n\n")
+	fmt.Fprintf(&b, "Config file: %s\n\n", file)
+	fmt.Fprintf(&b, "  %-18s %-24s %s\n", "Setting", "Value", "Source")
+	for _, c := range s {
+		fmt.Fprintf(&b, "  %-18s %-24s %s\n", c.name, c.value, c.source)
+	}
+
+	b.WriteString("\nTime limits:\n")
+	for _, cmd := range AllCommands() {
+		_, set := timeouts[cmd.ID]
+		if !set && cmd.Timeout == 0 {
+			continue
+		}
+		limit, source := "none", "default"
+		if cmd.Timeout > 0 {
+			limit = tmutil.FormatDuration(cmd.Timeout)
+		}
+		if set {
+			source = "config.json"
+		}
+		fmt.Fprintf(&b, "  %-18s %-24s %s\n", cmd.ID, limit, source)
+	}
+
+	dir := filepath.Dir(path)
+	b.WriteString("\nFiles:\n")
+	for _, f := range [][2]string{
+		{"Saved commands", "saved.json"},
+		{"Input history", "history.json"},
+		{"Command log", "tmcli.log"},
+	} {
+		fmt.Fprintf(&b, "  %-18s %s\n", f[0], filepath.Join(dir, f[1]))
+	}
+	for _, f := range ignoreFiles() {
+		fmt.Fprintf(&b, "  %-18s %s\n", "Ignore patterns", f)
+	}
+	b.WriteString("\nEdit the file with 'tmcli config edit'.")
+	return b.String(), nil
+}
+
+// EditConfig opens config.json in $VISUAL or $EDITOR (vi by default),
+// creating an empty one first if there is none, and checks that the
+// result still parses.
+func EditConfig() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(path, []byte("{}\n"), 0o644); err != nil {
+			return err
+		}
+	}
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// The editor may carry flags, e.g. "code --wait".
+	argv := append(strings.Fields(editor), path)
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", editor, err)
+	}
+	if _, err := readConfig(); err != nil {
+		return fmt.Errorf("%w; tmcli ignores the file until this is fixed", err)
+	}
+	return nil
+}
+
+// timeouts are the time limits from config.json, by command ID; 0 means
+// none. They replace the commands' built-in Timeout.
+var timeouts map[string]time.Duration
+
+// setTimeouts reads the "timeouts" object of config.json: a time limit per
+// command name or alias, such as "2h" or "30m", or "0" for none.
+func setTimeouts(cfg map[string]string) error {
+	parsed := make(map[string]time.Duration, len(cfg))
+	for name, v := range cfg {
+		cmd := FindCommand(name)
+		if cmd == nil {
+			return fmt.Errorf("timeouts: unknown command %q", name)
+		}
+		if v == "0" {
+			parsed[cmd.ID] = 0
+			continue
+		}
+		d, err := tmutil.ParseAge(v)
+		if err != nil {
+			return fmt.Errorf("timeouts: %s: invalid time limit %q: expected a duration such as 30m or 2h, or 0 for none", name, v)
+		}
+		parsed[cmd.ID] = d
+	}
+	timeouts = parsed
+	return nil
+}
+
+// ignoreFileName is the file of patterns findfile and backup listings
+// leave out, in the style of .gitignore.
+const ignoreFileName = ".tmcliignore"
+
+// ignoreFiles returns the ignore files that exist: the one in the
+// configuration directory, then the one in the working directory.
+func ignoreFiles() []string {
+	var files []string
+	if dir, err := configDir(); err == nil {
+		files = append(files, filepath.Join(dir, ignoreFileName))
+	}
+	if wd, err := os.Getwd(); err == nil {
+		files = append(files, filepath.Join(wd, ignoreFileName))
+	}
+	var found []string
+	for _, f := range files {
+		if _, err := os.Stat(f); err == nil && !slices.Contains(found, f) {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+// loadIgnorePatterns reads the lines of the ignore files, the working
+// directory's last so that its patterns win.
+func loadIgnorePatterns() ([]string, error) {
+	var lines []string
+	for _, f := range ignoreFiles() {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, strings.Split(string(data), "\n")...)
+	}
+	return lines, nil
+}
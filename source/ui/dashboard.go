@@ -0,0 +1,207 @@
+//
+// dashboard.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dashboardInterval is how often the dashboard reads the state again. Like
+// the status bar it is slow on purpose: the dashboard is a glance, the
+// monitor is live.
+const dashboardInterval = 10 * time.Second
+
+// startDashboard opens the TUI on the dashboard instead of the main menu;
+// set by dashboard in config.json.
+var startDashboard bool
+
+// SetStartDashboard makes the TUI open on the dashboard.
+func SetStartDashboard(on bool) {
+	startDashboard = on
+}
+
+// dashboardState is what the dashboard shows, read in one go.
+type dashboardState struct {
+	overview  tmutil.Overview
+	health    tmutil.BackupHealth
+	reachable bool
+	why       string // the reachability detail
+	at        time.Time
+}
+
+// dashboardMsg carries a fresh dashboardState; gen drops reads from before
+// the dashboard was last opened or refreshed.
+type dashboardMsg struct {
+	gen   int
+	state dashboardState
+}
+
+// dashboardTickMsg triggers the next dashboard read.
+type dashboardTickMsg struct {
+	gen int
+}
+
+// loadDashboard reads the state through the structured getters.
+func loadDashboard(gen int) tea.Cmd {
+	return func() tea.Msg {
+		s := dashboardState{
+			overview: tmutil.GetOverview(),
+			health:   tmutil.GetBackupHealth(0, 0),
+			at:       time.Now(),
+		}
+		s.reachable, s.why = tmutil.DestinationReachable()
+		return dashboardMsg{gen: gen, state: s}
+	}
+}
+
+// openDashboard shows the dashboard and starts reading the state.
+func (m Model) openDashboard() (Model, tea.Cmd) {
+	m.view = dashboardView
+	m.dashboardGen++
+	return m, loadDashboard(m.dashboardGen)
+}
+
+func (m Model) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dashboardMsg:
+		if msg.gen != m.dashboardGen {
+			return m, nil
+		}
+		m.dashboard, m.dashboardLoaded = msg.state, true
+		gen := m.dashboardGen
+		return m, tea.Tick(dashboardInterval, func(time.Time) tea.Msg { return dashboardTickMsg{gen: gen} })
+
+	case dashboardTickMsg:
+		if msg.gen != m.dashboardGen {
+			return m, nil
+		}
+		return m, loadDashboard(m.dashboardGen)
+
+	case tea.KeyMsg:
+		switch k := msg.String(); {
+		case k == "m", bindings.is(k, actSelect):
+			m.dashboardGen++ // stop refreshing while the monitor is open
+			m.monitor = NewMonitorModel(m.version, true)
+			m.monitorFromDashboard = true
+			m.view = monitorView
+			return m, m.monitor.Init()
+		case bindings.is(k, actRefresh):
+			return m.openDashboard()
+		case bindings.is(k, actBack):
+			m.dashboardGen++
+			m.view = categoryView
+			return m, nil
+		case k == "ctrl+c", bindings.is(k, actQuit):
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderDashboard() string {
+	var b strings.Builder
+	b.WriteString(m.renderTitle(tmutil.T("dashboard.title")))
+	b.WriteString("\n\n")
+
+	var body string
+	if !m.dashboardLoaded {
+		body = "Reading the Time Machine state…"
+	} else {
+		body = dashboardBody(m.dashboard)
+	}
+	b.WriteString(frameStyle(m.height).Render(body))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(bindings.key(actSelect) + "/m: open monitor • " + bindings.help(actRefresh) + ": refresh • " + bindings.backQuitHelp()))
+
+	return place(m.width, m.height, b.String())
+}
+
+// dashboardBody lays out the state as label and value rows.
+func dashboardBody(s dashboardState) string {
+	o := s.overview
+	var b strings.Builder
+	row := func(id, value string) {
+		b.WriteString(monitorLabelStyle.Render(tmutil.Label(id, 13)) + value + "\n")
+	}
+	// more continues the row above on the next line.
+	more := func(value string) {
+		b.WriteString(strings.Repeat(" ", 13) + value + "\n")
+	}
+
+	switch {
+	case o.StatusErr != nil:
+		msg, _, _ := strings.Cut(o.StatusErr.Error(), "\n")
+		row("status.state", errorStyle.Render("unknown: "+msg))
+	case o.Status.Running && o.Status.HasProgress && o.Status.Percent > 0:
+		row("status.state", fmt.Sprintf("Backup running %.0f%%, %s", o.Status.Percent*100, o.Status.Phase))
+	case o.Status.Running:
+		row("status.state", "Backup running, "+tmutil.DescribePhase(o.Status.Phase))
+	default:
+		row("status.state", tmutil.T("status.idle"))
+	}
+
+	if o.DestinationErr != nil || o.Destination.Name == "" {
+		row("status.destination", "none configured")
+	} else {
+		dest := o.Destination.Name
+		if o.Destination.Kind != "" {
+			dest += " (" + o.Destination.Kind + ")"
+		}
+		row("status.destination", dest)
+		reach := tmutil.T("common.yes")
+		if !s.reachable {
+			reach = tmutil.T("common.no")
+		}
+		row("status.reachable", reach+" ("+s.why+")")
+	}
+
+	if last := o.LastBackup(); last.IsZero() {
+		row("status.last_backup", "never")
+	} else {
+		row("status.last_backup", tmutil.FormatRelative(last, s.at)+" ("+tmutil.FormatTimeShort(last.Local())+")")
+	}
+	if n := o.BackupCount(); n > 0 {
+		row("dashboard.backups", fmt.Sprint(n))
+	}
+
+	used, avail := o.Prefs.BytesUsed, o.Prefs.BytesAvailable
+	if o.PrefsErr == nil && used > 0 && avail > 0 {
+		total := used + avail
+		row("status.disk_usage", fmt.Sprintf("%s %3.0f%%", renderProgressBar(float64(used)/float64(total)), 100*float64(used)/float64(total)))
+		more(fmt.Sprintf("%s used, %s free of %s", tmutil.FormatBytesInt64(used), tmutil.FormatBytesInt64(avail), tmutil.FormatBytesInt64(total)))
+	} else {
+		row("status.disk_usage", tmutil.T("common.unknown"))
+	}
+
+	b.WriteString("\n")
+	switch s.health.Status {
+	case tmutil.CheckPass:
+		row("dashboard.health", successStyle.Render("● OK"))
+	case tmutil.CheckWarn:
+		row("dashboard.health", warnStyle.Render("● STALE"))
+	default:
+		row("dashboard.health", errorStyle.Render("● FAILING"))
+	}
+	if s.health.Status != tmutil.CheckPass {
+		for _, line := range strings.Split(wordWrap(s.health.Detail, 50), "\n") {
+			more(line)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n"+tmutil.T("dashboard.updated"), tmutil.FormatTimeShort(s.at), tmutil.FormatDuration(dashboardInterval))
+	return b.String()
+}
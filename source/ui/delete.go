@@ -0,0 +1,321 @@
+//
+// delete.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type deleteState int
+
+const (
+	deleteLoading deleteState = iota
+	deleteList
+	deleteConfirm
+	deleteRunning
+	deleteDone
+)
+
+type backupListMsg struct {
+	paths []string
+	err   error
+}
+
+type uniqueSizeMsg struct {
+	path string
+	size string
+	err  error
+}
+
+type deleteResultMsg struct {
+	output string
+	err    error
+}
+
+// deleteExitMsg signals that the user backed out of the delete browser.
+type deleteExitMsg struct{}
+
+func loadBackups() tea.Msg {
+	paths, err := tmutil.BackupPaths()
+	return backupListMsg{paths: paths, err: err}
+}
+
+func computeUniqueSize(path string) tea.Cmd {
+	return func() tea.Msg {
+		size, err := tmutil.UniqueSizeOf(path)
+		return uniqueSizeMsg{path: path, size: size, err: err}
+	}
+}
+
+// DeleteModel lists completed backups with their unique sizes and deletes
+// the selected one (via `tmutil delete -p path`) after confirmation.
+// It can be used standalone (CLI) or embedded in the TUI.
+type DeleteModel struct {
+	version   string
+	state     deleteState
+	paths     []string          // newest first
+	sizes     map[string]string // unique size by path, filled in lazily
+	sizing    bool              // a uniquesize call is in flight
+	cursor    int
+	offset    int
+	output    string
+	err       error
+	width     int
+	height    int
+	altScreen bool // true when embedded in the full TUI
+}
+
+// NewDeleteModel creates a delete-by-size browser.
+func NewDeleteModel(version string, altScreen bool) DeleteModel {
+	return DeleteModel{
+		version:   version,
+		sizes:     make(map[string]string),
+		altScreen: altScreen,
+	}
+}
+
+// Init loads the backup list.
+func (m DeleteModel) Init() tea.Cmd {
+	return loadBackups
+}
+
+// nextSize starts computing the unique size of the highlighted backup, or
+// of the next backup that has none yet, so sizes fill in while browsing.
+func (m DeleteModel) nextSize() (DeleteModel, tea.Cmd) {
+	if m.sizing || len(m.paths) == 0 {
+		return m, nil
+	}
+	target := ""
+	if _, ok := m.sizes[m.paths[m.cursor]]; !ok {
+		target = m.paths[m.cursor]
+	} else {
+		for _, p := range m.paths {
+			if _, ok := m.sizes[p]; !ok {
+				target = p
+				break
+			}
+		}
+	}
+	if target == "" {
+		return m, nil
+	}
+	m.sizing = true
+	return m, computeUniqueSize(target)
+}
+
+func (m DeleteModel) exit() tea.Cmd {
+	if m.altScreen {
+		return func() tea.Msg { return deleteExitMsg{} }
+	}
+	return tea.Quit
+}
+
+// Update handles messages.
+func (m DeleteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case backupListMsg:
+		m.err = msg.err
+		m.paths = msg.paths
+		reverseInPlace(m.paths)
+		m.cursor = 0
+		m.offset = 0
+		m.state = deleteList
+		return m.nextSize()
+
+	case uniqueSizeMsg:
+		m.sizing = false
+		if msg.err != nil {
+			m.sizes[msg.path] = "?"
+		} else {
+			m.sizes[msg.path] = msg.size
+		}
+		return m.nextSize()
+
+	case deleteResultMsg:
+		m.output = msg.output
+		m.err = msg.err
+		m.state = deleteDone
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.state {
+		case deleteLoading, deleteRunning:
+			return m, nil
+		case deleteList:
+			return m.updateList(msg)
+		case deleteConfirm:
+			return m.updateConfirm(msg)
+		case deleteDone:
+			if bindings.is(msg.String(), actQuit) {
+				return m, tea.Quit
+			}
+			m.state = deleteLoading
+			m.output = ""
+			m.err = nil
+			m.sizes = make(map[string]string)
+			return m, loadBackups
+		}
+	}
+
+	return m, nil
+}
+
+func (m DeleteModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch k := msg.String(); {
+	case bindings.is(k, actQuit):
+		return m, tea.Quit
+	case bindings.is(k, actBack):
+		return m, m.exit()
+	case bindings.is(k, actRefresh):
+		tmutil.InvalidateBackupCache()
+		m.state = deleteLoading
+		return m, loadBackups
+	case bindings.is(k, actUp):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case bindings.is(k, actDown):
+		if m.cursor < len(m.paths)-1 {
+			m.cursor++
+		}
+	case bindings.is(k, actSelect), k == "x":
+		if len(m.paths) > 0 {
+			m.state = deleteConfirm
+		}
+		return m, nil
+	}
+
+	page := m.pageSize()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+page {
+		m.offset = m.cursor - page + 1
+	}
+	return m.nextSize()
+}
+
+func (m DeleteModel) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch k := msg.String(); {
+	case k == "y", k == "Y":
+		path := m.paths[m.cursor]
+		m.state = deleteRunning
+		return m, func() tea.Msg {
+			output, err := tmutil.Delete([]string{"-p", path})
+			return deleteResultMsg{output: output, err: err}
+		}
+	case k == "n", k == "N", k == "esc", k == "backspace":
+		m.state = deleteList
+	case bindings.is(k, actQuit):
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m DeleteModel) pageSize() int {
+	return pageSize(m.height, 14)
+}
+
+// View renders the delete browser.
+func (m DeleteModel) View() string {
+	return tmutil.ToASCII(tmutil.Redact(m.render()))
+}
+
+func (m DeleteModel) render() string {
+	body, help := m.renderBody()
+
+	if m.altScreen {
+		var b strings.Builder
+		content := lipgloss.JoinVertical(lipgloss.Center, "Delete by Size", m.version)
+		b.WriteString(headerStyle(m.height).Render(content))
+		b.WriteString("\n\n")
+		b.WriteString(frameStyle(m.height).Render(body))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render(help))
+		return place(m.width, m.height, b.String())
+	}
+
+	return body + "\n\n" + help
+}
+
+func (m DeleteModel) sizeOf(path string) string {
+	if size, ok := m.sizes[path]; ok {
+		return size
+	}
+	return "…"
+}
+
+func (m DeleteModel) renderBody() (string, string) {
+	switch m.state {
+	case deleteLoading:
+		return "Loading backups...", bindings.help(actQuit) + ": quit"
+	case deleteRunning:
+		return fmt.Sprintf("Deleting %s...", m.paths[m.cursor]), "please wait"
+	case deleteDone:
+		if m.err != nil {
+			return errorStyle.Render(fmt.Sprintf("Error: %v", m.err)), "any key: back to list • " + bindings.help(actQuit) + ": quit"
+		}
+		return successStyle.Render(m.output), "any key: back to list • " + bindings.help(actQuit) + ": quit"
+	case deleteConfirm:
+		path := m.paths[m.cursor]
+		var b strings.Builder
+		fmt.Fprintf(&b, "Delete this backup?\n\n")
+		fmt.Fprintf(&b, "  Path:   %s\n", path)
+		fmt.Fprintf(&b, "  Size:   %s\n\n", m.sizeOf(path))
+		b.WriteString(errorStyle.Render("This permanently removes the backup and cannot be undone."))
+		return b.String(), "y: delete • n/esc: cancel"
+	}
+
+	if m.err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", m.err)), bindings.backQuitHelp()
+	}
+	if len(m.paths) == 0 {
+		return "No backups found.", bindings.backQuitHelp()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "  %-19s  %8s  %s\n", "Date", "Unique", "Backup")
+	b.WriteString("  " + tmutil.Rule(56) + "\n")
+	end := m.offset + m.pageSize()
+	if end > len(m.paths) {
+		end = len(m.paths)
+	}
+	for i := m.offset; i < end; i++ {
+		p := m.paths[i]
+		date := ""
+		if t, err := tmutil.BackupDate(p); err == nil {
+			date = tmutil.FormatTime(t)
+		}
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%-19s  %8s  %s\n", prefix, date, m.sizeOf(p), filepath.Base(p))
+	}
+	fmt.Fprintf(&b, "\n%d backup(s)", len(m.paths))
+
+	return b.String(), "↑/↓: navigate • enter: delete • " + bindings.help(actRefresh) + ": reload • " + bindings.backQuitHelp()
+}
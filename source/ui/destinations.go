@@ -0,0 +1,38 @@
+//
+// destinations.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"strings"
+
+	"tmcli/tmutil"
+)
+
+// destinationList lists the destinations as tmutil.Destinations does,
+// with the rows of stale destinations in the warning style, so a drive
+// left out of a rotation stands out. Output flags go to Destinations.
+func destinationList(args []string) (string, error) {
+	if len(args) > 0 {
+		return tmutil.Destinations(args)
+	}
+	records, err := tmutil.ListDestinationRecords()
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(tmutil.FormatDestinations(records), "\n")
+	for i, r := range records {
+		// lines[0] is the header.
+		if r.Stale && i+1 < len(lines) {
+			lines[i+1] = warnStyle.Render(lines[i+1])
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
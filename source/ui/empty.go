@@ -0,0 +1,65 @@
+//
+// empty.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"tmcli/tmutil"
+)
+
+// isEmptyOutput reports whether a successful command produced nothing to
+// show: no output at all, or only a heading such as "Snapshots for disk /:".
+func isEmptyOutput(output string) bool {
+	s := strings.TrimSpace(output)
+	return s == "" || (!strings.Contains(s, "\n") && strings.HasSuffix(s, ":"))
+}
+
+// emptyState is the message shown in place of an empty result.
+func (m Model) emptyState() string {
+	msg := "Nothing to show. The command finished without output."
+	if m.lastCmd.Empty != nil {
+		msg = m.lastCmd.Empty(m.lastArgs)
+	}
+	paras := strings.Split(msg, "\n\n")
+	for i, p := range paras {
+		paras[i] = wordWrap(p, 60)
+	}
+	return strings.Join(paras, "\n\n")
+}
+
+// argOr returns args[i], or def when it is missing or blank.
+func argOr(args []string, i int, def string) string {
+	if i < len(args) && strings.TrimSpace(args[i]) != "" {
+		return args[i]
+	}
+	return def
+}
+
+func emptySnapshots(args []string) string {
+	mount := argOr(args, 0, "/")
+	if mount == "all" || (len(args) > 0 && args[0] == "--all") {
+		return "No local snapshots on any mounted volume.\n\nCreate one from Snapshots → Create Snapshot."
+	}
+	return fmt.Sprintf("No local snapshots on %s.\n\nCreate one from Snapshots → Create Snapshot.", mount)
+}
+
+func emptyBackups([]string) string {
+	if warning := tmutil.UnmountedWarning(); warning != "" {
+		return "No completed backups found.\n\n" + warning
+	}
+	return "No completed backups found.\n\nConnect the backup disk, or start a backup from Backup → Start."
+}
+
+func emptyDestinations([]string) string {
+	return "No backup destination is configured.\n\nAdd one from Destinations → Setup Wizard."
+}
@@ -0,0 +1,112 @@
+//
+// feed.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"tmcli/tmutil"
+)
+
+// feedRecord is one line of the machine-readable monitor feed.
+type feedRecord struct {
+	Time           time.Time  `json:"time"`
+	Running        bool       `json:"running"`
+	Phase          string     `json:"phase,omitempty"`
+	Destination    string     `json:"destination,omitempty"`
+	StartedAt      *time.Time `json:"startedAt,omitempty"`
+	Percent        float64    `json:"percent"`
+	TimeRemaining  float64    `json:"timeRemaining"`
+	BytesCopied    int64      `json:"bytesCopied"`
+	TotalBytes     int64      `json:"totalBytes"`
+	FilesCopied    int64      `json:"filesCopied"`
+	TotalFiles     int64      `json:"totalFiles"`
+	BytesPerSecond float64    `json:"bytesPerSecond"`
+	ETA            *time.Time `json:"eta,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// RunMonitorFeed polls backup status every second and writes one JSON
+// object per poll to w, for piping into jq or a log shipper. Each record is
+// written with a single Write so it streams line by line. When wait is
+// true it returns once a backup it saw running has ended, or when none has
+// shown up as running within followStartWait, as a backup started just
+// before is not running yet; otherwise it runs until ctx is cancelled.
+// Cancellation returns nil without writing a record for the interrupted
+// poll.
+func RunMonitorFeed(ctx context.Context, w io.Writer, wait bool) error {
+	enc := json.NewEncoder(redactWriter{w})
+	var prevBytes int64
+	var prevTime time.Time
+	start, seen := time.Now(), false
+
+	for {
+		now := time.Now()
+		info, err := tmutil.GetStatus()
+		if ctx.Err() != nil {
+			return nil
+		}
+		rec := feedRecord{Time: now}
+		if err != nil {
+			rec.Error = err.Error()
+		} else {
+			rec.Running = info.Running
+			rec.Phase = info.Phase
+			rec.Destination = info.Destination
+			if !info.StartedAt.IsZero() {
+				started := info.StartedAt
+				rec.StartedAt = &started
+			}
+			rec.Percent = info.Percent
+			rec.TimeRemaining = info.TimeRemaining
+			rec.BytesCopied = info.BytesCopied
+			rec.TotalBytes = info.TotalBytes
+			rec.FilesCopied = info.FilesCopied
+			rec.TotalFiles = info.TotalFiles
+
+			if !prevTime.IsZero() && info.BytesCopied >= prevBytes {
+				rec.BytesPerSecond = float64(info.BytesCopied-prevBytes) / now.Sub(prevTime).Seconds()
+			}
+			prevBytes = info.BytesCopied
+			prevTime = now
+
+			switch {
+			case info.TimeRemaining > 0:
+				eta := now.Add(time.Duration(info.TimeRemaining) * time.Second)
+				rec.ETA = &eta
+			case rec.BytesPerSecond > 0 && info.TotalBytes > info.BytesCopied:
+				secs := float64(info.TotalBytes-info.BytesCopied) / rec.BytesPerSecond
+				eta := now.Add(time.Duration(secs) * time.Second)
+				rec.ETA = &eta
+			}
+		}
+
+		if encErr := enc.Encode(rec); encErr != nil {
+			return encErr
+		}
+
+		if wait && err == nil {
+			if info.Running {
+				seen = true
+			} else if seen || now.Sub(start) >= followStartWait {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,127 @@
+//
+// follow.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"tmcli/tmutil"
+)
+
+const (
+	// followReportInterval is how often FollowBackup prints progress while
+	// the phase stays the same.
+	followReportInterval = 10 * time.Second
+	// followStartWait is how long FollowBackup waits for a just-started
+	// backup to show up as running before deciding it has already ended.
+	followStartWait = 30 * time.Second
+)
+
+// ErrBackupIncomplete reports that a followed backup ended without adding
+// a new backup.
+var ErrBackupIncomplete = errors.New("backup ended without completing")
+
+// FollowBackup prints the progress of the running backup to w as plain
+// lines until it ends, then a summary: the CLI counterpart of Start &
+// Monitor for scripts and terminals without the TUI. A line is printed on
+// every phase change and every followReportInterval in between. It
+// returns ErrBackupIncomplete when no new backup was completed, and nil
+// when ctx is cancelled.
+func FollowBackup(ctx context.Context, w io.Writer) error {
+	w = redactWriter{w}
+	before, _ := tmutil.LatestBackup()
+	start := time.Now()
+
+	var last tmutil.StatusInfo
+	var lastPhase string
+	var lastReport time.Time
+	seen := false
+	for {
+		info, err := tmutil.GetStatus()
+		if ctx.Err() != nil {
+			return nil
+		}
+		now := time.Now()
+		switch {
+		case err != nil:
+			if now.Sub(lastReport) >= followReportInterval {
+				fmt.Fprintf(w, "%s  status unavailable: %v\n", now.Format("15:04:05"), err)
+				lastReport = now
+			}
+		case info.Running:
+			seen = true
+			last = info
+			if info.Phase != lastPhase || now.Sub(lastReport) >= followReportInterval {
+				fmt.Fprintf(w, "%s  %s\n", now.Format("15:04:05"), followLine(info))
+				lastPhase, lastReport = info.Phase, now
+			}
+		case seen || now.Sub(start) >= followStartWait:
+			return followSummary(w, before, last, now.Sub(start))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// followLine describes a running backup in one line, e.g. "Copying 42%,
+// 1.2 GB of 2.9 GB, 1,204 of 3,010 files, about 6 minutes left".
+func followLine(info tmutil.StatusInfo) string {
+	phase := info.Phase
+	if phase == "" {
+		phase = "Running"
+	}
+	if info.HasProgress && info.Percent > 0 {
+		phase += fmt.Sprintf(" %.0f%%", info.Percent*100)
+	}
+	parts := []string{phase}
+	if info.TotalBytes > 0 {
+		parts = append(parts, tmutil.FormatBytesInt64(info.BytesCopied)+" of "+tmutil.FormatBytesInt64(info.TotalBytes))
+	}
+	if info.TotalFiles > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d files", info.FilesCopied, info.TotalFiles))
+	}
+	if info.TimeRemaining > 0 {
+		parts = append(parts, "about "+tmutil.FormatDuration(time.Duration(info.TimeRemaining)*time.Second)+" left")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// followSummary prints how the followed backup ended; last is the final
+// status seen while it ran.
+func followSummary(w io.Writer, before string, last tmutil.StatusInfo, elapsed time.Duration) error {
+	after, err := tmutil.LatestBackup()
+	if err != nil || strings.TrimSpace(after) == "" || strings.TrimSpace(after) == strings.TrimSpace(before) {
+		fmt.Fprintf(w, "\nBackup ended after %s without a new backup. Check the destination and 'tmcli status'.\n",
+			tmutil.FormatDuration(elapsed.Round(time.Second)))
+		return ErrBackupIncomplete
+	}
+	var b strings.Builder
+	b.WriteString("\nBackup completed")
+	if last.BytesCopied > 0 {
+		fmt.Fprintf(&b, ": %s", tmutil.FormatBytesInt64(last.BytesCopied))
+		if last.FilesCopied > 0 {
+			fmt.Fprintf(&b, " and %d files", last.FilesCopied)
+		}
+		b.WriteString(" copied")
+	}
+	// A backup that was already running started before the follow did.
+	fmt.Fprintf(&b, ", followed for %s.\nLatest backup: %s\n", tmutil.FormatDuration(elapsed.Round(time.Second)), strings.TrimSpace(after))
+	_, err = io.WriteString(w, b.String())
+	return err
+}
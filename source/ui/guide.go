@@ -0,0 +1,529 @@
+//
+// guide.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Guide walks the user through a command's arguments by picking each one
+// from a list instead of typing it. Each step's pick becomes the next
+// argument. A guide without steps only asks its Confirm question: before
+// running a command without inputs, or about the values submitted in the
+// input form of one with them.
+type Guide struct {
+	Steps       []GuideStep
+	Confirm     func(picks []string) string // optional: question asked before running; "" runs without asking
+	ConfirmArgs []string                    // appended to the picks once confirmed, e.g. "--yes"
+	Ready       func(picks []string) bool   // optional: false opens the input form, filled with the picks, to complete them
+}
+
+// GuideStep is one pick of a guided flow.
+type GuideStep struct {
+	Title string
+	Load  func(picks []string) ([]string, error) // candidates, given the earlier picks
+	Label func(item string) string               // optional: how a candidate is shown; the pick is still the item
+}
+
+// guideDoneMsg carries the arguments picked in a guided flow.
+type guideDoneMsg struct {
+	cmd  Command
+	args []string
+}
+
+// guideFormMsg asks for the regular input form instead of the guide.
+type guideFormMsg struct {
+	cmd    Command
+	values []string // pre-filled into the form's fields, in order
+}
+
+// guideExitMsg signals that the user left the guided flow.
+type guideExitMsg struct{}
+
+// guideQuestionMsg carries the Confirm question, which is worked out in the
+// background since it may measure what the command would change.
+type guideQuestionMsg struct {
+	question string
+}
+
+// confirmsForm reports whether cmd's guide only confirms the values of its
+// input form.
+func (c Command) confirmsForm() bool {
+	return c.Guide != nil && len(c.Guide.Steps) == 0 && c.Guide.Confirm != nil && len(c.Inputs) > 0
+}
+
+// GuideModel runs a command's Guide.
+type GuideModel struct {
+	cmd        Command
+	step       int
+	picks      []string
+	items      []string
+	labels     []string // how items are shown
+	err        error
+	cursor     int
+	confirming bool
+	question   string // the Confirm question, asked once per confirmation; "" while it is worked out
+	fromForm   bool   // confirming the input form's values; going back returns to the form
+	fromSaved  bool   // confirming a saved command; going back returns to the saved list
+	note       string // one-line feedback shown above the help line
+	width      int
+	height     int
+}
+
+// NewGuideModel starts cmd's guide at its first step, or at its question
+// when it has no steps.
+func NewGuideModel(cmd Command) (GuideModel, tea.Cmd) {
+	m := GuideModel{cmd: cmd}
+	if len(cmd.Guide.Steps) == 0 {
+		return m.confirm()
+	}
+	return m.load(), nil
+}
+
+// NewFormConfirm asks cmd's guide question about the arguments submitted
+// in its input form.
+func NewFormConfirm(cmd Command, args []string) (GuideModel, tea.Cmd) {
+	m := GuideModel{cmd: cmd, picks: args, fromForm: true}
+	return m.confirm()
+}
+
+// NewFormReview shows the command line and values submitted in cmd's input
+// form, for ReviewBeforeRun, to run them or go back and edit them. When
+// cmd's guide also confirms the form, its Confirm text, such as a warning,
+// is shown above the review and its ConfirmArgs are kept.
+func NewFormReview(cmd Command, args []string) (GuideModel, tea.Cmd) {
+	review := cmd
+	review.Guide = &Guide{Confirm: func(picks []string) string { return reviewQuestion(cmd, picks) }}
+	if cmd.confirmsForm() {
+		ask := cmd.Guide.Confirm
+		review.Guide = &Guide{ConfirmArgs: cmd.Guide.ConfirmArgs, Confirm: func(picks []string) string {
+			if note := ask(picks); note != "" {
+				return note + "\n\n" + reviewQuestion(cmd, picks)
+			}
+			return reviewQuestion(cmd, picks)
+		}}
+	}
+	return NewFormConfirm(review, args)
+}
+
+// maskArgs returns args with the passwords in URLs masked.
+func maskArgs(args []string) []string {
+	shown := make([]string, len(args))
+	for i, a := range args {
+		shown[i] = a
+		if u, err := url.Parse(a); err == nil && u.User != nil {
+			shown[i] = u.Redacted()
+		}
+	}
+	return shown
+}
+
+// commandLine is the tmcli invocation that runs cmd with args, quoted for
+// the shell and prefixed with sudo when cmd needs root. Passwords in URLs
+// are masked.
+func commandLine(cmd Command, args []string) string {
+	line := []string{"tmcli", cmd.ID}
+	if cmd.RequiresRoot {
+		line = append([]string{"sudo"}, line...)
+	}
+	// Trailing empty fields are simply left off the command line.
+	shown := maskArgs(args)
+	last := len(shown)
+	for last > 0 && shown[last-1] == "" {
+		last--
+	}
+	return tmutil.ShellJoin(append(line, shown[:last]...))
+}
+
+// reviewQuestion lists the command line a form assembled and each field's
+// value. Passwords in URLs are masked.
+func reviewQuestion(cmd Command, args []string) string {
+	shown := maskArgs(args)
+	var fields strings.Builder
+	for i, inp := range cmd.Inputs {
+		if i >= len(shown) {
+			break
+		}
+		v := shown[i]
+		switch {
+		case inp.Toggle && v != "":
+			v = "on"
+		case inp.Toggle:
+			v = "off"
+		case inp.Choices != nil:
+			for _, c := range inp.Choices {
+				if c.Value == v && c.Label != "" {
+					v = c.Label
+				}
+			}
+		case v == "":
+			v = "(none)"
+		}
+		fmt.Fprintf(&fields, "  %-24s %s\n", inp.Label, v)
+	}
+	if cmd.Timeout > 0 {
+		fmt.Fprintf(&fields, "  %-24s %s\n", "Time limit", tmutil.FormatDuration(cmd.Timeout))
+	}
+	return "Review before running:\n\n  " + commandLine(cmd, args) + "\n\n" +
+		strings.TrimSuffix(fields.String(), "\n") + "\n\nRun it? (y/N)"
+}
+
+// confirm asks the guide's question about the picks so far. A ReadOnly
+// command has nothing to confirm and runs at once.
+func (m GuideModel) confirm() (GuideModel, tea.Cmd) {
+	if m.cmd.ReadOnly {
+		return m, m.done()
+	}
+	m.confirming = true
+	m.question = ""
+	ask, picks := m.cmd.Guide.Confirm, m.picks
+	return m, func() tea.Msg { return guideQuestionMsg{question: ask(picks)} }
+}
+
+// done runs the command with the picks and the guide's ConfirmArgs.
+func (m GuideModel) done() tea.Cmd {
+	args := append(append([]string(nil), m.picks...), m.cmd.Guide.ConfirmArgs...)
+	msg := guideDoneMsg{cmd: m.cmd, args: args}
+	return func() tea.Msg { return msg }
+}
+
+// load fetches the candidates for the current step.
+func (m GuideModel) load() GuideModel {
+	step := m.cmd.Guide.Steps[m.step]
+	m.items, m.err = step.Load(m.picks)
+	m.labels = m.items
+	if step.Label != nil {
+		m.labels = make([]string, len(m.items))
+		for i, item := range m.items {
+			m.labels[i] = step.Label(item)
+		}
+	}
+	m.cursor = 0
+	return m
+}
+
+// Update handles key events.
+func (m GuideModel) Update(msg tea.Msg) (GuideModel, tea.Cmd) {
+	if q, ok := msg.(guideQuestionMsg); ok {
+		if !m.confirming {
+			return m, nil
+		}
+		if q.question == "" {
+			return m, m.done()
+		}
+		m.question = q.question
+		return m, nil
+	}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if key.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if m.confirming {
+		if bindings.is(key.String(), actCmdLine) && m.fromForm && m.question != "" {
+			m.note = copyCommandLine(m.cmd, append(append([]string(nil), m.picks...), m.cmd.Guide.ConfirmArgs...))
+			return m, nil
+		}
+		switch key.String() {
+		case "y", "Y":
+			if m.question == "" {
+				return m, nil
+			}
+			return m, m.done()
+		case "n", "N", "esc", "backspace":
+			m.note = ""
+			if len(m.cmd.Guide.Steps) == 0 {
+				return m, func() tea.Msg { return guideExitMsg{} }
+			}
+			m.confirming = false
+			m.picks = m.picks[:len(m.picks)-1]
+		}
+		return m, nil
+	}
+
+	switch k := key.String(); {
+	case k == "esc":
+		return m, func() tea.Msg { return guideExitMsg{} }
+	case bindings.is(k, actBack):
+		if m.step == 0 {
+			return m, func() tea.Msg { return guideExitMsg{} }
+		}
+		m.step--
+		m.picks = m.picks[:m.step]
+		return m.load(), nil
+	case bindings.is(k, actQuit):
+		return m, tea.Quit
+	case bindings.is(k, actUp):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case bindings.is(k, actDown):
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case bindings.is(k, actRefresh):
+		return m.load(), nil
+	case k == "f":
+		cmd := m.cmd
+		return m, func() tea.Msg { return guideFormMsg{cmd: cmd} }
+	case bindings.is(k, actSelect):
+		if len(m.items) == 0 {
+			return m, nil
+		}
+		m.picks = append(m.picks, m.items[m.cursor])
+		if m.step < len(m.cmd.Guide.Steps)-1 {
+			m.step++
+			return m.load(), nil
+		}
+		if ready := m.cmd.Guide.Ready; ready != nil && !ready(m.picks) {
+			msg := guideFormMsg{cmd: m.cmd, values: m.picks}
+			return m, func() tea.Msg { return msg }
+		}
+		if m.cmd.Guide.Confirm != nil {
+			return m.confirm()
+		}
+		return m, m.done()
+	}
+	return m, nil
+}
+
+// View renders the current step, or the confirmation.
+func (m GuideModel) View() string {
+	var b strings.Builder
+	b.WriteString(headerStyle(m.height).Render(m.cmd.Title))
+	b.WriteString("\n\n")
+
+	var body strings.Builder
+	var help string
+	if m.confirming && m.question == "" {
+		body.WriteString("Checking…")
+		help = "please wait • n/esc: cancel"
+	} else if m.confirming {
+		paras := strings.Split(m.question, "\n\n")
+		for i, p := range paras {
+			// Indented paragraphs, such as a command line, are shown as is.
+			if !strings.HasPrefix(p, "  ") {
+				paras[i] = wordWrap(p, 60)
+			}
+		}
+		body.WriteString(strings.Join(paras, "\n\n"))
+		help = "y: confirm • n/esc: back"
+		if len(m.cmd.Guide.Steps) == 0 && !m.fromForm {
+			help = "y: confirm • n/esc: cancel"
+		}
+		if m.fromForm {
+			help = bindings.help(actCmdLine) + ": copy command • " + help
+		}
+		if m.note != "" {
+			help = m.note + "\n" + help
+		}
+	} else {
+		fmt.Fprintf(&body, "Step %d of %d: %s\n\n", m.step+1, len(m.cmd.Guide.Steps), m.cmd.Guide.Steps[m.step].Title)
+		switch {
+		case m.err != nil:
+			body.WriteString(errorStyle.Render("Error: "+m.err.Error()) + "\n")
+		case len(m.items) == 0:
+			body.WriteString("Nothing found. Connect the disk and press r to rescan,\nor press f to type the paths instead.\n")
+		}
+		// Keep the cursor in view on long lists.
+		ps := pageSize(m.height, 14)
+		start := 0
+		if m.cursor >= ps {
+			start = m.cursor - ps + 1
+		}
+		for i := start; i < len(m.items) && i < start+ps; i++ {
+			if i == m.cursor {
+				body.WriteString(cursorLineStyle.Render("> "+m.labels[i]) + "\n")
+			} else {
+				fmt.Fprintf(&body, "  %s\n", m.labels[i])
+			}
+		}
+		help = "↑/↓: navigate • enter: select • " + bindings.key(actBack) + ": previous step • " + bindings.key(actRefresh) + ": rescan • f: type instead • esc: cancel"
+	}
+	b.WriteString(frameStyle(m.height).Render(strings.TrimSuffix(body.String(), "\n")))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(help))
+
+	return place(m.width, m.height, b.String())
+}
+
+// localVolumes lists the volumes mounted on this Mac, leaving out backup
+// destinations.
+func localVolumes([]string) ([]string, error) {
+	dests := make(map[string]bool)
+	if list, err := tmutil.ListDestinations(); err == nil {
+		for _, d := range list {
+			dests[d.MountPoint] = true
+		}
+	}
+	vols := []string{"/"}
+	mounted, _ := filepath.Glob("/Volumes/*")
+	for _, v := range mounted {
+		if !dests[v] {
+			vols = append(vols, v)
+		}
+	}
+	return vols, nil
+}
+
+// mountedVolumes lists everything mounted under /Volumes.
+func mountedVolumes([]string) ([]string, error) {
+	return filepath.Glob("/Volumes/*")
+}
+
+// inheritGuide picks the destination volume and then the machine directory
+// or sparse bundle on it to inherit.
+var inheritGuide = &Guide{
+	Steps: []GuideStep{
+		{Title: "choose the backup disk or network share", Load: mountedVolumes},
+		{Title: "choose the backups to inherit", Load: func(picks []string) ([]string, error) {
+			return tmutil.InheritCandidates(picks[0])
+		}},
+	},
+	Confirm: func(picks []string) string {
+		return fmt.Sprintf("Inherit %s?\n\nThis Mac takes ownership of these backups and continues backing up into them. The computer that made them can no longer add to them. Only do this when this Mac replaces that one, e.g. after migrating to a new Mac. (y/N)",
+			picks[1])
+	},
+}
+
+// associateGuide picks associatedisk's volume and then its backup
+// directory from the latest backups.
+var associateGuide = &Guide{
+	Steps: []GuideStep{
+		{Title: "choose the volume that was reformatted or replaced", Load: localVolumes},
+		{Title: "choose its directory in the latest backup", Load: func([]string) ([]string, error) {
+			return tmutil.VolumeBackupDirs()
+		}},
+	},
+	Confirm: func(picks []string) string {
+		return fmt.Sprintf("Associate %s with %s?\n\nTime Machine will treat %s as the volume backed up there, so its next backup continues that history instead of starting over. (y/N)",
+			picks[0], picks[1], picks[0])
+	},
+}
+
+// quotaGuide warns, in setquota's review, when the new quota is below what
+// the destination already holds; confirming it passes --yes.
+var quotaGuide = &Guide{
+	Confirm:     tmutil.QuotaWarning,
+	ConfirmArgs: []string{"--yes"},
+}
+
+// restoreGuide confirms restoring a directory with its size.
+var restoreGuide = &Guide{
+	Confirm: tmutil.RestoreQuestion,
+}
+
+// inProgressGuide shows the incomplete backup deleteinprogress would
+// remove, or that there is none, before it runs.
+var inProgressGuide = &Guide{
+	Confirm: tmutil.InProgressQuestion,
+}
+
+// keepSnapshotsGuide lists the snapshots keeplocalsnapshots would delete
+// before it runs.
+var keepSnapshotsGuide = &Guide{
+	Confirm: tmutil.KeepSnapshotsQuestion,
+}
+
+// stopGuide shows how far the running backup is before stopping it.
+var stopGuide = &Guide{
+	Confirm: func([]string) string {
+		info, err := tmutil.GetStatus()
+		switch {
+		case err != nil:
+			return "Stop the backup?\n\nThe backup status could not be read: " + err.Error() + " (y/N)"
+		case !info.Running:
+			return "No backup is running. Stop anyway? (y/N)"
+		case info.HasProgress && info.Percent > 0:
+			return fmt.Sprintf("Stop backup at %.0f%%?\n\nProgress: %s. Stopping keeps what is copied, but the next backup has to scan for changes again. (y/N)",
+				info.Percent*100, tmutil.StopProgress(info))
+		}
+		return fmt.Sprintf("Stop the backup?\n\nProgress: %s. Stopping keeps what is copied, but the next backup has to scan for changes again. (y/N)",
+			tmutil.StopProgress(info))
+	},
+	ConfirmArgs: []string{"--yes"},
+}
+
+// setDestinationGuide picks a mounted disk or network share and whether it
+// replaces the current destinations. Network shares need a password that
+// mount does not show, so they continue in the input form to add it.
+var setDestinationGuide = func() *Guide {
+	candidates := make(map[string]tmutil.VolumeCandidate) // by item, for labels and the question
+	space := func(c tmutil.VolumeCandidate) string {
+		if c.Free < 0 {
+			return "free space unknown"
+		}
+		return fmt.Sprintf("%s free of %s", tmutil.FormatBytesInt64(c.Free), tmutil.FormatBytesInt64(c.Size))
+	}
+	return &Guide{
+		Steps: []GuideStep{
+			{Title: "choose the disk or network share to back up to", Load: func([]string) ([]string, error) {
+				vols, err := tmutil.DestinationCandidates()
+				if err != nil {
+					return nil, err
+				}
+				clear(candidates)
+				items := make([]string, 0, len(vols))
+				for _, v := range vols {
+					item := v.MountPoint
+					if v.URL != "" {
+						item = v.URL
+					}
+					candidates[item] = v
+					items = append(items, item)
+				}
+				return items, nil
+			}, Label: func(item string) string {
+				c := candidates[item]
+				if c.URL != "" {
+					return fmt.Sprintf("%s  (network share on %s, %s; add the password next)", tmutil.Redact(item), c.MountPoint, space(c))
+				}
+				return fmt.Sprintf("%s  (%s)", item, space(c))
+			}},
+			{Title: "replace the current destinations or add this one", Load: func([]string) ([]string, error) {
+				if dests, err := tmutil.ListDestinations(); err == nil && len(dests) == 0 {
+					return []string{"n"}, nil
+				}
+				return []string{"n", "y"}, nil
+			}, Label: func(item string) string {
+				if item == "y" {
+					return "Add alongside the current destinations"
+				}
+				return "Replace the current destinations"
+			}},
+		},
+		// A share's URL holds the user name but not the password.
+		Ready: func(picks []string) bool {
+			u, err := url.Parse(picks[0])
+			if err != nil || u.Scheme == "" {
+				return true
+			}
+			_, ok := u.User.Password()
+			return ok
+		},
+		Confirm: func(picks []string) string {
+			verb := "Set %s (%s) as the backup destination, replacing the current ones? (y/N)"
+			if picks[1] == "y" {
+				verb = "Add %s (%s) as a backup destination? (y/N)"
+			}
+			return fmt.Sprintf(verb, picks[0], space(candidates[picks[0]]))
+		},
+	}
+}()
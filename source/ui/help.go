@@ -13,6 +13,8 @@ package ui
 import (
 	"fmt"
 	"strings"
+
+	"tmcli/tmutil"
 )
 
 // BuildCommandHelp generates detailed help for a single command.
@@ -20,7 +22,7 @@ func BuildCommandHelp(cmd Command) string {
 	var b strings.Builder
 
 	fmt.Fprintf(&b, "%s\n", cmd.Title)
-	b.WriteString(strings.Repeat("─", 40) + "\n\n")
+	b.WriteString(tmutil.Rule(40) + "\n\n")
 
 	// Description
 	b.WriteString(wordWrap(cmd.Description, 48))
@@ -28,25 +30,21 @@ func BuildCommandHelp(cmd Command) string {
 
 	// Hotkey
 	fmt.Fprintf(&b, "Hotkey:  %s\n", cmd.Hotkey)
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(&b, "Aliases: %s\n", strings.Join(cmd.Aliases, ", "))
+	}
 	if cmd.RequiresRoot {
 		fmt.Fprintf(&b, "Root:    yes\n")
 	}
 
 	// CLI usage
-	if cmd.IsMonitor {
-		fmt.Fprintf(&b, "CLI:     tmcli %s\n", cmd.ID)
-	} else if len(cmd.Inputs) > 0 {
-		var params []string
-		for _, inp := range cmd.Inputs {
-			if inp.Required {
-				params = append(params, fmt.Sprintf("<%s>", inp.Label))
-			} else {
-				params = append(params, fmt.Sprintf("[%s]", inp.Label))
-			}
+	fmt.Fprintf(&b, "CLI:     %s\n", cliUsage(cmd))
+
+	for _, inp := range cmd.Inputs {
+		if inp.isPath() {
+			b.WriteString("         Quote paths containing spaces, e.g. '/Users/me/My Files'\n")
+			break
 		}
-		fmt.Fprintf(&b, "CLI:     tmcli %s %s\n", cmd.ID, strings.Join(params, " "))
-	} else {
-		fmt.Fprintf(&b, "CLI:     tmcli %s\n", cmd.ID)
 	}
 
 	// Parameters
@@ -58,7 +56,20 @@ func BuildCommandHelp(cmd Command) string {
 				req = "required"
 			}
 			fmt.Fprintf(&b, "  %-22s %s\n", inp.Label, req)
-			if inp.Placeholder != "" {
+			if inp.Toggle {
+				fmt.Fprintf(&b, "  %-22s on/off; on passes %s\n", "", inp.Flag)
+			} else if r := inp.Number; r != nil {
+				fmt.Fprintf(&b, "  %-22s a number from %d to %d\n", "", r.Min, r.Max)
+				if inp.Placeholder != "" {
+					fmt.Fprintf(&b, "  %-22s e.g. %s\n", "", inp.Placeholder)
+				}
+			} else if inp.Choices != nil {
+				names := make([]string, len(inp.Choices))
+				for i, c := range inp.Choices {
+					names[i] = c.title()
+				}
+				fmt.Fprintf(&b, "  %-22s one of %s\n", "", strings.Join(names, ", "))
+			} else if inp.Placeholder != "" {
 				fmt.Fprintf(&b, "  %-22s e.g. %s\n", "", inp.Placeholder)
 			}
 		}
@@ -67,6 +78,101 @@ func BuildCommandHelp(cmd Command) string {
 	return b.String()
 }
 
+// cliUsage is the command line that runs cmd, with <required> and
+// [optional] parameters named by their labels.
+func cliUsage(cmd Command) string {
+	usage := "tmcli " + cmd.ID
+	if cmd.IsMonitor {
+		return usage
+	}
+	for _, inp := range cmd.Inputs {
+		if inp.Required {
+			usage += fmt.Sprintf(" <%s>", inp.Label)
+		} else {
+			usage += fmt.Sprintf(" [%s]", inp.Label)
+		}
+	}
+	return usage
+}
+
+// HelpCategory, HelpCommand and HelpInput are the command catalog as
+// tmcli help --json writes it. Their fields mirror Category, Command and
+// InputField under stable JSON names; the functions behind a command are
+// left out.
+type HelpCategory struct {
+	Title    string        `json:"title"`
+	Hotkey   string        `json:"hotkey"`
+	Commands []HelpCommand `json:"commands"`
+}
+
+type HelpCommand struct {
+	ID           string      `json:"id"`
+	Aliases      []string    `json:"aliases,omitempty"`
+	Title        string      `json:"title"`
+	Description  string      `json:"description"`
+	Hotkey       string      `json:"hotkey"`
+	Usage        string      `json:"usage"` // as BuildCommandHelp shows it
+	Inputs       []HelpInput `json:"inputs"`
+	RequiresRoot bool        `json:"requiresRoot"`
+	ReadOnly     bool        `json:"readOnly"`          // changes nothing
+	Remote       bool        `json:"remote"`            // runs with --host
+	Interactive  bool        `json:"interactive"`       // opens a TUI screen: the monitor, browser, deleter or setup wizard
+	Timeout      string      `json:"timeout,omitempty"` // time limit, e.g. "30m0s"; none when absent
+}
+
+type HelpInput struct {
+	Label       string       `json:"label"`
+	Placeholder string       `json:"placeholder,omitempty"`
+	Required    bool         `json:"required"`
+	Path        bool         `json:"path"`
+	Choices     []Choice     `json:"choices,omitempty"`
+	Toggle      bool         `json:"toggle"`
+	Flag        string       `json:"flag,omitempty"` // what a toggle passes when on
+	Number      *NumberRange `json:"number,omitempty"`
+}
+
+// HelpCatalog returns every category and command, in the curated order of
+// Categories.
+func HelpCatalog() []HelpCategory {
+	var cats []HelpCategory
+	for _, cat := range Categories() {
+		hc := HelpCategory{Title: cat.Title, Hotkey: cat.Hotkey, Commands: []HelpCommand{}}
+		for _, cmd := range cat.Commands {
+			c := HelpCommand{
+				ID:           cmd.ID,
+				Aliases:      cmd.Aliases,
+				Title:        cmd.Title,
+				Description:  cmd.Description,
+				Hotkey:       cmd.Hotkey,
+				Usage:        cliUsage(cmd),
+				Inputs:       []HelpInput{},
+				RequiresRoot: cmd.RequiresRoot,
+				ReadOnly:     cmd.ReadOnly,
+				Remote:       cmd.Remote,
+				Interactive:  cmd.IsMonitor || cmd.IsBrowser || cmd.IsDeleter || cmd.IsSetup,
+			}
+			if cmd.Timeout > 0 {
+				c.Timeout = cmd.Timeout.String()
+			}
+			for _, inp := range cmd.Inputs {
+				c.Inputs = append(c.Inputs, HelpInput{
+					Label:       inp.Label,
+					Placeholder: inp.Placeholder,
+					Required:    inp.Required,
+					Path:        inp.isPath(),
+					Choices:     inp.Choices,
+					Toggle:      inp.Toggle,
+					Flag:        inp.Flag,
+					Number:      inp.Number,
+				})
+			}
+			hc.Commands = append(hc.Commands, c)
+		}
+		cats = append(cats, hc)
+	}
+	return cats
+}
+
 // wordWrap wraps text at the given width on word boundaries.
 func wordWrap(text string, width int) string {
 	words := strings.Fields(text)
@@ -12,13 +12,18 @@ package ui
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
+// inputFieldWidth is the width of the input form's text fields in a
+// window wide enough for them.
+const inputFieldWidth = 50
+
 // InputModel handles multi-field text input for parameterized commands.
 type InputModel struct {
 	command Command
@@ -26,6 +31,12 @@ type InputModel struct {
 	focus   int
 	width   int
 	height  int
+
+	choice  []int        // per field: selected index for choice fields
+	on      []bool       // per field: state of toggle fields
+	history fieldHistory // previous values by field label
+	recall  []int        // per field: index into its history, -1 = draft
+	draft   []string     // per field: the value typed before recalling
 }
 
 // NewInputModel creates an input form for the given command.
@@ -35,16 +46,133 @@ func NewInputModel(cmd Command) InputModel {
 		ti := textinput.New()
 		ti.Placeholder = inp.Placeholder
 		ti.CharLimit = 256
-		ti.Width = 50
+		ti.Width = inputFieldWidth
 		if i == 0 {
 			ti.Focus()
 		}
 		fields[i] = ti
 	}
-	return InputModel{
+	recall := make([]int, len(fields))
+	for i := range recall {
+		recall[i] = -1
+	}
+	m := InputModel{
 		command: cmd,
 		fields:  fields,
+		choice:  make([]int, len(fields)),
+		on:      make([]bool, len(fields)),
+		history: loadHistory(),
+		recall:  recall,
+		draft:   make([]string, len(fields)),
+	}
+	for i, inp := range cmd.Inputs {
+		if inp.Prefill != nil {
+			if v := inp.Prefill(); v != "" {
+				m = m.setValue(i, v)
+			}
+		}
 	}
+	return m
+}
+
+// withValues pre-fills the fields in order; empty values are skipped.
+func (m InputModel) withValues(values ...string) InputModel {
+	for i, v := range values {
+		if i < len(m.fields) && v != "" {
+			m = m.setValue(i, v)
+		}
+	}
+	return m
+}
+
+// value returns field i's argument: the text typed, the selected choice,
+// or a toggle's flag when it is on.
+func (m InputModel) value(i int) string {
+	if inp := m.command.Inputs[i]; inp.Toggle {
+		if m.on[i] {
+			return inp.Flag
+		}
+		return ""
+	}
+	if choices := m.command.Inputs[i].Choices; choices != nil {
+		return choices[m.choice[i]].Value
+	}
+	v := strings.TrimSpace(m.fields[i].Value())
+	if r := m.command.Inputs[i].Number; r != nil && v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return v // left for the command to reject
+		}
+		return strconv.Itoa(r.clamp(n))
+	}
+	return v
+}
+
+// clamp limits n to the range.
+func (r NumberRange) clamp(n int) int {
+	return max(r.Min, min(r.Max, n))
+}
+
+// stepNumber moves a focused numeric field by steps increments, starting
+// from the range's Start when the field is empty or unreadable.
+func (m InputModel) stepNumber(steps int) InputModel {
+	r := m.command.Inputs[m.focus].Number
+	n, err := strconv.Atoi(strings.TrimSpace(m.fields[m.focus].Value()))
+	if err != nil {
+		n = r.Start
+	} else {
+		n += steps * max(r.Step, 1)
+	}
+	m.fields[m.focus].SetValue(strconv.Itoa(r.clamp(n)))
+	m.fields[m.focus].CursorEnd()
+	return m
+}
+
+// digitsOnly drops everything but digits from typed or pasted runes.
+func digitsOnly(runes []rune) []rune {
+	out := runes[:0:0]
+	for _, r := range runes {
+		if r >= '0' && r <= '9' {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// setValue fills field i. A choice field selects the matching choice and
+// ignores values that match none; a toggle turns on for its flag or a yes.
+func (m InputModel) setValue(i int, v string) InputModel {
+	if inp := m.command.Inputs[i]; inp.Toggle {
+		switch strings.ToLower(v) {
+		case strings.ToLower(inp.Flag), "y", "yes", "true", "1", "on":
+			m.on[i] = true
+		default:
+			m.on[i] = false
+		}
+		return m
+	}
+	choices := m.command.Inputs[i].Choices
+	if choices == nil {
+		m.fields[i].SetValue(v)
+		return m
+	}
+	for j, c := range choices {
+		if strings.EqualFold(v, c.Value) || strings.EqualFold(v, c.title()) {
+			m.choice[i] = j
+			break
+		}
+	}
+	return m
+}
+
+// moveChoice steps the selection of a focused choice field, stopping at
+// either end.
+func (m InputModel) moveChoice(step int) InputModel {
+	n := m.choice[m.focus] + step
+	if n >= 0 && n < len(m.command.Inputs[m.focus].Choices) {
+		m.choice[m.focus] = n
+	}
+	return m
 }
 
 // Init implements tea.Model.
@@ -58,6 +186,21 @@ type inputSubmitMsg struct {
 	args    []string
 }
 
+// resize sets the form's size and fits the text fields to it: 50 columns,
+// or what is left of a narrower window inside the frame.
+func (m InputModel) resize(width, height int) InputModel {
+	m.width, m.height = width, height
+	w := inputFieldWidth
+	if width > 0 {
+		// Leave room for the frame and the field's "> " prompt.
+		w = max(10, min(inputFieldWidth, width-frameOverhead(height)-2))
+	}
+	for i := range m.fields {
+		m.fields[i].Width = w
+	}
+	return m
+}
+
 // inputCancelMsg signals that the user cancelled.
 type inputCancelMsg struct{}
 
@@ -65,10 +208,41 @@ type inputCancelMsg struct{}
 func (m InputModel) Update(msg tea.Msg) (InputModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
+		m = m.resize(msg.Width, msg.Height)
 
 	case tea.KeyMsg:
+		if inp := m.command.Inputs[m.focus]; inp.Choices != nil {
+			prev, next := "left", "right"
+			if inp.Vertical {
+				prev, next = "up", "down"
+			}
+			switch msg.String() {
+			case prev, "h", "k":
+				return m.moveChoice(-1), nil
+			case next, "l", "j", " ":
+				return m.moveChoice(1), nil
+			}
+		}
+		if m.command.Inputs[m.focus].Number != nil {
+			switch msg.String() {
+			case "up", "+":
+				return m.stepNumber(1), nil
+			case "down", "-":
+				return m.stepNumber(-1), nil
+			}
+		}
+		if m.command.Inputs[m.focus].Toggle {
+			switch msg.String() {
+			case " ", "x", "left", "right", "h", "l":
+				m.on[m.focus] = !m.on[m.focus]
+				return m, nil
+			}
+		}
+		if len(m.fields) > 1 && msg.Type == tea.KeyRunes && (msg.Alt || !m.typing()) {
+			if i := m.jumpTarget(string(msg.Runes)); i >= 0 {
+				return m.focusField(i), nil
+			}
+		}
 		switch msg.String() {
 		case "ctrl+c":
 			return m, tea.Quit
@@ -78,6 +252,10 @@ func (m InputModel) Update(msg tea.Msg) (InputModel, tea.Cmd) {
 			return m.nextField(), nil
 		case "shift+tab", "up":
 			return m.prevField(), nil
+		case "ctrl+p":
+			return m.recallHistory(1), nil
+		case "ctrl+n":
+			return m.recallHistory(-1), nil
 		case "enter":
 			// If not on last field, advance; otherwise submit
 			if m.focus < len(m.fields)-1 {
@@ -87,6 +265,20 @@ func (m InputModel) Update(msg tea.Msg) (InputModel, tea.Cmd) {
 		}
 	}
 
+	if inp := m.command.Inputs[m.focus]; inp.Choices != nil || inp.Toggle {
+		return m, nil // choice and toggle fields take no typing
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && key.Paste && m.command.Inputs[m.focus].isPath() {
+		key.Runes = []rune(sanitizePath(string(key.Runes)))
+		msg = key
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyRunes && m.command.Inputs[m.focus].Number != nil {
+		if key.Runes = digitsOnly(key.Runes); len(key.Runes) == 0 {
+			return m, nil
+		}
+		msg = key
+	}
+
 	// Update the focused field
 	var cmd tea.Cmd
 	m.fields[m.focus], cmd = m.fields[m.focus].Update(msg)
@@ -107,18 +299,89 @@ func (m InputModel) prevField() InputModel {
 	return m
 }
 
+func (m InputModel) focusField(i int) InputModel {
+	m.fields[m.focus].Blur()
+	m.focus = i
+	m.fields[m.focus].Focus()
+	return m
+}
+
+// jumpTarget returns the field key jumps to: "1"-"9" pick a field by
+// position, and a letter the next field whose label starts with it. It
+// returns -1 when no field matches.
+func (m InputModel) jumpTarget(key string) int {
+	if len(key) != 1 {
+		return -1
+	}
+	if key[0] >= '1' && key[0] <= '9' {
+		if i := int(key[0] - '1'); i < len(m.fields) {
+			return i
+		}
+		return -1
+	}
+	for step := 1; step <= len(m.fields); step++ {
+		i := (m.focus + step) % len(m.fields)
+		if strings.HasPrefix(strings.ToLower(m.command.Inputs[i].Label), strings.ToLower(key)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// typing reports whether keys go into the focused field as text, in which
+// case only alt+key jumps between fields.
+func (m InputModel) typing() bool {
+	inp := m.command.Inputs[m.focus]
+	return inp.Choices == nil && !inp.Toggle
+}
+
+// helpKeys names the command help keys that work in the focused field: a
+// text field takes typed characters, so there only keys such as f1 do.
+func (m InputModel) helpKeys() string {
+	var names []string
+	for _, k := range bindings[actCmdHelp] {
+		if !m.typing() || len([]rune(k)) > 1 {
+			names = append(names, keyName(k))
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// recallHistory steps the focused field through its history: step 1 moves
+// to an older value, -1 to a newer one, and stepping past the newest
+// restores what was typed.
+func (m InputModel) recallHistory(step int) InputModel {
+	values := m.history[m.command.Inputs[m.focus].Label]
+	pos := m.recall[m.focus] + step
+	if pos >= len(values) || pos < -1 {
+		return m
+	}
+	if m.recall[m.focus] == -1 {
+		m.draft[m.focus] = m.value(m.focus)
+	}
+	m.recall[m.focus] = pos
+	if pos == -1 {
+		m = m.setValue(m.focus, m.draft[m.focus])
+	} else {
+		m = m.setValue(m.focus, values[pos])
+	}
+	m.fields[m.focus].CursorEnd()
+	return m
+}
+
 func (m InputModel) submit() tea.Cmd {
 	// Validate required fields
 	for i, inp := range m.command.Inputs {
-		if inp.Required && strings.TrimSpace(m.fields[i].Value()) == "" {
+		if inp.Required && m.value(i) == "" {
 			return nil // don't submit if required fields are empty
 		}
 	}
 
 	args := make([]string, len(m.fields))
 	for i := range m.fields {
-		args[i] = strings.TrimSpace(m.fields[i].Value())
+		args[i] = m.value(i)
 	}
+	recordHistory(m.command.Inputs, args)
 	cmd := m.command
 	return func() tea.Msg {
 		return inputSubmitMsg{command: cmd, args: args}
@@ -129,7 +392,7 @@ func (m InputModel) submit() tea.Cmd {
 func (m InputModel) View() string {
 	var b strings.Builder
 
-	b.WriteString(titleStyle.Render(m.command.Title))
+	b.WriteString(headerStyle(m.height).Render(m.command.Title))
 	b.WriteString("\n\n")
 
 	var form strings.Builder
@@ -138,18 +401,93 @@ func (m InputModel) View() string {
 		if inp.Required {
 			label += " *"
 		}
-		form.WriteString(fmt.Sprintf("%s\n", inputLabelStyle.Render(label)))
-		form.WriteString(fmt.Sprintf("%s\n", m.fields[i].View()))
+		if inp.Toggle {
+			form.WriteString(fmt.Sprintf("%s\n", m.renderToggle(i)))
+		} else if inp.Choices != nil {
+			form.WriteString(fmt.Sprintf("%s\n", inputLabelStyle.Render(label)))
+			form.WriteString(fmt.Sprintf("%s\n", m.renderChoices(i)))
+		} else {
+			form.WriteString(fmt.Sprintf("%s\n", inputLabelStyle.Render(label)))
+			form.WriteString(fmt.Sprintf("%s\n", m.fields[i].View()))
+		}
 		if i < len(m.command.Inputs)-1 {
 			form.WriteString("\n")
 		}
 	}
-	b.WriteString(outputStyle.Render(form.String()))
+	b.WriteString(frameStyle(m.height).Render(form.String()))
 
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("tab: next field • enter: submit • esc: cancel"))
+	keys := "tab: next field • ctrl+p/ctrl+n: previous values • enter: submit • esc: cancel"
+	if len(m.fields) > 1 {
+		keys = "tab: next field • alt+1-9/letter: jump to field • ctrl+p/ctrl+n: previous values • enter: submit • esc: cancel"
+	}
+	if inp := m.command.Inputs[m.focus]; inp.Choices != nil {
+		if inp.Vertical {
+			keys = "↑/↓: choose • " + keys
+		} else {
+			keys = "←/→: choose • " + keys
+		}
+	} else if inp.Toggle {
+		keys = "space: toggle • " + keys
+	} else if inp.Number != nil {
+		keys = "↑/↓: step • " + keys
+	}
+	if k := m.helpKeys(); k != "" && FindCommand(m.command.ID) != nil {
+		keys += " • " + k + ": help"
+	}
+	b.WriteString(helpStyle.Render(keys))
+
+	return place(m.width, m.height, b.String())
+}
 
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+// renderChoices draws a choice field, marking the selected choice and
+// highlighting it while the field has focus.
+func (m InputModel) renderChoices(i int) string {
+	inp := m.command.Inputs[i]
+	parts := make([]string, len(inp.Choices))
+	for j, c := range inp.Choices {
+		switch {
+		case j != m.choice[i]:
+			parts[j] = "  " + c.title() + " "
+		case i == m.focus:
+			parts[j] = cursorLineStyle.Render("> " + c.title() + " ")
+		default:
+			parts[j] = "> " + c.title() + " "
+		}
+	}
+	if inp.Vertical {
+		return strings.Join(parts, "\n")
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderToggle draws a toggle field as a checkbox beside its label,
+// highlighted while the field has focus.
+func (m InputModel) renderToggle(i int) string {
+	box := "[ ]"
+	if m.on[i] {
+		box = "[x]"
+	}
+	line := box + " " + m.command.Inputs[i].Label
+	if i == m.focus {
+		return cursorLineStyle.Render(line)
+	}
+	return inputLabelStyle.Render(line)
+}
+
+// sanitizePath cleans up a path pasted from Finder or another app: it
+// trims whitespace and newlines, removes surrounding quotes, and turns a
+// file:// URL into a plain path.
+func sanitizePath(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	if strings.HasPrefix(s, "file://") {
+		if u, err := url.Parse(s); err == nil && u.Path != "" {
+			return u.Path // also decodes %20 and friends
+		}
+		s = strings.TrimPrefix(s, "file://")
+	}
+	return s
 }
@@ -0,0 +1,180 @@
+//
+// keymap.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// action is something a key does in the TUI. The names are the keys of the
+// "keys" object in config.json.
+type action string
+
+const (
+	actQuit      action = "quit"
+	actBack      action = "back"
+	actUp        action = "up"
+	actDown      action = "down"
+	actLeft      action = "left"
+	actRight     action = "right"
+	actSelect    action = "select"
+	actPageUp    action = "pageUp"
+	actPageDown  action = "pageDown"
+	actDashboard action = "dashboard"   // main menu: dashboard
+	actSaved     action = "saved"       // main menu: saved commands
+	actVersion   action = "version"     // main menu: version
+	actHelp      action = "help"        // main menu: help
+	actCmdHelp   action = "commandHelp" // input form, output: help for the command
+	actRepeat    action = "repeat"      // menus: repeat the last command
+	actCopy      action = "copy"        // output: copy the highlighted path
+	actCmdLine   action = "copyCommand" // output, review: copy the command line
+	actOpen      action = "open"        // output: open the highlighted path in Finder
+	actWiden     action = "widen"       // output: search more backups
+	actRefresh   action = "refresh"     // output, lists: refresh or reload; output: re-run a timed-out command
+	actTimes     action = "times"       // output: absolute/relative times
+	actRaw       action = "raw"         // output: raw/formatted output
+	actSize      action = "sizeSort"    // output: sort rows by size
+	actPin       action = "pin"         // output: save the command
+	actRemove    action = "remove"      // saved commands: remove the highlighted one
+)
+
+// keyMap binds each action to the keys that trigger it, as Bubble Tea
+// names them ("q", "esc", "pgdown", "ctrl+x"). ctrl+c always quits and
+// is not part of the map.
+type keyMap map[action][]string
+
+// defaultKeys are the bindings tmcli ships with.
+func defaultKeys() keyMap {
+	return keyMap{
+		actQuit:      {"q"},
+		actBack:      {"b", "esc", "backspace"},
+		actUp:        {"up", "k"},
+		actDown:      {"down", "j"},
+		actLeft:      {"left"},
+		actRight:     {"right"},
+		actSelect:    {"enter"},
+		actPageUp:    {"pgup"},
+		actPageDown:  {"pgdown", " "},
+		actDashboard: {"o"},
+		actSaved:     {"p"},
+		actVersion:   {"v"},
+		actHelp:      {"h"},
+		actCmdHelp:   {"?", "f1"},
+		actRepeat:    {"."},
+		actCopy:      {"c"},
+		actCmdLine:   {"s"},
+		actOpen:      {"o"},
+		actWiden:     {"+"},
+		actRefresh:   {"r"},
+		actTimes:     {"t"},
+		actRaw:       {"v"},
+		actSize:      {"z"},
+		actPin:       {"p"},
+		actRemove:    {"x", "delete"},
+	}
+}
+
+// bindings is the active key map, set from config.json by ApplyConfig.
+var bindings = defaultKeys()
+
+// newKeyMap applies the bindings from config.json over the defaults. Each
+// entry replaces all of an action's default keys; "space" names the space
+// bar.
+func newKeyMap(cfg map[string][]string) (keyMap, error) {
+	km := defaultKeys()
+	for name, bound := range cfg {
+		a := action(name)
+		if _, ok := km[a]; !ok {
+			return nil, fmt.Errorf("unknown key action %q: expected one of %s", name, strings.Join(km.actions(), ", "))
+		}
+		if len(bound) == 0 {
+			return nil, fmt.Errorf("no keys given for %q", name)
+		}
+		km[a] = nil
+		for _, k := range bound {
+			if k == "space" {
+				k = " "
+			}
+			km[a] = append(km[a], k)
+		}
+	}
+	return km, nil
+}
+
+// actions lists the action names in order.
+func (km keyMap) actions() []string {
+	names := make([]string, 0, len(km))
+	for a := range km {
+		names = append(names, string(a))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// is reports whether key triggers a.
+func (km keyMap) is(key string, a action) bool {
+	return slices.Contains(km[a], key)
+}
+
+// keyName is how a key is shown on screen.
+func keyName(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "space"
+	}
+	return k
+}
+
+// key names a's first key, for menu items such as "[q] Quit".
+func (km keyMap) key(a action) string {
+	if len(km[a]) == 0 {
+		return ""
+	}
+	return keyName(km[a][0])
+}
+
+// help names a's keys for a footer, e.g. "b/esc"; at most two are listed.
+func (km keyMap) help(a action) string {
+	var names []string
+	for _, k := range km[a] {
+		names = append(names, keyName(k))
+		if len(names) == 2 {
+			break
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// navHelp is the footer text for moving through a menu; across adds the
+// left and right keys of a menu laid out in columns.
+func (km keyMap) navHelp(across bool) string {
+	move := km.key(actUp) + "/" + km.key(actDown)
+	if across {
+		move += "/" + km.key(actLeft) + "/" + km.key(actRight)
+	}
+	return move + ": navigate • " + km.key(actSelect) + "/hotkey: select"
+}
+
+// backQuitHelp is the footer of views that can only be left.
+func (km keyMap) backQuitHelp() string {
+	return km.help(actBack) + ": back • " + km.help(actQuit) + ": quit"
+}
@@ -0,0 +1,41 @@
+//
+// locale.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+
+	"tmcli/tmutil"
+)
+
+// LoadLocale selects the output language from the environment and, when
+// present, loads its catalog from messages.<lang>.json in the config
+// directory. Messages missing from the catalog fall back to English, as
+// does an unknown language.
+func LoadLocale() error {
+	lang := tmutil.LocaleFromEnv()
+	if lang == "en" {
+		return nil
+	}
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "messages."+lang+".json")
+	if _, err := os.Stat(path); err == nil {
+		if err := tmutil.LoadCatalog(lang, path); err != nil {
+			return err
+		}
+	}
+	tmutil.SetLocale(lang)
+	return nil
+}
@@ -0,0 +1,75 @@
+//
+// log.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"tmcli/tmutil"
+)
+
+// logMaxBytes is the size at which the command log is rotated unless
+// config.json sets another.
+const logMaxBytes = 1 << 20
+
+// LogConfig is the "log" object of config.json: how much of the command
+// log to keep.
+type LogConfig struct {
+	MaxSizeMB int    `json:"maxSizeMB,omitempty"` // rotate at this size (default 1)
+	MaxAge    string `json:"maxAge,omitempty"`    // rotate a log this old when it is opened, e.g. 7d
+	Keep      int    `json:"keep,omitempty"`      // rotated logs to keep (default 1)
+}
+
+// retention turns the log settings into a tmutil.LogRetention.
+func (c *LogConfig) retention() (tmutil.LogRetention, error) {
+	r := tmutil.LogRetention{MaxBytes: logMaxBytes, Keep: 1}
+	if c == nil {
+		return r, nil
+	}
+	if c.MaxSizeMB < 0 || c.Keep < 0 {
+		return r, fmt.Errorf("invalid log settings: maxSizeMB and keep cannot be negative")
+	}
+	if c.MaxSizeMB > 0 {
+		r.MaxBytes = int64(c.MaxSizeMB) << 20
+	}
+	if c.Keep > 0 {
+		r.Keep = c.Keep
+	}
+	if c.MaxAge != "" {
+		age, err := tmutil.ParseAge(c.MaxAge)
+		if err != nil {
+			return r, fmt.Errorf("invalid log maxAge: %w", err)
+		}
+		r.MaxAge = age
+	}
+	return r, nil
+}
+
+// EnableCommandLog starts logging every tmutil invocation to tmcli.log in
+// the configuration directory, rotated as config.json's "log" settings
+// say, and returns the log's path.
+func EnableCommandLog() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	cfg, err := readConfig()
+	if err != nil {
+		return "", err
+	}
+	r, err := cfg.Log.retention()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "tmcli.log")
+	return path, tmutil.EnableLog(path, r)
+}
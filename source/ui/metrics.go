@@ -0,0 +1,97 @@
+//
+// metrics.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"tmcli/tmutil"
+)
+
+// metricsSample is everything /metrics reports, gathered up front so that
+// formatting is a pure function of it.
+type metricsSample struct {
+	Now        time.Time
+	Status     tmutil.StatusInfo
+	StatusOK   bool
+	LastBackup time.Time // zero if unknown
+	Dests      []tmutil.DestinationPrefs
+}
+
+// collectMetrics reads the current status and preferences. Sources that
+// fail are left out rather than failing the scrape.
+func collectMetrics() metricsSample {
+	s := metricsSample{Now: time.Now()}
+	if info, err := tmutil.GetStatus(); err == nil {
+		s.Status, s.StatusOK = info, true
+	}
+	if prefs, err := tmutil.GetBackupPrefs(); err == nil {
+		s.LastBackup = prefs.LastSnapshot()
+	}
+	if dests, err := tmutil.GetDestinationPrefs(); err == nil {
+		s.Dests = dests
+	}
+	return s
+}
+
+// formatMetrics renders s in the Prometheus text exposition format.
+func formatMetrics(s metricsSample) string {
+	var b strings.Builder
+	gauge := func(name, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	boolValue := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+
+	gauge("tm_up", "Whether tmutil status could be read.")
+	fmt.Fprintf(&b, "tm_up %d\n", boolValue(s.StatusOK))
+	if s.StatusOK {
+		gauge("tm_backup_running", "Whether a backup is running.")
+		fmt.Fprintf(&b, "tm_backup_running %d\n", boolValue(s.Status.Running))
+		gauge("tm_backup_percent", "Progress of the running backup, 0 to 1.")
+		fmt.Fprintf(&b, "tm_backup_percent %g\n", s.Status.Percent)
+		gauge("tm_backup_bytes_copied", "Bytes copied by the running backup.")
+		fmt.Fprintf(&b, "tm_backup_bytes_copied %d\n", s.Status.BytesCopied)
+	}
+	if !s.LastBackup.IsZero() {
+		gauge("tm_last_backup_age_seconds", "Seconds since the last completed backup.")
+		fmt.Fprintf(&b, "tm_last_backup_age_seconds %.0f\n", s.Now.Sub(s.LastBackup).Seconds())
+		gauge("tm_last_backup_timestamp_seconds", "Unix time of the last completed backup.")
+		fmt.Fprintf(&b, "tm_last_backup_timestamp_seconds %d\n", s.LastBackup.Unix())
+	}
+	if len(s.Dests) > 0 {
+		gauge("tm_destination_bytes_available", "Free bytes on the destination.")
+		for _, d := range s.Dests {
+			fmt.Fprintf(&b, "tm_destination_bytes_available{id=%q} %d\n", d.ID, d.BytesAvailable)
+		}
+		gauge("tm_destination_bytes_used", "Bytes used by backups on the destination.")
+		for _, d := range s.Dests {
+			fmt.Fprintf(&b, "tm_destination_bytes_used{id=%q} %d\n", d.ID, d.BytesUsed)
+		}
+		gauge("tm_destination_quota_bytes", "Quota set on the destination, 0 if none.")
+		for _, d := range s.Dests {
+			fmt.Fprintf(&b, "tm_destination_quota_bytes{id=%q} %d\n", d.ID, d.QuotaGB*1e9)
+		}
+	}
+	return b.String()
+}
+
+func serveMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, tmutil.Redact(formatMetrics(collectMetrics())))
+}
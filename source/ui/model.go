@@ -11,9 +11,15 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"tmcli/tmutil"
+
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -30,13 +36,48 @@ const (
 	helpCategoryView
 	helpCommandView
 	helpDetailView
+	deleteView
+	streamView
+	setupView
+	guideView
+	savedView
+	browserView
+	runningView
+	busyView
+	unmountedView
+	dashboardView
 )
 
 type commandResultMsg struct {
+	gen    int // runGen of the command, to drop results of cancelled runs
 	output string
+	sized  tmutil.SizedList // from Sized; output is its String
 	err    error
 }
 
+// refreshTickMsg asks the output view to refresh; gen discards ticks from
+// earlier results.
+type refreshTickMsg struct {
+	gen int
+}
+
+// refreshResultMsg carries refreshed output for the output view.
+type refreshResultMsg struct {
+	gen    int
+	output string
+	raw    string
+	live   bool
+	err    error
+}
+
+// refreshInterval is how often a live output view re-runs its command.
+const refreshInterval = 1 * time.Second
+
+// monitorReadyMsg reports the result of a command run before attaching the monitor.
+type monitorReadyMsg struct {
+	err error
+}
+
 // Model is the top-level Bubbletea model.
 type Model struct {
 	version    string
@@ -46,28 +87,114 @@ type Model struct {
 	cmdCursor  int // cursor within command submenu
 	output       string
 	scrollOffset int
+	following    bool // keep the last output line in view as lines arrive, like tail -f
 	err          error
 	width      int
 	height     int
 	monitor      MonitorModel
+	deleter      DeleteModel
+	browser      BrowserModel
+	stream       <-chan tea.Msg // progress from a running streaming command
+	streamTitle  string
+	streamCount  int
+	streamStatus bool // show streamLast as the status instead of counting lines
+	streamLast   string
+	streamLines  []string  // output so far, shown live unless streamStatus
+	streamTotal  int       // expected progress lines, 0 if unknown
+	streamStart  time.Time // when the streaming command started
+	spinner      spinner.Model
 	input        InputModel
+	inputBack    viewState // view to return to when the input form is cancelled
+	setup        SetupModel
+	guide        GuideModel
+	busy         busyRun
+	unmounted    unmountedRun
+	dashboard       dashboardState
+	dashboardLoaded bool // dashboard holds a read; false until the first arrives
+	dashboardGen    int  // generation of the dashboard reads, to drop stale ones
+	monitorFromDashboard bool // back from the monitor returns to the dashboard
+	saved        []SavedCommand
+	savedCursor  int
+	lastCmd      Command  // most recently executed command, for pinning and refresh
+	lastArgs     []string // its arguments
+	repeatCmd    *Command // last command run that needs no root, re-run with .; nil before any
+	repeatArgs   []string // its arguments
+	refreshGen   int      // generation of the current output, for live refresh
+	report       string   // formatted output, kept while the raw form is shown
+	rawOutput    string   // raw form of the output, from Refresh
+	showRaw      bool     // output view shows rawOutput instead of report
+	relativeTimes bool    // timestamps shown as "2h ago"; toggled with t
+	sized        tmutil.SizedList // output rows with their sizes, from Sized
+	sizeOrder    sizeOrder        // order of the sized rows; toggled with z
+	lineCursor   int      // output line under the cursor, for commands with LinePath
+	outputNote   string   // one-line feedback shown in the output view
+	menuNote     string   // one-line feedback shown in the command menu
+	cancel       context.CancelFunc // cancels the running command; nil when idle
+	runGen       int                // generation of the running command
+	runStart     time.Time          // when the running command started
+	stopping     bool               // a stream was asked to stop; waiting for it to end
+	stopped      error              // ErrCancelled or ErrTimeout when the output is what a stopped command printed
+	stoppedAfter time.Duration      // how long it ran before that
+	isRoot        bool   // running as root, computed once at startup
 	helpCursor    int    // cursor within help category picker
 	helpCmdCursor int    // cursor within help command list
 	helpOutput    string // rendered help text for detail view
+	helpBack      viewState // view the help detail view returns to
+	statusBar     string // backup state shown above the help line; "" hides it
+	start         *openCommandMsg // command to open once started, from WithCommand
+}
+
+// openCommandMsg opens a command as the TUI starts.
+type openCommandMsg struct {
+	cmd  Command
+	args []string
 }
 
 // NewModel returns the initial model.
 func NewModel(version string) Model {
-	return Model{
+	m := Model{
 		version:    version,
 		view:       categoryView,
-		categories: Categories(),
+		categories: MenuCategories(),
+		isRoot:     tmutil.IsRoot(),
+		relativeTimes: tmutil.RelativeTimes(),
+	}
+	if startDashboard {
+		m.view = dashboardView
 	}
+	return m
 }
 
-// Init implements tea.Model.
+// WithCommand starts the TUI at the command named id: its input form, with
+// args filled in, or its result when it takes no input. Going back leads
+// to the menu of its category. It reports false, leaving the main menu,
+// when there is no such command.
+func (m Model) WithCommand(id string, args []string) (Model, bool) {
+	for i, cat := range m.categories {
+		for j, cmd := range cat.Commands {
+			if cmd.ID != id {
+				continue
+			}
+			m.catCursor, m.cmdCursor = i, j
+			m.view = commandView
+			m.start = &openCommandMsg{cmd: cmd, args: args}
+			return m, true
+		}
+	}
+	return m, false
+}
+
+// Init implements tea.Model. It checks for a configured destination so a
+// fresh system can be offered the setup wizard, and starts the status bar.
 func (m Model) Init() tea.Cmd {
-	return nil
+	if m.start != nil {
+		start := *m.start
+		return tea.Batch(checkDestination, pollStatusBar, func() tea.Msg { return start })
+	}
+	if m.view == dashboardView {
+		return tea.Batch(checkDestination, pollStatusBar, loadDashboard(m.dashboardGen))
+	}
+	return tea.Batch(checkDestination, pollStatusBar)
 }
 
 // Update implements tea.Model.
@@ -76,7 +203,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		return m, nil
+		tmutil.SetOutputWidth(m.width - frameOverhead(m.height))
+		return m.resizeActive(msg)
 
 	case tea.KeyMsg:
 		switch m.view {
@@ -98,26 +226,197 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateHelpCommand(msg)
 		case helpDetailView:
 			return m.updateHelpDetail(msg)
+		case deleteView:
+			return m.updateDelete(msg)
+		case streamView:
+			return m.updateStream(msg)
+		case setupView:
+			return m.updateSetup(msg)
+		case guideView:
+			return m.updateGuide(msg)
+		case savedView:
+			return m.updateSaved(msg)
+		case browserView:
+			return m.updateBrowser(msg)
+		case runningView:
+			return m.updateRunning(msg)
+		case busyView:
+			return m.updateBusy(msg)
+		case unmountedView:
+			return m.updateUnmounted(msg)
+		case dashboardView:
+			return m.updateDashboard(msg)
 		}
 
+	case statusBarMsg:
+		m.statusBar = msg.text
+		return m, scheduleStatusBar()
+
+	case statusBarTickMsg:
+		return m, pollStatusBar
+
 	case statusUpdateMsg, statusTickMsg:
 		if m.view == monitorView {
 			return m.updateMonitor(msg)
 		}
 
+	case dashboardMsg, dashboardTickMsg:
+		if m.view == dashboardView {
+			return m.updateDashboard(msg)
+		}
+
+	case backupListMsg, uniqueSizeMsg, deleteResultMsg:
+		if m.view == deleteView {
+			return m.updateDelete(msg)
+		}
+		if m.view == browserView {
+			return m.updateBrowser(msg)
+		}
+
+	case browserSelectMsg:
+		restore := FindCommand("restore")
+		updated, cmd := m.openInput(*restore, browserView)
+		updated.input = updated.input.withValues(msg.path)
+		updated.input = updated.input.nextField()
+		return updated, cmd
+
+	case browserExitMsg:
+		m.view = commandView
+		return m, nil
+
+	case streamTotalMsg, streamLineMsg, streamDoneMsg, spinner.TickMsg:
+		if m.view == streamView {
+			return m.updateStream(msg)
+		}
+		if m.view == runningView {
+			return m.updateRunning(msg)
+		}
+
+	case setupNeededMsg:
+		if m.view == categoryView {
+			m.setup = NewSetupModel(true)
+			m.view = setupView
+		}
+		return m, nil
+
+	case setupChosenMsg:
+		return m.openInput(setupOptionsCommand(msg.volume), categoryView)
+
+	case setupExitMsg:
+		m.view = categoryView
+		return m, nil
+
+	case guideDoneMsg:
+		return m.execute(msg.cmd, msg.args)
+
+	case guideFormMsg:
+		updated, cmd := m.openInput(msg.cmd, commandView)
+		updated.input = updated.input.withValues(msg.values...)
+		return updated, cmd
+
+	case guideExitMsg:
+		m.view = commandView
+		if m.guide.fromSaved {
+			m.view = savedView
+			return m, nil
+		}
+		if m.guide.fromForm {
+			m.view = inputView
+			return m, m.input.Init()
+		}
+		return m, nil
+
+	case guideQuestionMsg:
+		if m.view != guideView {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.guide, cmd = m.guide.Update(msg)
+		return m, cmd
+
+	case deleteExitMsg:
+		m.view = commandView
+		return m, nil
+
+	case monitorReadyMsg:
+		if msg.err != nil {
+			m.output = ""
+			m.err = msg.err
+			m.scrollOffset, m.following = 0, false
+			m.view = outputView
+			return m, nil
+		}
+		m.monitor = NewMonitorModel(m.version, true)
+		m.view = monitorView
+		return m, m.monitor.Init()
+
 	case commandResultMsg:
+		if msg.gen != m.runGen {
+			return m, nil
+		}
+		m = m.endRun()
+		if partial, ok := tmutil.PartialOutput(msg.err); ok {
+			m.scrollOffset, m.following = 0, false
+			return m.showPartial(partial, msg.err), nil
+		}
 		m.output = msg.output
+		m.report, m.rawOutput, m.showRaw = msg.output, "", false
+		m.sized, m.sizeOrder = msg.sized, sizeListed
 		m.err = msg.err
-		m.scrollOffset = 0
+		m.scrollOffset, m.following = 0, false
 		m.view = outputView
+		m = m.resetLineCursor()
+		m.refreshGen++
+		if m.lastCmd.Refresh != nil && msg.err == nil {
+			return m, m.scheduleRefresh()
+		}
 		return m, nil
 
+	case refreshTickMsg:
+		if msg.gen != m.refreshGen || m.view != outputView || m.lastCmd.Refresh == nil {
+			return m, nil
+		}
+		return m, m.runRefresh()
+
+	case refreshResultMsg:
+		if msg.gen != m.refreshGen || m.view != outputView {
+			return m, nil
+		}
+		m.report, m.rawOutput = msg.output, msg.raw
+		m = m.showOutput()
+		m.err = msg.err
+		if msg.live && msg.err == nil {
+			return m, m.scheduleRefresh()
+		}
+		return m, nil
+
+	case openCommandMsg:
+		m.start = nil
+		if len(msg.args) > 0 && len(msg.cmd.Inputs) > 0 && (msg.cmd.Guide == nil || msg.cmd.confirmsForm()) {
+			var cmd tea.Cmd
+			m, cmd = m.openInput(msg.cmd, commandView)
+			m.input = m.input.withValues(msg.args...)
+			return m, cmd
+		}
+		return m.selectCommand(msg.cmd)
+
 	case inputSubmitMsg:
-		m.view = outputView
-		return m, m.executeWithArgs(msg.command, msg.args)
+		if msg.command.ReviewBeforeRun {
+			var cmd tea.Cmd
+			m.guide, cmd = NewFormReview(msg.command, msg.args)
+			m.view = guideView
+			return m, cmd
+		}
+		if msg.command.confirmsForm() {
+			var cmd tea.Cmd
+			m.guide, cmd = NewFormConfirm(msg.command, msg.args)
+			m.view = guideView
+			return m, cmd
+		}
+		return m.execute(msg.command, msg.args)
 
 	case inputCancelMsg:
-		m.view = commandView
+		m.view = m.inputBack
 		return m, nil
 	}
 
@@ -127,40 +426,57 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // --- Category menu ---
 
 func (m Model) updateCategory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	versionIdx := len(m.categories)
+	dashboardIdx := len(m.categories)
+	savedIdx := dashboardIdx + 1
+	versionIdx := savedIdx + 1
 	helpIdx := versionIdx + 1
 	quitIdx := helpIdx + 1
 	count := quitIdx + 1
-	switch msg.String() {
-	case "ctrl+c":
+	switch k := msg.String(); {
+	case k == "ctrl+c":
 		return m, tea.Quit
-	case "up", "k":
+	case bindings.is(k, actLeft), bindings.is(k, actRight):
+		_, rows := m.menuColumns(len(m.categories), m.categoryMenuWidth())
+		dir := 1
+		if bindings.is(k, actLeft) {
+			dir = -1
+		}
+		m.catCursor = moveAcross(m.catCursor, len(m.categories), rows, dir)
+	case bindings.is(k, actUp):
 		if m.catCursor > 0 {
 			m.catCursor--
 		} else {
 			m.catCursor = count - 1
 		}
-	case "down", "j":
+	case bindings.is(k, actDown):
 		if m.catCursor < count-1 {
 			m.catCursor++
 		} else {
 			m.catCursor = 0
 		}
-	case "enter":
+	case bindings.is(k, actSelect):
 		return m.selectCategoryItem()
-	case "v":
+	case bindings.is(k, actDashboard):
+		m.catCursor = dashboardIdx
+		return m.openDashboard()
+	case bindings.is(k, actSaved):
+		m.catCursor = savedIdx
+		return m.openSaved()
+	case bindings.is(k, actVersion):
 		m.view = versionView
 		return m, nil
-	case "h":
+	case bindings.is(k, actHelp):
 		m.view = helpCategoryView
 		m.helpCursor = 0
 		return m, nil
-	case "q":
+	case bindings.is(k, actRepeat):
+		return m.repeatLast()
+	case bindings.is(k, actQuit):
 		return m, tea.Quit
 	default:
 		// Check category hotkeys
 		for i, cat := range m.categories {
-			if msg.String() == cat.Hotkey {
+			if k == cat.Hotkey {
 				m.catCursor = i
 				m.view = commandView
 				m.cmdCursor = 0
@@ -172,12 +488,18 @@ func (m Model) updateCategory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) selectCategoryItem() (tea.Model, tea.Cmd) {
-	versionIdx := len(m.categories)
+	dashboardIdx := len(m.categories)
+	savedIdx := dashboardIdx + 1
+	versionIdx := savedIdx + 1
 	helpIdx := versionIdx + 1
 	quitIdx := helpIdx + 1
 	switch m.catCursor {
 	case quitIdx:
 		return m, tea.Quit
+	case dashboardIdx:
+		return m.openDashboard()
+	case savedIdx:
+		return m.openSaved()
 	case versionIdx:
 		m.view = versionView
 		return m, nil
@@ -199,25 +521,29 @@ func (m Model) updateCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	backIdx := len(cmds)
 	quitIdx := backIdx + 1
 	count := quitIdx + 1
-	switch msg.String() {
-	case "ctrl+c":
+	switch k := msg.String(); {
+	case k == "ctrl+c":
 		return m, tea.Quit
-	case "esc", "backspace":
-		m.view = categoryView
-		return m, nil
-	case "up", "k":
+	case bindings.is(k, actLeft), bindings.is(k, actRight):
+		_, rows := m.menuColumns(len(cmds), commandMenuWidth(m.categories[m.catCursor]))
+		dir := 1
+		if bindings.is(k, actLeft) {
+			dir = -1
+		}
+		m.cmdCursor = moveAcross(m.cmdCursor, len(cmds), rows, dir)
+	case bindings.is(k, actUp):
 		if m.cmdCursor > 0 {
 			m.cmdCursor--
 		} else {
 			m.cmdCursor = count - 1
 		}
-	case "down", "j":
+	case bindings.is(k, actDown):
 		if m.cmdCursor < count-1 {
 			m.cmdCursor++
 		} else {
 			m.cmdCursor = 0
 		}
-	case "enter":
+	case bindings.is(k, actSelect):
 		switch m.cmdCursor {
 		case quitIdx:
 			return m, tea.Quit
@@ -228,109 +554,399 @@ func (m Model) updateCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m.selectCommand(cmds[m.cmdCursor])
 		}
 	default:
-		// Check command hotkeys
+		// Command hotkeys win over back, repeat and quit, so a command
+		// may use b.
 		for _, cmd := range cmds {
-			if msg.String() == cmd.Hotkey {
+			if k == cmd.Hotkey {
 				return m.selectCommand(cmd)
 			}
 		}
-		if msg.String() == "b" {
+		switch {
+		case bindings.is(k, actBack):
 			m.view = categoryView
 			return m, nil
-		}
-		if msg.String() == "q" {
+		case bindings.is(k, actRepeat):
+			return m.repeatLast()
+		case bindings.is(k, actQuit):
 			return m, tea.Quit
 		}
 	}
 	return m, nil
 }
 
+// repeatLast re-runs the last command with the same arguments, going
+// straight to its output or the monitor.
+func (m Model) repeatLast() (tea.Model, tea.Cmd) {
+	if m.repeatCmd == nil {
+		return m, nil
+	}
+	if m.repeatCmd.IsMonitor {
+		return m.selectCommand(*m.repeatCmd)
+	}
+	return m.execute(*m.repeatCmd, m.repeatArgs)
+}
+
+// repeatHint is the footer text for the . key, naming what it will run.
+func (m Model) repeatHint() string {
+	if m.repeatCmd == nil {
+		return ""
+	}
+	label := m.repeatCmd.Title
+	var args []string
+	for _, a := range m.repeatArgs {
+		if a != "" {
+			args = append(args, a)
+		}
+	}
+	if len(args) > 0 {
+		label += " " + tmutil.ShellJoin(args)
+	}
+	return " • " + bindings.key(actRepeat) + ": repeat " + tmutil.TruncateMiddle(label, 40)
+}
+
 func (m Model) selectCommand(cmd Command) (tea.Model, tea.Cmd) {
+	if cmd.IsMonitor {
+		m.repeatCmd, m.repeatArgs = &cmd, nil
+	}
+	if cmd.IsMonitor && cmd.Execute != nil {
+		m.view = outputView
+		return m, func() tea.Msg {
+			_, err := cmd.Execute(nil)
+			return monitorReadyMsg{err: err}
+		}
+	}
 	if cmd.IsMonitor {
 		m.monitor = NewMonitorModel(m.version, true)
 		m.view = monitorView
 		return m, m.monitor.Init()
 	}
+	if cmd.IsDeleter {
+		m.deleter = NewDeleteModel(m.version, true)
+		m.deleter.width = m.width
+		m.deleter.height = m.height
+		m.view = deleteView
+		return m, m.deleter.Init()
+	}
+	if cmd.IsBrowser {
+		if held, unmounted := m.checkMounted(cmd, nil); unmounted {
+			return held, nil
+		}
+		return m.openBrowser()
+	}
+	if cmd.IsSetup {
+		m.setup = NewSetupModel(false)
+		m.view = setupView
+		return m, nil
+	}
+	if cmd.Guide != nil && !cmd.confirmsForm() {
+		var init tea.Cmd
+		m.guide, init = NewGuideModel(cmd)
+		m.view = guideView
+		return m, init
+	}
 	if len(cmd.Inputs) > 0 {
-		m.input = NewInputModel(cmd)
-		m.input.width = m.width
-		m.input.height = m.height
-		m.view = inputView
-		return m, m.input.Init()
+		return m.openInput(cmd, commandView)
+	}
+	return m.execute(cmd, nil)
+}
+
+// openBrowser opens the interactive backup browser.
+func (m Model) openBrowser() (Model, tea.Cmd) {
+	m.browser = NewBrowserModel(m.version, true)
+	m.browser.width = m.width
+	m.browser.height = m.height
+	m.view = browserView
+	return m, m.browser.Init()
+}
+
+// openInput shows the input form for cmd; cancelling returns to back.
+func (m Model) openInput(cmd Command, back viewState) (Model, tea.Cmd) {
+	m.input = NewInputModel(cmd)
+	m.input = m.input.resize(m.width, m.height)
+	m.inputBack = back
+	m.view = inputView
+	return m, m.input.Init()
+}
+
+// execute runs a command, streaming its progress when it supports it. A
+// command that reads backups is held back while no destination is
+// mounted, and one that should not run during a backup while one is
+// running.
+func (m Model) execute(cmd Command, args []string) (Model, tea.Cmd) {
+	if held, unmounted := m.checkMounted(cmd, args); unmounted {
+		return held, nil
+	}
+	if held, busy := m.checkBusy(cmd, args); busy {
+		return held, nil
+	}
+	return m.launch(cmd, args)
+}
+
+// launch runs a command without checking for a running backup.
+func (m Model) launch(cmd Command, args []string) (Model, tea.Cmd) {
+	m.lastCmd = cmd
+	m.lastArgs = args
+	// A stray . must not repeat a delete, restore or other change, so only
+	// commands that need no root privileges are offered again.
+	if !cmd.RequiresRoot {
+		m.repeatCmd, m.repeatArgs = &cmd, args
+	}
+	m = m.beginRun(cmd.Timeout)
+	if cmd.Stream != nil {
+		return m.startStream(cmd, args)
+	}
+	m.spinner = newSpinner()
+	m.view = runningView
+	return m, tea.Batch(m.executeWithArgs(cmd, args), m.spinner.Tick)
+}
+
+// scheduleRefresh queues the next live refresh of the output view.
+func (m Model) scheduleRefresh() tea.Cmd {
+	gen := m.refreshGen
+	return tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{gen: gen}
+	})
+}
+
+// runRefresh re-runs the last command's Refresh function.
+func (m Model) runRefresh() tea.Cmd {
+	gen, refresh := m.refreshGen, m.lastCmd.Refresh
+	return func() tea.Msg {
+		output, raw, live, err := refresh()
+		return refreshResultMsg{gen: gen, output: output, raw: raw, live: live, err: err}
+	}
+}
+
+// showOutput puts the report or, when toggled, its raw form in the output
+// view.
+func (m Model) showOutput() Model {
+	if m.showRaw && m.rawOutput != "" {
+		m.output = m.rawOutput
+	} else {
+		m.output = m.report
 	}
-	m.view = outputView
-	return m, m.executeWithArgs(cmd, nil)
+	return m
 }
 
 func (m Model) executeWithArgs(cmd Command, args []string) tea.Cmd {
+	gen := m.runGen
 	return func() tea.Msg {
+		if cmd.Sized != nil {
+			list, err := cmd.Sized(args, nil)
+			return commandResultMsg{gen: gen, output: list.String(), sized: list, err: err}
+		}
 		output, err := cmd.Execute(args)
-		return commandResultMsg{output: output, err: err}
+		return commandResultMsg{gen: gen, output: output, err: err}
+	}
+}
+
+// beginRun gives the next command its own cancellable context, which
+// also expires after timeout when that is set.
+func (m Model) beginRun(timeout time.Duration) Model {
+	m = m.endRun()
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(tmutil.BaseContext(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(tmutil.BaseContext())
+	}
+	tmutil.SetContext(ctx)
+	m.cancel = cancel
+	m.runGen++
+	m.runStart = time.Now()
+	m.stopped = nil
+	m.menuNote = ""
+	return m
+}
+
+// endRun releases the context of the command that just finished.
+func (m Model) endRun() Model {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+		tmutil.SetContext(nil)
 	}
+	return m
+}
+
+// cancelRun aborts the running command, which kills any tmutil process
+// it started, and returns to the command menu.
+func (m Model) cancelRun() Model {
+	m = m.endRun()
+	m.runGen++ // drop the aborted command's result
+	if m.stream != nil {
+		go func(ch <-chan tea.Msg) {
+			for range ch {
+			}
+		}(m.stream)
+		m.stream = nil
+	}
+	return m.cancelled()
+}
+
+// cancelled returns to the command menu from a cancelled command.
+func (m Model) cancelled() Model {
+	m.stopping = false
+	m.output = ""
+	m.err = nil
+	m.view = commandView
+	m.menuNote = fmt.Sprintf("%s cancelled.", m.lastCmd.Title)
+	return m
+}
+
+// timedOut reports whether the last command was stopped by its timeout,
+// so r can run it again with a longer one.
+func (m Model) timedOut() bool {
+	return m.lastCmd.Timeout > 0 && (errors.Is(m.err, tmutil.ErrTimeout) || errors.Is(m.stopped, tmutil.ErrTimeout))
 }
 
 // --- Output view ---
 
 func (m Model) updateOutput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c":
+	switch k := msg.String(); {
+	case k == "ctrl+c":
 		return m, tea.Quit
-	case "q":
+	case bindings.is(k, actQuit):
 		return m, tea.Quit
-	case "esc", "backspace", "b":
+	case bindings.is(k, actBack):
 		m.view = commandView
 		m.output = ""
 		m.err = nil
-		m.scrollOffset = 0
-	case "up", "k":
-		if m.scrollOffset > 0 {
-			m.scrollOffset--
+		m.scrollOffset, m.following = 0, false
+		m.outputNote = ""
+	case bindings.is(k, actCopy):
+		if m.hasLineCursor() {
+			m = m.copyCursorPath()
 		}
-	case "down", "j":
-		lines := strings.Split(m.output, "\n")
-		maxOff := len(lines) - m.outputPageSize()
-		if maxOff < 0 {
-			maxOff = 0
+	case bindings.is(k, actOpen):
+		if m.hasLineCursor() {
+			m = m.openCursorPath()
 		}
-		if m.scrollOffset < maxOff {
-			m.scrollOffset++
+	case bindings.is(k, actCmdLine):
+		if FindCommand(m.lastCmd.ID) != nil {
+			m.outputNote = copyCommandLine(m.lastCmd, m.lastArgs)
 		}
-	case "pgup":
-		m.scrollOffset -= m.outputPageSize()
-		if m.scrollOffset < 0 {
-			m.scrollOffset = 0
+	case bindings.is(k, actWiden):
+		if m.lastCmd.Widen != nil && m.err == nil {
+			return m.execute(m.lastCmd, m.lastCmd.Widen(m.lastArgs))
 		}
-	case "pgdown", " ":
-		lines := strings.Split(m.output, "\n")
-		maxOff := len(lines) - m.outputPageSize()
-		if maxOff < 0 {
-			maxOff = 0
+	case bindings.is(k, actRefresh):
+		if m.lastCmd.Refresh != nil {
+			m.refreshGen++
+			return m, m.runRefresh()
+		}
+		if m.timedOut() {
+			retry := m.lastCmd
+			retry.Timeout *= 2
+			return m.execute(retry, m.lastArgs)
+		}
+	case bindings.is(k, actTimes):
+		if m.lastCmd.Timestamps && m.err == nil {
+			m.relativeTimes = !m.relativeTimes
+			tmutil.SetRelativeTimes(m.relativeTimes)
+			m.outputNote = ""
+			if err := saveRelativeTimes(m.relativeTimes); err != nil {
+				m.outputNote = fmt.Sprintf("Could not save the preference: %v", err)
+			}
+			if m.lastCmd.Refresh != nil {
+				m.refreshGen++
+				return m, m.runRefresh()
+			}
+			return m.execute(m.lastCmd, m.lastArgs)
+		}
+	case bindings.is(k, actRaw):
+		if m.lastCmd.Refresh != nil && m.err == nil {
+			m.showRaw = !m.showRaw
+			m.scrollOffset, m.following = 0, false
+			if m.rawOutput == "" {
+				// The raw form arrives with the next refresh.
+				m.refreshGen++
+				return m, m.runRefresh()
+			}
+			m = m.showOutput()
+		}
+	case bindings.is(k, actSize):
+		if m.sized.Sortable() && m.err == nil {
+			m = m.sortBySize()
+		}
+	case bindings.is(k, actCmdHelp):
+		m = m.openCommandHelp()
+	case bindings.is(k, actPin):
+		if m.err == nil && FindCommand(m.lastCmd.ID) != nil {
+			return m.openInput(pinCommand(m.lastCmd.ID, m.lastArgs), outputView)
+		}
+	case bindings.is(k, actUp):
+		if m.hasLineCursor() {
+			m = m.moveLineCursor(-1)
+		} else {
+			m = m.scrollOutput(-1, strings.Count(m.output, "\n")+1)
+		}
+	case bindings.is(k, actDown):
+		if m.hasLineCursor() {
+			m = m.moveLineCursor(1)
+		} else {
+			m = m.scrollOutput(1, strings.Count(m.output, "\n")+1)
+		}
+	case bindings.is(k, actPageUp):
+		if m.hasLineCursor() {
+			m = m.pageLineCursor(-m.outputPageSize())
+		} else {
+			m = m.scrollOutput(-m.outputPageSize(), strings.Count(m.output, "\n")+1)
 		}
-		m.scrollOffset += m.outputPageSize()
-		if m.scrollOffset > maxOff {
-			m.scrollOffset = maxOff
+	case bindings.is(k, actPageDown):
+		if m.hasLineCursor() {
+			m = m.pageLineCursor(m.outputPageSize())
+		} else {
+			m = m.scrollOutput(m.outputPageSize(), strings.Count(m.output, "\n")+1)
 		}
 	}
 	return m, nil
 }
 
 func (m Model) outputPageSize() int {
-	ps := m.height - 12
-	if ps < 5 {
-		ps = 5
+	if m.view == streamView || m.stopped != nil {
+		// The live stream has a status line above the help line, and
+		// partial output a line saying so.
+		return pageSize(m.height, 13)
 	}
-	return ps
+	return pageSize(m.height, 12)
+}
+
+// scrollOutput moves the output view by delta of n lines. Reaching the
+// last page turns following on, so lines that arrive later stay in view;
+// scrolling up turns it off, so they don't pull the view away.
+func (m Model) scrollOutput(delta, n int) Model {
+	maxOff := max(n-m.outputPageSize(), 0)
+	m.scrollOffset = min(max(m.outputOffset(n)+delta, 0), maxOff)
+	m.following = m.scrollOffset == maxOff
+	return m
+}
+
+// outputOffset is the first of n output lines shown: the last page while
+// following, else the scroll offset. Following is resolved here, when the
+// view is drawn, so a burst of lines scrolls once per frame, not per line.
+func (m Model) outputOffset(n int) int {
+	maxOff := max(n-m.outputPageSize(), 0)
+	if m.following {
+		return maxOff
+	}
+	return min(m.scrollOffset, maxOff)
 }
 
 // --- Monitor view ---
 
 func (m Model) updateMonitor(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		switch keyMsg.String() {
-		case "esc", "backspace", "b":
+		switch k := keyMsg.String(); {
+		case bindings.is(k, actBack):
 			m.view = commandView
+			if m.monitorFromDashboard {
+				m.monitorFromDashboard = false
+				return m.openDashboard()
+			}
 			return m, nil
-		case "q", "ctrl+c":
+		case k == "ctrl+c", bindings.is(k, actQuit):
 			return m, tea.Quit
 		}
 	}
@@ -340,9 +956,66 @@ func (m Model) updateMonitor(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// resizeActive passes a terminal resize on to the sub-model of the
+// current view, so that it lays itself out again at once rather than on
+// its next key or update. The input form is resized even while hidden
+// behind its help, which returns to it.
+func (m Model) resizeActive(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
+	m.input = m.input.resize(msg.Width, msg.Height)
+	switch m.view {
+	case monitorView:
+		return m.updateMonitor(msg)
+	case deleteView:
+		return m.updateDelete(msg)
+	case browserView:
+		return m.updateBrowser(msg)
+	case setupView:
+		m.setup.width, m.setup.height = msg.Width, msg.Height
+	case guideView:
+		m.guide.width, m.guide.height = msg.Width, msg.Height
+	}
+	return m, nil
+}
+
+// --- Delete view ---
+
+func (m Model) updateDelete(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.deleter.Update(msg)
+	m.deleter = updated.(DeleteModel)
+	return m, cmd
+}
+
+// --- Browser view ---
+
+func (m Model) updateBrowser(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.browser.Update(msg)
+	m.browser = updated.(BrowserModel)
+	return m, cmd
+}
+
+// --- Setup view ---
+
+func (m Model) updateSetup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.setup, cmd = m.setup.Update(msg)
+	return m, cmd
+}
+
+// --- Guide view ---
+
+func (m Model) updateGuide(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.guide, cmd = m.guide.Update(msg)
+	return m, cmd
+}
+
 // --- Input view ---
 
 func (m Model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A typed character belongs to a text field; keys such as f1 don't.
+	if bindings.is(msg.String(), actCmdHelp) && (msg.Type != tea.KeyRunes || !m.input.typing()) && m.contextCommand() != nil {
+		return m.openCommandHelp(), nil
+	}
 	updated, cmd := m.input.Update(msg)
 	m.input = updated
 	return m, cmd
@@ -351,14 +1024,12 @@ func (m Model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // --- Version view ---
 
 func (m Model) updateVersion(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c":
+	switch k := msg.String(); {
+	case k == "ctrl+c", bindings.is(k, actQuit):
 		return m, tea.Quit
-	case "esc", "backspace", "b":
+	case bindings.is(k, actBack):
 		m.view = categoryView
 		return m, nil
-	case "q":
-		return m, tea.Quit
 	}
 	return m, nil
 }
@@ -373,42 +1044,37 @@ func (m Model) renderVersion() string {
 	fmt.Fprintf(&info, "Time Machine CLI %s\n\n", m.version)
 	info.WriteString("Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)\n")
 	info.WriteString("Licensed under the MIT License.")
-	b.WriteString(outputStyle.Render(info.String()))
+	b.WriteString(frameStyle(m.height).Render(info.String()))
 
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("b/esc: back • q: quit"))
+	b.WriteString(helpStyle.Render(bindings.backQuitHelp()))
 
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+	return place(m.width, m.height, b.String())
 }
 
 // --- Help views ---
 
 func (m Model) updateHelpCategory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	cats := Categories()
+	cats := MenuCategories()
 	backIdx := len(cats)
 	quitIdx := backIdx + 1
 	count := quitIdx + 1
-	switch msg.String() {
-	case "ctrl+c":
+	switch k := msg.String(); {
+	case k == "ctrl+c":
 		return m, tea.Quit
-	case "esc", "backspace":
-		m.view = categoryView
-		return m, nil
-	case "up", "k":
+	case bindings.is(k, actUp):
 		if m.helpCursor > 0 {
 			m.helpCursor--
 		} else {
 			m.helpCursor = count - 1
 		}
-	case "down", "j":
+	case bindings.is(k, actDown):
 		if m.helpCursor < count-1 {
 			m.helpCursor++
 		} else {
 			m.helpCursor = 0
 		}
-	case "enter":
+	case bindings.is(k, actSelect):
 		switch m.helpCursor {
 		case quitIdx:
 			return m, tea.Quit
@@ -420,14 +1086,14 @@ func (m Model) updateHelpCategory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.helpCmdCursor = 0
 			return m, nil
 		}
-	case "b":
+	case bindings.is(k, actBack):
 		m.view = categoryView
 		return m, nil
-	case "q":
+	case bindings.is(k, actQuit):
 		return m, tea.Quit
 	default:
 		for i, cat := range cats {
-			if msg.String() == cat.Hotkey {
+			if k == cat.Hotkey {
 				m.helpCursor = i
 				m.view = helpCommandView
 				m.helpCmdCursor = 0
@@ -439,30 +1105,27 @@ func (m Model) updateHelpCategory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateHelpCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	cats := Categories()
+	cats := MenuCategories()
 	cmds := cats[m.helpCursor].Commands
 	backIdx := len(cmds)
 	quitIdx := backIdx + 1
 	count := quitIdx + 1
-	switch msg.String() {
-	case "ctrl+c":
+	switch k := msg.String(); {
+	case k == "ctrl+c":
 		return m, tea.Quit
-	case "esc", "backspace":
-		m.view = helpCategoryView
-		return m, nil
-	case "up", "k":
+	case bindings.is(k, actUp):
 		if m.helpCmdCursor > 0 {
 			m.helpCmdCursor--
 		} else {
 			m.helpCmdCursor = count - 1
 		}
-	case "down", "j":
+	case bindings.is(k, actDown):
 		if m.helpCmdCursor < count-1 {
 			m.helpCmdCursor++
 		} else {
 			m.helpCmdCursor = 0
 		}
-	case "enter":
+	case bindings.is(k, actSelect):
 		switch m.helpCmdCursor {
 		case quitIdx:
 			return m, tea.Quit
@@ -471,18 +1134,20 @@ func (m Model) updateHelpCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		default:
 			m.helpOutput = BuildCommandHelp(cmds[m.helpCmdCursor])
+			m.helpBack = helpCommandView
 			m.view = helpDetailView
 			return m, nil
 		}
-	case "b":
+	case bindings.is(k, actBack):
 		m.view = helpCategoryView
 		return m, nil
-	case "q":
+	case bindings.is(k, actQuit):
 		return m, tea.Quit
 	default:
 		for i, cmd := range cmds {
-			if msg.String() == cmd.Hotkey {
+			if k == cmd.Hotkey {
 				m.helpOutput = BuildCommandHelp(cmds[i])
+				m.helpBack = helpCommandView
 				m.view = helpDetailView
 				return m, nil
 			}
@@ -492,28 +1157,82 @@ func (m Model) updateHelpCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateHelpDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c":
+	switch k := msg.String(); {
+	case k == "ctrl+c", bindings.is(k, actQuit):
 		return m, tea.Quit
-	case "esc", "backspace", "b":
-		m.view = helpCommandView
+	case bindings.is(k, actBack):
+		m.view = m.helpBack
+		switch m.view {
+		case inputView:
+			return m, m.input.Init()
+		case outputView:
+			// Live refresh stopped while the help was shown.
+			if m.lastCmd.Refresh != nil {
+				m.refreshGen++
+				return m, m.runRefresh()
+			}
+		}
 		return m, nil
-	case "q":
-		return m, tea.Quit
 	}
 	return m, nil
 }
 
+// contextCommand is the command whose input form or output is shown, if
+// it has help: a saved command's name form belongs to no command.
+func (m Model) contextCommand() *Command {
+	id := m.lastCmd.ID
+	if m.view == inputView {
+		id = m.input.command.ID
+	}
+	return FindCommand(id)
+}
+
+// cmdHelpHint names the command help key for a footer, or is "" when the
+// command in context has no help.
+func (m Model) cmdHelpHint() string {
+	if m.contextCommand() == nil {
+		return ""
+	}
+	return bindings.help(actCmdHelp) + ": help • "
+}
+
+// openCommandHelp shows the full help for the command in context, and
+// returns to the current view when it is closed.
+func (m Model) openCommandHelp() Model {
+	cmd := m.contextCommand()
+	if cmd == nil {
+		return m
+	}
+	m.helpOutput = BuildCommandHelp(*cmd)
+	m.helpBack = m.view
+	m.view = helpDetailView
+	return m
+}
+
 // renderTitle renders a bordered title with the version centered below it inside the box.
 func (m Model) renderTitle(title string) string {
 	content := lipgloss.JoinVertical(lipgloss.Center, title, m.version)
-	return titleStyle.Render(content)
+	if m.isRoot {
+		content = lipgloss.JoinVertical(lipgloss.Center, content, errorStyle.Render("running as root"))
+	}
+	return headerStyle(m.height).Render(content)
 }
 
 // --- Views ---
 
 // View implements tea.Model.
 func (m Model) View() string {
+	view := m.render()
+	// The monitor and the dashboard show the same state, and a compact
+	// layout has no spare line.
+	if m.view != monitorView && m.view != dashboardView && !isCompact(m.height) {
+		view = withStatusBar(view, m.statusBar, m.width)
+	}
+	return tmutil.ToASCII(tmutil.Redact(view))
+}
+
+// render draws the current view.
+func (m Model) render() string {
 	switch m.view {
 	case categoryView:
 		return m.renderCategory()
@@ -523,6 +1242,32 @@ func (m Model) View() string {
 		return m.renderOutput()
 	case monitorView:
 		return m.renderMonitor()
+	case deleteView:
+		return m.renderDelete()
+	case streamView:
+		return m.renderStream()
+	case runningView:
+		return m.renderRunning()
+	case busyView:
+		return m.renderBusy()
+	case unmountedView:
+		return m.renderUnmounted()
+	case dashboardView:
+		return m.renderDashboard()
+	case savedView:
+		return m.renderSaved()
+	case browserView:
+		m.browser.width = m.width
+		m.browser.height = m.height
+		return m.browser.View()
+	case setupView:
+		m.setup.width = m.width
+		m.setup.height = m.height
+		return m.setup.View()
+	case guideView:
+		m.guide.width = m.width
+		m.guide.height = m.height
+		return m.guide.View()
 	case inputView:
 		return m.input.View()
 	case versionView:
@@ -543,42 +1288,59 @@ func (m Model) renderCategory() string {
 	b.WriteString(m.renderTitle("Time Machine CLI"))
 	b.WriteString("\n\n")
 
-	versionIdx := len(m.categories)
+	dashboardIdx := len(m.categories)
+	savedIdx := dashboardIdx + 1
+	versionIdx := savedIdx + 1
 	helpIdx := versionIdx + 1
 	quitIdx := helpIdx + 1
 
 	var menu strings.Builder
+	var items []string
 	for i, cat := range m.categories {
 		if i == m.catCursor {
-			fmt.Fprintf(&menu, "> [%s] %s\n", cat.Hotkey, cat.Title)
+			items = append(items, fmt.Sprintf("> [%s] %s", cat.Hotkey, cat.Title))
 		} else {
-			fmt.Fprintf(&menu, "  [%s] %s\n", cat.Hotkey, cat.Title)
+			items = append(items, fmt.Sprintf("  [%s] %s", cat.Hotkey, cat.Title))
 		}
 	}
+	itemW := m.categoryMenuWidth()
+	_, rows := m.menuColumns(len(items), itemW)
+	menu.WriteString(layoutColumns(items, rows, itemW))
 	menu.WriteString("\n")
+	if m.catCursor == dashboardIdx {
+		fmt.Fprintf(&menu, "> [%s] Dashboard\n", bindings.key(actDashboard))
+	} else {
+		fmt.Fprintf(&menu, "  [%s] Dashboard\n", bindings.key(actDashboard))
+	}
+	if m.catCursor == savedIdx {
+		fmt.Fprintf(&menu, "> [%s] Saved\n", bindings.key(actSaved))
+	} else {
+		fmt.Fprintf(&menu, "  [%s] Saved\n", bindings.key(actSaved))
+	}
 	if m.catCursor == versionIdx {
-		fmt.Fprintf(&menu, "> [v] Version\n")
+		fmt.Fprintf(&menu, "> [%s] Version\n", bindings.key(actVersion))
 	} else {
-		fmt.Fprintf(&menu, "  [v] Version\n")
+		fmt.Fprintf(&menu, "  [%s] Version\n", bindings.key(actVersion))
 	}
 	if m.catCursor == helpIdx {
-		fmt.Fprintf(&menu, "> [h] Help\n")
+		fmt.Fprintf(&menu, "> [%s] Help\n", bindings.key(actHelp))
 	} else {
-		fmt.Fprintf(&menu, "  [h] Help\n")
+		fmt.Fprintf(&menu, "  [%s] Help\n", bindings.key(actHelp))
 	}
 	if m.catCursor == quitIdx {
-		fmt.Fprintf(&menu, "> [q] Quit\n")
+		fmt.Fprintf(&menu, "> [%s] Quit\n", bindings.key(actQuit))
 	} else {
-		fmt.Fprintf(&menu, "  [q] Quit\n")
+		fmt.Fprintf(&menu, "  [%s] Quit\n", bindings.key(actQuit))
 	}
-	b.WriteString(outputStyle.Render(menu.String()))
+	b.WriteString(frameStyle(m.height).Render(menu.String()))
 
+	cols, _ := m.menuColumns(len(items), itemW)
+	keys := bindings.navHelp(cols > 1)
+	keys += m.repeatHint()
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("↑/↓: navigate • enter/hotkey: select"))
+	b.WriteString(helpStyle.Render(keys))
 
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+	return place(m.width, m.height, b.String())
 }
 
 func (m Model) renderCommand() string {
@@ -598,15 +1360,11 @@ func (m Model) renderCommand() string {
 	}
 
 	// Calculate max item width for right-aligned asterisks
-	maxW := len("  [b] Back")
-	for _, cmd := range cat.Commands {
-		w := len(fmt.Sprintf("  [%s] %s", cmd.Hotkey, cmd.Title))
-		if w > maxW {
-			maxW = w
-		}
-	}
+	maxW := commandMenuWidth(cat) - 2
+	cols, rows := m.menuColumns(len(cat.Commands), maxW+2)
 
 	var menu strings.Builder
+	var items []string
 	for i, cmd := range cat.Commands {
 		var line string
 		if i == m.cmdCursor {
@@ -616,22 +1374,25 @@ func (m Model) renderCommand() string {
 		}
 		if hasRoot {
 			if cmd.RequiresRoot {
-				fmt.Fprintf(&menu, "%-*s *\n", maxW, line)
+				line = fmt.Sprintf("%-*s *", maxW, line)
+				if !m.isRoot && i != m.cmdCursor {
+					line = helpStyle.Render(line)
+				}
 			} else {
-				fmt.Fprintf(&menu, "%-*s  \n", maxW, line)
+				line = fmt.Sprintf("%-*s  ", maxW, line)
 			}
-		} else {
-			fmt.Fprintf(&menu, "%s\n", line)
 		}
+		items = append(items, line)
 	}
+	menu.WriteString(layoutColumns(items, rows, maxW+2))
 	menu.WriteString("\n")
-	backLine := "  [b] Back"
+	backLine := "  [" + bindings.key(actBack) + "] Back"
 	if m.cmdCursor == len(cat.Commands) {
-		backLine = "> [b] Back"
+		backLine = "> [" + bindings.key(actBack) + "] Back"
 	}
-	quitLine := "  [q] Quit"
+	quitLine := "  [" + bindings.key(actQuit) + "] Quit"
 	if m.cmdCursor == len(cat.Commands)+1 {
-		quitLine = "> [q] Quit"
+		quitLine = "> [" + bindings.key(actQuit) + "] Quit"
 	}
 	if hasRoot {
 		fmt.Fprintf(&menu, "%-*s  \n", maxW, backLine)
@@ -640,19 +1401,27 @@ func (m Model) renderCommand() string {
 		fmt.Fprintf(&menu, "%s\n", backLine)
 		fmt.Fprintf(&menu, "%s\n", quitLine)
 	}
-	b.WriteString(outputStyle.Render(menu.String()))
+	b.WriteString(frameStyle(m.height).Render(menu.String()))
 
 	if hasRoot {
 		b.WriteString("\n")
-		b.WriteString(helpStyle.Render("* requires root"))
+		if m.isRoot {
+			b.WriteString(helpStyle.Render("* requires root • ") + errorStyle.Render("running as root"))
+		} else {
+			b.WriteString(helpStyle.Render("* requires root (run with sudo)"))
+		}
 	}
 
+	if m.menuNote != "" {
+		b.WriteString("\n\n")
+		b.WriteString(m.menuNote)
+	}
+	keys := bindings.navHelp(cols > 1) + " • " + bindings.help(actBack) + ": back"
+	keys += m.repeatHint()
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("↑/↓: navigate • enter/hotkey: select • esc: back"))
+	b.WriteString(helpStyle.Render(keys))
 
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+	return place(m.width, m.height, b.String())
 }
 
 func (m Model) renderOutput() string {
@@ -663,33 +1432,96 @@ func (m Model) renderOutput() string {
 
 	if m.err != nil {
 		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		keys := bindings.help(actCmdLine) + ": copy command • " + m.cmdHelpHint() + bindings.backQuitHelp()
+		if m.outputNote != "" {
+			keys = m.outputNote + "\n" + keys
+		}
+		if m.timedOut() {
+			b.WriteString("\n\n")
+			b.WriteString(wordWrap(fmt.Sprintf("%s was stopped after %s. How long it takes is hard to predict, so it may just need longer.",
+				m.lastCmd.Title, tmutil.FormatDuration(m.lastCmd.Timeout)), 70))
+			keys = fmt.Sprintf("%s: run again with %s • ", bindings.help(actRefresh), tmutil.FormatDuration(2*m.lastCmd.Timeout)) + keys
+		} else if hint := Remediation(m.err); hint != "" {
+			b.WriteString("\n\n")
+			b.WriteString(wordWrap("Suggestion: "+hint, 70))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render(keys))
+	} else if isEmptyOutput(m.output) && m.lastCmd.Refresh == nil {
+		b.WriteString(frameStyle(m.height).Render(m.emptyState()))
 		b.WriteString("\n\n")
-		b.WriteString(helpStyle.Render("b/esc: back • q: quit"))
+		keys := bindings.help(actPin) + ": save command • " + bindings.help(actCmdLine) + ": copy command • " + m.cmdHelpHint() + bindings.backQuitHelp()
+		if m.outputNote != "" {
+			keys = m.outputNote + "\n" + keys
+		}
+		b.WriteString(helpStyle.Render(keys))
 	} else {
 		lines := strings.Split(m.output, "\n")
 		pageSize := m.outputPageSize()
+		keys := bindings.help(actPin) + ": save command • " + bindings.help(actCmdLine) + ": copy command • " + m.cmdHelpHint() + bindings.backQuitHelp()
+		if m.hasLineCursor() {
+			keys = bindings.help(actCopy) + ": copy path • " + bindings.help(actOpen) + ": open in Finder • " + keys
+		}
+		if m.lastCmd.Widen != nil {
+			keys = bindings.help(actWiden) + ": search more backups • " + keys
+		}
+		if m.sized.Sortable() {
+			keys = bindings.help(actSize) + ": " + m.sizeOrder.next().String() + " • " + keys
+		}
+		if m.lastCmd.Timestamps {
+			if m.relativeTimes {
+				keys = bindings.help(actTimes) + ": absolute times • " + keys
+			} else {
+				keys = bindings.help(actTimes) + ": relative times • " + keys
+			}
+		}
+		if m.lastCmd.Refresh != nil {
+			if m.showRaw {
+				keys = bindings.help(actRaw) + ": formatted • " + keys
+			} else {
+				keys = bindings.help(actRaw) + ": raw output • " + keys
+			}
+		}
+		if m.timedOut() {
+			keys = fmt.Sprintf("%s: run again with %s • ", bindings.help(actRefresh), tmutil.FormatDuration(2*m.lastCmd.Timeout)) + keys
+		}
+		if m.outputNote != "" {
+			keys = m.outputNote + "\n" + keys
+		}
+		if m.stopped != nil {
+			keys = m.stoppedNote() + "\n" + keys
+		}
 
+		if m.hasLineCursor() {
+			// Leave room for the cursor marker.
+			lines = fitLines(lines, m.width-frameOverhead(m.height)-2)
+		}
 		if len(lines) <= pageSize {
-			b.WriteString(outputStyle.Render(m.output))
+			if m.hasLineCursor() {
+				lines = m.markCursorLine(lines, 0)
+			}
+			b.WriteString(frameStyle(m.height).Render(strings.Join(lines, "\n")))
 			b.WriteString("\n\n")
-			b.WriteString(helpStyle.Render("b/esc: back • q: quit"))
+			b.WriteString(helpStyle.Render(keys))
 		} else {
-			end := m.scrollOffset + pageSize
-			if end > len(lines) {
-				end = len(lines)
+			start := m.scrollOffset
+			if !m.hasLineCursor() {
+				start = m.outputOffset(len(lines))
+			}
+			end := min(start+pageSize, len(lines))
+			page := lines[start:end]
+			if m.hasLineCursor() {
+				page = m.markCursorLine(page, start)
 			}
-			page := strings.Join(lines[m.scrollOffset:end], "\n")
-			b.WriteString(outputStyle.Render(page))
+			b.WriteString(frameStyle(m.height).Render(strings.Join(page, "\n")))
 			b.WriteString("\n\n")
 			b.WriteString(helpStyle.Render(
-				fmt.Sprintf("↑/↓: scroll • pgup/pgdn: page • lines %d–%d of %d • b/esc: back • q: quit",
-					m.scrollOffset+1, end, len(lines))))
+				fmt.Sprintf("↑/↓: scroll • pgup/pgdn: page • lines %d–%d of %d • %s",
+					start+1, end, len(lines), keys)))
 		}
 	}
 
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+	return place(m.width, m.height, b.String())
 }
 
 func (m Model) renderMonitor() string {
@@ -698,13 +1530,19 @@ func (m Model) renderMonitor() string {
 	return m.monitor.View()
 }
 
+func (m Model) renderDelete() string {
+	m.deleter.width = m.width
+	m.deleter.height = m.height
+	return m.deleter.View()
+}
+
 func (m Model) renderHelpCategory() string {
 	var b strings.Builder
 
 	b.WriteString(m.renderTitle("Help"))
 	b.WriteString("\n\n")
 
-	cats := Categories()
+	cats := MenuCategories()
 	var menu strings.Builder
 	for i, cat := range cats {
 		if i == m.helpCursor {
@@ -717,29 +1555,27 @@ func (m Model) renderHelpCategory() string {
 	backIdx := len(cats)
 	quitIdx := backIdx + 1
 	if m.helpCursor == backIdx {
-		fmt.Fprintf(&menu, "> [b] Back\n")
+		fmt.Fprintf(&menu, "> [%s] Back\n", bindings.key(actBack))
 	} else {
-		fmt.Fprintf(&menu, "  [b] Back\n")
+		fmt.Fprintf(&menu, "  [%s] Back\n", bindings.key(actBack))
 	}
 	if m.helpCursor == quitIdx {
-		fmt.Fprintf(&menu, "> [q] Quit\n")
+		fmt.Fprintf(&menu, "> [%s] Quit\n", bindings.key(actQuit))
 	} else {
-		fmt.Fprintf(&menu, "  [q] Quit\n")
+		fmt.Fprintf(&menu, "  [%s] Quit\n", bindings.key(actQuit))
 	}
-	b.WriteString(outputStyle.Render(menu.String()))
+	b.WriteString(frameStyle(m.height).Render(menu.String()))
 
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("↑/↓: navigate • enter/hotkey: select • esc: back"))
+	b.WriteString(helpStyle.Render(bindings.navHelp(false) + " • " + bindings.help(actBack) + ": back"))
 
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+	return place(m.width, m.height, b.String())
 }
 
 func (m Model) renderHelpCommand() string {
 	var b strings.Builder
 
-	cats := Categories()
+	cats := MenuCategories()
 	cat := cats[m.helpCursor]
 	b.WriteString(m.renderTitle("Help — " + cat.Title))
 	b.WriteString("\n\n")
@@ -754,7 +1590,7 @@ func (m Model) renderHelpCommand() string {
 	}
 
 	// Calculate max item width for right-aligned asterisks
-	maxW := len("  [b] Back")
+	maxW := len("  [" + bindings.key(actBack) + "] Back")
 	for _, cmd := range cat.Commands {
 		w := len(fmt.Sprintf("  [%s] %s", cmd.Hotkey, cmd.Title))
 		if w > maxW {
@@ -772,7 +1608,11 @@ func (m Model) renderHelpCommand() string {
 		}
 		if hasRoot {
 			if cmd.RequiresRoot {
-				fmt.Fprintf(&menu, "%-*s *\n", maxW, line)
+				line = fmt.Sprintf("%-*s *", maxW, line)
+				if !m.isRoot && i != m.cmdCursor {
+					line = helpStyle.Render(line)
+				}
+				fmt.Fprintf(&menu, "%s\n", line)
 			} else {
 				fmt.Fprintf(&menu, "%-*s  \n", maxW, line)
 			}
@@ -781,13 +1621,13 @@ func (m Model) renderHelpCommand() string {
 		}
 	}
 	menu.WriteString("\n")
-	backLine := "  [b] Back"
+	backLine := "  [" + bindings.key(actBack) + "] Back"
 	if m.helpCmdCursor == len(cat.Commands) {
-		backLine = "> [b] Back"
+		backLine = "> [" + bindings.key(actBack) + "] Back"
 	}
-	quitLine := "  [q] Quit"
+	quitLine := "  [" + bindings.key(actQuit) + "] Quit"
 	if m.helpCmdCursor == len(cat.Commands)+1 {
-		quitLine = "> [q] Quit"
+		quitLine = "> [" + bindings.key(actQuit) + "] Quit"
 	}
 	if hasRoot {
 		fmt.Fprintf(&menu, "%-*s  \n", maxW, backLine)
@@ -796,19 +1636,21 @@ func (m Model) renderHelpCommand() string {
 		fmt.Fprintf(&menu, "%s\n", backLine)
 		fmt.Fprintf(&menu, "%s\n", quitLine)
 	}
-	b.WriteString(outputStyle.Render(menu.String()))
+	b.WriteString(frameStyle(m.height).Render(menu.String()))
 
 	if hasRoot {
 		b.WriteString("\n")
-		b.WriteString(helpStyle.Render("* requires root"))
+		if m.isRoot {
+			b.WriteString(helpStyle.Render("* requires root • ") + errorStyle.Render("running as root"))
+		} else {
+			b.WriteString(helpStyle.Render("* requires root (run with sudo)"))
+		}
 	}
 
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("↑/↓: navigate • enter/hotkey: select • esc: back"))
+	b.WriteString(helpStyle.Render(bindings.navHelp(false) + " • " + bindings.help(actBack) + ": back"))
 
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+	return place(m.width, m.height, b.String())
 }
 
 func (m Model) renderHelpDetail() string {
@@ -816,11 +1658,9 @@ func (m Model) renderHelpDetail() string {
 
 	b.WriteString(m.renderTitle("Help"))
 	b.WriteString("\n\n")
-	b.WriteString(outputStyle.Render(m.helpOutput))
+	b.WriteString(frameStyle(m.height).Render(m.helpOutput))
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("b/esc: back • q: quit"))
+	b.WriteString(helpStyle.Render(bindings.backQuitHelp()))
 
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+	return place(m.width, m.height, b.String())
 }
@@ -21,7 +21,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-const pollInterval = 1 * time.Second
+const (
+	pollInterval     = 1 * time.Second
+	idlePollInterval = 5 * time.Second // used after idleBackoffTicks idle polls
+	idleBackoffTicks = 5
+)
 
 type statusTickMsg struct{}
 type statusUpdateMsg struct {
@@ -34,12 +38,33 @@ func pollStatus() tea.Msg {
 	return statusUpdateMsg{info: info, err: err}
 }
 
-func tickCmd() tea.Cmd {
-	return tea.Tick(pollInterval, func(time.Time) tea.Msg {
+func tickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
 		return statusTickMsg{}
 	})
 }
 
+// phaseSpan records when a backup phase was first observed.
+type phaseSpan struct {
+	phase string
+	start time.Time
+}
+
+// throughputSample is a (time, bytes copied) observation used to estimate
+// completion from the observed copy rate.
+type throughputSample struct {
+	at    time.Time
+	bytes int64
+}
+
+// thinningStall is how long a thinning phase may go without copy progress
+// before the monitor warns that it may be blocking the backup.
+const thinningStall = 10 * time.Minute
+
+// maxThroughputSamples bounds the regression window (about two minutes at
+// the default poll interval).
+const maxThroughputSamples = 120
+
 // MonitorModel is a Bubbletea model for monitoring backup progress.
 // It can be used standalone (CLI --monitor) or embedded in the TUI.
 type MonitorModel struct {
@@ -50,6 +75,16 @@ type MonitorModel struct {
 	height   int
 	done     bool // backup finished while monitoring
 	altScreen bool // true when running as full TUI
+	phases    []phaseSpan // phase transitions observed this session
+	doneAt    time.Time   // when the backup was seen to finish
+	idleTicks int         // consecutive polls with no backup running
+	samples   []throughputSample // recent copy progress for the rate estimate
+	firstETA  time.Time          // tmutil's first completion estimate this session
+	lastBytes int64              // BytesCopied at the previous poll
+	lastFiles int64              // FilesCopied at the previous poll
+	growing   bool               // copied counts rose between polls while the totals were still 0
+	movedAt   time.Time          // when the phase last changed or the copied bytes last rose
+	deadline  time.Time          // optional time the backup should finish by; zero when unset
 }
 
 // NewMonitorModel creates a monitor model.
@@ -57,6 +92,30 @@ func NewMonitorModel(version string, altScreen bool) MonitorModel {
 	return MonitorModel{version: version, altScreen: altScreen}
 }
 
+// WithDeadline makes the monitor compare its predicted completion with by
+// and warn when the backup looks set to finish late.
+func (m MonitorModel) WithDeadline(by time.Time) MonitorModel {
+	m.deadline = by
+	return m
+}
+
+// ParseDeadline reads a time of day such as "18:00", "6pm" or "6:30pm" as
+// its next occurrence after now: later today, or else tomorrow.
+func ParseDeadline(s string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{"15:04", "3:04pm", "3pm"} {
+		t, err := time.ParseInLocation(layout, strings.ToLower(strings.ReplaceAll(s, " ", "")), now.Location())
+		if err != nil {
+			continue
+		}
+		by := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !by.After(now) {
+			by = by.AddDate(0, 0, 1)
+		}
+		return by, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid deadline %q: expected a time of day such as 18:00 or 6pm", s)
+}
+
 // Init starts the first poll immediately.
 func (m MonitorModel) Init() tea.Cmd {
 	return pollStatus
@@ -71,8 +130,7 @@ func (m MonitorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c", "esc":
+		if k := msg.String(); k == "ctrl+c" || k == "esc" || bindings.is(k, actQuit) {
 			return m, tea.Quit
 		}
 
@@ -81,10 +139,46 @@ func (m MonitorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err == nil {
 			m.info = msg.info
 			if !m.info.Running {
+				if !m.done {
+					m.doneAt = time.Now()
+				}
 				m.done = true
+			} else {
+				now := time.Now()
+				if n := len(m.phases); n == 0 || m.phases[n-1].phase != m.info.Phase {
+					m.phases = append(m.phases, phaseSpan{phase: m.info.Phase, start: now})
+					m.movedAt = now
+				}
+				if m.info.BytesCopied > m.lastBytes {
+					m.movedAt = now
+				}
+				if m.info.HasProgress && m.info.TotalBytes > 0 {
+					m.samples = append(m.samples, throughputSample{at: now, bytes: m.info.BytesCopied})
+					if len(m.samples) > maxThroughputSamples {
+						m.samples = m.samples[len(m.samples)-maxThroughputSamples:]
+					}
+				}
+				// Early on tmutil reports 0 totals while already copying;
+				// note when the copied counts grow so progress can be shown.
+				if m.info.TotalBytes == 0 && m.info.TotalFiles == 0 {
+					if m.info.BytesCopied > m.lastBytes || m.info.FilesCopied > m.lastFiles {
+						m.growing = true
+					}
+				} else {
+					m.growing = false
+				}
+				m.lastBytes, m.lastFiles = m.info.BytesCopied, m.info.FilesCopied
+				if m.firstETA.IsZero() && m.info.TimeRemaining > 0 {
+					m.firstETA = now.Add(time.Duration(m.info.TimeRemaining) * time.Second)
+				}
 			}
 		}
-		return m, tickCmd()
+		if msg.err == nil && m.info.Running {
+			m.idleTicks = 0
+		} else {
+			m.idleTicks++
+		}
+		return m, tickCmd(m.interval())
 
 	case statusTickMsg:
 		return m, pollStatus
@@ -93,62 +187,106 @@ func (m MonitorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// interval returns the current poll interval, backing off while idle.
+func (m MonitorModel) interval() time.Duration {
+	if m.idleTicks >= idleBackoffTicks {
+		return idlePollInterval
+	}
+	return pollInterval
+}
+
+// updateHint describes the current poll interval for the help line.
+func (m MonitorModel) updateHint() string {
+	return fmt.Sprintf("updates every %s", m.interval())
+}
+
 // View renders the monitor.
 func (m MonitorModel) View() string {
+	return tmutil.ToASCII(tmutil.Redact(m.render()))
+}
+
+func (m MonitorModel) render() string {
 	body := m.renderBody()
 
 	if m.altScreen {
 		var b strings.Builder
-		content := lipgloss.JoinVertical(lipgloss.Center, "Backup Monitor", m.version)
-		b.WriteString(titleStyle.Render(content))
+		content := lipgloss.JoinVertical(lipgloss.Center, tmutil.T("monitor.title"), m.version)
+		b.WriteString(headerStyle(m.height).Render(content))
 		b.WriteString("\n\n")
-		b.WriteString(outputStyle.Render(body))
+		b.WriteString(frameStyle(m.height).Render(body))
 		b.WriteString("\n\n")
-		b.WriteString(helpStyle.Render("b/esc: back • q: quit • updates every 1s"))
-		return lipgloss.Place(m.width, m.height,
-			lipgloss.Center, lipgloss.Center,
-			b.String())
+		b.WriteString(helpStyle.Render(bindings.backQuitHelp() + " • " + m.updateHint()))
+		return place(m.width, m.height, b.String())
 	}
 
 	return body
 }
 
 // renderBody builds the monitor content as plain text so alignment is
-// consistent regardless of whether it is later wrapped by lipgloss.Place.
+// consistent regardless of whether it is later wrapped by place.
 func (m MonitorModel) renderBody() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error: %v", m.err)
 	}
 
 	if m.done {
-		return fmt.Sprintf("Backup complete.\n\n%s  100.0%%", renderProgressBar(1.0))
+		body := fmt.Sprintf("%s\n\n%s  100.0%%", tmutil.T("monitor.complete"), m.progressBar(1.0))
+		if acc := m.renderAccuracy(); acc != "" {
+			body += "\n\n" + acc
+		}
+		if dl := m.renderDeadline(); dl != "" {
+			body += "\n" + strings.TrimSuffix(dl, "\n")
+		}
+		if tl := m.renderPhases(); tl != "" {
+			body += "\n\n" + tl
+		}
+		return body
 	}
 
 	if !m.info.Running {
-		return fmt.Sprintf("No backup in progress. Waiting...\n\n%s    0.0%%", renderProgressBar(0))
+		return fmt.Sprintf("%s\n\n%s    0.0%%", tmutil.T("monitor.waiting"), m.progressBar(0))
 	}
 
 	var b strings.Builder
 
 	if m.info.Phase != "" {
-		fmt.Fprintf(&b, "Phase:       %s\n", m.info.Phase)
+		b.WriteString(monitorLine("status.phase", tmutil.DescribePhase(m.info.Phase)))
 	}
 	if m.info.Destination != "" {
-		fmt.Fprintf(&b, "Destination: %s\n", m.info.Destination)
+		b.WriteString(monitorLine("status.destination", m.info.Destination))
+	}
+	if stuck, ok := m.thinningStalled(time.Now()); ok {
+		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf(tmutil.T("monitor.thinning_stalled"), tmutil.FormatDuration(stuck.Round(time.Minute)))) + "\n")
 	}
 	b.WriteString("\n")
 
+	if !m.info.HasProgress {
+		// tmutil omits Progress while preparing or finishing; a 0% bar
+		// would look stuck, so describe the phase instead.
+		fmt.Fprintf(&b, "%s\n", phaseActivity(m.info.Phase))
+		b.WriteString(m.renderDeadline())
+		m.renderTimes(&b)
+		if !m.altScreen {
+			b.WriteString("\n" + bindings.help(actQuit) + ": quit • " + m.updateHint())
+		}
+		return b.String()
+	}
+
 	pct := m.info.Percent
-	fmt.Fprintf(&b, "%s  %.1f%%\n\n", renderProgressBar(pct), pct*100)
+	fmt.Fprintf(&b, "%s  %.1f%%\n\n", m.progressBar(pct), pct*100)
 
 	if m.info.TotalBytes > 0 {
-		fmt.Fprintf(&b, "Bytes:       %s / %s\n",
+		b.WriteString(monitorLine("status.bytes", fmt.Sprintf("%s / %s",
 			tmutil.FormatBytesInt64(m.info.BytesCopied),
-			tmutil.FormatBytesInt64(m.info.TotalBytes))
+			tmutil.FormatBytesInt64(m.info.TotalBytes))))
 	}
 	if m.info.TotalFiles > 0 {
-		fmt.Fprintf(&b, "Files:       %d / %d\n",
-			m.info.FilesCopied, m.info.TotalFiles)
+		b.WriteString(monitorLine("status.files", fmt.Sprintf("%d / %d",
+			m.info.FilesCopied, m.info.TotalFiles)))
+	}
+	if m.growing {
+		fmt.Fprintf(&b, "%s: %s, %d files (%s)\n", tmutil.T("monitor.copied_so_far"),
+			tmutil.FormatBytesInt64(m.info.BytesCopied), m.info.FilesCopied, tmutil.T("monitor.total_unknown"))
 	}
 	if m.info.TimeRemaining > 0 {
 		mins := int(m.info.TimeRemaining) / 60
@@ -156,42 +294,225 @@ func (m MonitorModel) renderBody() string {
 		mins = mins % 60
 		estimate := time.Now().Add(time.Duration(m.info.TimeRemaining) * time.Second)
 		if hrs > 0 {
-			fmt.Fprintf(&b, "Remaining:   %dh %dm [%s]\n", hrs, mins, estimate.Format("2006-01-02 15:04:05"))
+			b.WriteString(monitorLine("status.remaining", fmt.Sprintf("%dh %dm [%s]", hrs, mins, tmutil.FormatTime(estimate))))
 		} else {
-			fmt.Fprintf(&b, "Remaining:   %dm [%s]\n", mins, estimate.Format("2006-01-02 15:04:05"))
+			b.WriteString(monitorLine("status.remaining", fmt.Sprintf("%dm [%s]", mins, tmutil.FormatTime(estimate))))
 		}
 	} else {
-		fmt.Fprintf(&b, "Remaining:   Calculating...\n")
+		b.WriteString(monitorLine("status.remaining", tmutil.T("status.calculating")))
 	}
+	if eta, ok := m.rateETA(); ok {
+		b.WriteString(monitorLine("monitor.observed", fmt.Sprintf("%s [%s] at %s/s",
+			time.Until(eta).Round(time.Minute), tmutil.FormatTime(eta),
+			tmutil.FormatBytesInt64(int64(m.rate())))))
+	}
+	b.WriteString(m.renderDeadline())
+
+	m.renderTimes(&b)
 
+	if !m.altScreen {
+		b.WriteString("\n" + bindings.help(actQuit) + ": quit • " + m.updateHint())
+	}
+
+	return b.String()
+}
+
+// thinningStalled reports how long the backup has been thinning without
+// copy progress, once that exceeds thinningStall. Thinning that drags on
+// usually means the destination is short of space and Time Machine is
+// struggling to delete old backups to make room.
+func (m MonitorModel) thinningStalled(now time.Time) (time.Duration, bool) {
+	if !m.info.Running || !strings.Contains(m.info.Phase, "Thinning") || m.movedAt.IsZero() {
+		return 0, false
+	}
+	stuck := now.Sub(m.movedAt)
+	return stuck, stuck >= thinningStall
+}
+
+// rate returns the copy rate in bytes per second from a least-squares fit
+// of the recent samples, or 0 when there are too few to be meaningful.
+func (m MonitorModel) rate() float64 {
+	n := len(m.samples)
+	if n < 5 {
+		return 0
+	}
+	t0 := m.samples[0].at
+	var sumT, sumB float64
+	for _, s := range m.samples {
+		sumT += s.at.Sub(t0).Seconds()
+		sumB += float64(s.bytes)
+	}
+	meanT, meanB := sumT/float64(n), sumB/float64(n)
+	var cov, variance float64
+	for _, s := range m.samples {
+		dt := s.at.Sub(t0).Seconds() - meanT
+		cov += dt * (float64(s.bytes) - meanB)
+		variance += dt * dt
+	}
+	if variance == 0 || cov <= 0 {
+		return 0
+	}
+	return cov / variance
+}
+
+// rateETA estimates completion from the observed copy rate.
+func (m MonitorModel) rateETA() (time.Time, bool) {
+	r := m.rate()
+	remaining := m.info.TotalBytes - m.info.BytesCopied
+	if r <= 0 || remaining <= 0 {
+		return time.Time{}, false
+	}
+	return time.Now().Add(time.Duration(float64(remaining)/r) * time.Second), true
+}
+
+// predicted returns when the backup should finish: from the observed copy
+// rate when there are enough samples, otherwise from tmutil's estimate.
+func (m MonitorModel) predicted() (time.Time, bool) {
+	if eta, ok := m.rateETA(); ok {
+		return eta, true
+	}
+	if m.info.TimeRemaining > 0 {
+		return time.Now().Add(time.Duration(m.info.TimeRemaining) * time.Second), true
+	}
+	return time.Time{}, false
+}
+
+// renderDeadline compares the predicted (or, once done, the actual)
+// completion with the deadline, e.g. "Deadline:    18:00, on track, 25m
+// to spare". A predicted miss is shown as an error. It is empty without a
+// deadline.
+func (m MonitorModel) renderDeadline() string {
+	if m.deadline.IsZero() {
+		return ""
+	}
+	by := tmutil.FormatTime(m.deadline) + ", "
+	if m.done {
+		if m.doneAt.After(m.deadline) {
+			return monitorLine("monitor.deadline", by+fmt.Sprintf(tmutil.T("monitor.missed"), m.doneAt.Sub(m.deadline).Round(time.Minute)))
+		}
+		return monitorLine("monitor.deadline", by+fmt.Sprintf(tmutil.T("monitor.met"), m.deadline.Sub(m.doneAt).Round(time.Minute)))
+	}
+	eta, ok := m.predicted()
+	if !ok {
+		return monitorLine("monitor.deadline", by+tmutil.T("monitor.no_prediction"))
+	}
+	if eta.After(m.deadline) {
+		late := fmt.Sprintf(tmutil.T("monitor.will_miss"), tmutil.FormatTime(eta), eta.Sub(m.deadline).Round(time.Minute))
+		return tmutil.Label("monitor.deadline", 13) + errorStyle.Render(by+late) + "\n"
+	}
+	return monitorLine("monitor.deadline", by+fmt.Sprintf(tmutil.T("monitor.on_track"), m.deadline.Sub(eta).Round(time.Minute)))
+}
+
+// renderAccuracy compares tmutil's first completion estimate with the
+// actual completion time once the backup has finished.
+func (m MonitorModel) renderAccuracy() string {
+	if m.firstETA.IsZero() || m.doneAt.IsZero() {
+		return ""
+	}
+	off := m.doneAt.Sub(m.firstETA).Round(time.Second)
+	verdict := tmutil.T("monitor.late")
+	if off < 0 {
+		verdict = tmutil.T("monitor.early")
+		off = -off
+	}
+	return monitorLine("monitor.first_eta", tmutil.FormatTime(m.firstETA)) +
+		tmutil.Label("monitor.finished", 13) +
+		fmt.Sprintf("%s (%s %s)", tmutil.FormatTime(m.doneAt), off, verdict)
+}
+
+// renderPhases returns the phase timeline, e.g.
+// "Phases:      ThinningPreBackup (42s) → Copying (12m3s)".
+func (m MonitorModel) renderPhases() string {
+	if len(m.phases) == 0 {
+		return ""
+	}
+	end := time.Now()
+	if m.done {
+		end = m.doneAt
+	}
+	parts := make([]string, len(m.phases))
+	for i := len(m.phases) - 1; i >= 0; i-- {
+		p := m.phases[i]
+		name := p.phase
+		if name == "" {
+			name = "Unknown"
+		}
+		parts[i] = fmt.Sprintf("%s (%s)", name, end.Sub(p.start).Round(time.Second))
+		end = p.start
+	}
+	return tmutil.Label("monitor.phases", 13) + strings.Join(parts, " → ")
+}
+
+// renderTimes appends the started/current/elapsed block when the start time
+// is known, followed by the phase timeline.
+func (m MonitorModel) renderTimes(b *strings.Builder) {
 	if !m.info.StartedAt.IsZero() {
 		now := time.Now()
 		elapsed := now.Sub(m.info.StartedAt)
 		b.WriteString("\n")
-		fmt.Fprintf(&b, "Started:     %s\n", m.info.StartedAt.Local().Format("2006-01-02 15:04:05"))
-		fmt.Fprintf(&b, "Current:     %s\n", now.Format("2006-01-02 15:04:05"))
-		fmt.Fprintf(&b, "Elapsed:     %s\n", tmutil.FormatDuration(elapsed))
+		b.WriteString(monitorLine("status.started", tmutil.FormatTime(m.info.StartedAt.Local())))
+		b.WriteString(monitorLine("monitor.current", tmutil.FormatTime(now)))
+		b.WriteString(monitorLine("status.elapsed", tmutil.FormatDuration(elapsed)))
 	}
+	if tl := m.renderPhases(); tl != "" {
+		fmt.Fprintf(b, "%s\n", tl)
+	}
+}
 
-	if !m.altScreen {
-		b.WriteString("\nq: quit • updates every 1s")
+// monitorLine renders an aligned "Label:       value" monitor line.
+func monitorLine(id, value string) string {
+	return tmutil.Label(id, 13) + value + "\n"
+}
+
+// phaseActivity describes what a backup is doing in a phase that reports no progress.
+func phaseActivity(phase string) string {
+	switch phase {
+	case "Starting":
+		return "Starting…"
+	case "ThinningPreBackup":
+		return "Thinning old backups…"
+	case "MountingBackupVol", "MountingBackupVolForHealthCheck", "MountingDiskImage":
+		return "Mounting backup volume…"
+	case "PreparingSourceVolumes", "Preparing", "FindingChanges", "FindingBackupVol", "LazyThinning":
+		return "Preparing…"
+	case "Finishing", "ThinningPostBackup", "Stopping", "HealthCheckFsck", "HealthCheckCopyHFSMeta":
+		return "Finishing up…"
+	case "Copying":
+		return "Calculating…"
+	case "":
+		return "Working…"
 	}
+	return phase + "…"
+}
 
-	return b.String()
+// progressBar renders the progress bar at the width that fits the window:
+// progressBarWidth, or less in a narrow one, leaving room for the frame
+// and the percentage beside it.
+func (m MonitorModel) progressBar(percent float64) string {
+	width := progressBarWidth
+	if m.width > 0 {
+		width = max(10, min(progressBarWidth, m.width-frameOverhead(m.height)-10))
+	}
+	return renderProgressBarWidth(percent, width)
 }
 
 func renderProgressBar(percent float64) string {
+	return renderProgressBarWidth(percent, progressBarWidth)
+}
+
+// renderProgressBarWidth renders a bar width cells wide, inside brackets.
+func renderProgressBarWidth(percent float64, width int) string {
 	if percent < 0 {
 		percent = 0
 	}
 	if percent > 1 {
 		percent = 1
 	}
-	filled := int(float64(progressBarWidth) * percent)
-	empty := progressBarWidth - filled
+	filled := int(float64(width) * percent)
+	empty := width - filled
 
-	bar := progressFullStyle.Render(strings.Repeat("█", filled)) +
-		progressEmptyStyle.Render(strings.Repeat("░", empty))
+	bar := progressFullStyle.Render(tmutil.BarFull(filled)) +
+		progressEmptyStyle.Render(tmutil.BarEmpty(empty))
 
 	return fmt.Sprintf("[%s]", bar)
 }
@@ -0,0 +1,272 @@
+//
+// paths.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"tmcli/tmutil"
+)
+
+// absPathLine returns the absolute path on a line of findfile, findbydate
+// or listbackups output, dropping a trailing "  (size)" annotation.
+func absPathLine(output string, line int) string {
+	lines := strings.Split(output, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	l := strings.TrimSpace(lines[line])
+	if !strings.HasPrefix(l, "/") {
+		return ""
+	}
+	if i := strings.LastIndex(l, "  ("); i > 0 && strings.HasSuffix(l, ")") {
+		l = l[:i]
+	}
+	return l
+}
+
+// browsePathLine returns the path of the entry on a browsebackup output
+// line, joining the "Contents of <dir>" header with the entry name.
+func browsePathLine(output string, line int) string {
+	lines := strings.Split(output, "\n")
+	if line < 3 || line >= len(lines) || !strings.HasPrefix(lines[0], "Contents of ") {
+		return ""
+	}
+	entry := strings.TrimSpace(lines[line])
+	if entry == "" || !strings.HasPrefix(lines[line], "  ") {
+		return ""
+	}
+	// Entries are "name  size" or "name/  <dir>"; names may contain spaces.
+	if i := strings.LastIndex(entry, "  "); i > 0 {
+		entry = strings.TrimSpace(entry[:i])
+	}
+	entry = strings.TrimSuffix(entry, "/")
+	return filepath.Join(strings.TrimPrefix(lines[0], "Contents of "), entry)
+}
+
+// lastFieldPathLine returns the absolute path that ends a table row, such
+// as "  1.2 GB  fixed   /Users/me/VMs" in listexclusions; the columns
+// before it are separated by two or more spaces.
+func lastFieldPathLine(output string, line int) string {
+	lines := strings.Split(output, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	l := strings.TrimSpace(lines[line])
+	if strings.HasPrefix(l, "/") {
+		return l
+	}
+	if i := strings.Index(l, "  /"); i >= 0 {
+		return l[i+2:]
+	}
+	return ""
+}
+
+// copyToClipboard places text on the macOS clipboard via pbcopy.
+func copyToClipboard(text string) error {
+	cmd := exec.Command("pbcopy")
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("pbcopy: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// pasteFromClipboard returns the text on the macOS clipboard via pbpaste,
+// or "" when it cannot be read.
+func pasteFromClipboard() string {
+	out, err := exec.Command("pbpaste").Output()
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// clipboardBackupPath returns the path on the clipboard when it is an item
+// inside a backup, such as one copied from FindFile results, and "" for
+// anything else, so Restore is never prefilled with unrelated text.
+func clipboardBackupPath() string {
+	text := sanitizePath(pasteFromClipboard())
+	if strings.Contains(text, "\n") {
+		return ""
+	}
+	// A line copied whole from findfile output keeps its "  (size)".
+	path := absPathLine(text, 0)
+	if !tmutil.IsBackupPath(path) {
+		return ""
+	}
+	return path
+}
+
+// openInFinder reveals path in Finder via open(1).
+func openInFinder(path string) error {
+	if out, err := exec.Command("open", path).CombinedOutput(); err != nil {
+		return fmt.Errorf("open: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// --- Output line cursor ---
+
+// hasLineCursor reports whether the output view shows a line cursor, which
+// it does for successful output of commands that list paths.
+func (m Model) hasLineCursor() bool {
+	return m.lastCmd.LinePath != nil && m.err == nil
+}
+
+// cursorPath returns the path on the cursor line, or "".
+func (m Model) cursorPath() string {
+	if !m.hasLineCursor() {
+		return ""
+	}
+	return m.lastCmd.LinePath(m.output, m.lineCursor)
+}
+
+// resetLineCursor puts the cursor on the first line that holds a path.
+func (m Model) resetLineCursor() Model {
+	m.lineCursor = 0
+	m.outputNote = ""
+	if m.hasLineCursor() && m.cursorPath() == "" {
+		m = m.moveLineCursor(1)
+	}
+	return m
+}
+
+// moveLineCursor moves the cursor to the next line holding a path in
+// direction dir (+1 or -1), starting from the current line when it holds
+// none, and scrolls it into view. The cursor stays put if there is none.
+func (m Model) moveLineCursor(dir int) Model {
+	count := len(strings.Split(m.output, "\n"))
+	start := m.lineCursor + dir
+	if m.cursorPath() == "" {
+		start = m.lineCursor
+	}
+	for i := start; i >= 0 && i < count; i += dir {
+		if m.lastCmd.LinePath(m.output, i) != "" {
+			m.lineCursor = i
+			break
+		}
+	}
+	return m.followLineCursor()
+}
+
+// pageLineCursor moves the cursor by delta lines, then onto the nearest
+// line holding a path in that direction.
+func (m Model) pageLineCursor(delta int) Model {
+	count := len(strings.Split(m.output, "\n"))
+	m.lineCursor += delta
+	if m.lineCursor < 0 {
+		m.lineCursor = 0
+	}
+	if m.lineCursor > count-1 {
+		m.lineCursor = count - 1
+	}
+	if m.cursorPath() != "" {
+		return m.followLineCursor()
+	}
+	dir := 1
+	if delta < 0 {
+		dir = -1
+	}
+	if moved := m.moveLineCursor(dir); moved.cursorPath() != "" {
+		return moved
+	}
+	return m.moveLineCursor(-dir)
+}
+
+// followLineCursor scrolls the output so the cursor line is visible.
+func (m Model) followLineCursor() Model {
+	page := m.outputPageSize()
+	if m.lineCursor < m.scrollOffset {
+		m.scrollOffset = m.lineCursor
+	}
+	if m.lineCursor >= m.scrollOffset+page {
+		m.scrollOffset = m.lineCursor - page + 1
+	}
+	return m
+}
+
+// copyCursorPath copies the path on the cursor line to the clipboard.
+func (m Model) copyCursorPath() Model {
+	path := m.cursorPath()
+	if path == "" {
+		m.outputNote = "No path on this line."
+		return m
+	}
+	if err := copyToClipboard(path); err != nil {
+		m.outputNote = fmt.Sprintf("Copy failed: %v", err)
+		return m
+	}
+	m.outputNote = "Copied " + path
+	return m
+}
+
+// copyCommandLine puts the command line that runs cmd with args on the
+// clipboard, and returns a note saying what was copied.
+func copyCommandLine(cmd Command, args []string) string {
+	line := commandLine(cmd, args)
+	if err := copyToClipboard(line); err != nil {
+		return fmt.Sprintf("Copy failed: %v", err)
+	}
+	return "Copied " + line
+}
+
+// openCursorPath opens the path on the cursor line in Finder, provided it
+// exists (which also means its backup volume is mounted).
+func (m Model) openCursorPath() Model {
+	path := m.cursorPath()
+	if path == "" {
+		m.outputNote = "No path on this line."
+		return m
+	}
+	if _, err := os.Stat(path); err != nil {
+		m.outputNote = "Cannot open " + path + ": not found or not mounted."
+		return m
+	}
+	if err := openInFinder(path); err != nil {
+		m.outputNote = fmt.Sprintf("Open failed: %v", err)
+		return m
+	}
+	m.outputNote = "Opened " + path + " in Finder"
+	return m
+}
+
+// fitLines shortens lines wider than width by eliding their middle, so a
+// long path keeps its start and its file name and size. The output itself
+// is untouched, so the path under the cursor is still complete.
+func fitLines(lines []string, width int) []string {
+	if width <= 0 {
+		return lines
+	}
+	fitted := make([]string, len(lines))
+	for i, l := range lines {
+		fitted[i] = tmutil.TruncateMiddle(l, width)
+	}
+	return fitted
+}
+
+// markCursorLine highlights the cursor line among the displayed lines;
+// first is the index of lines[0] in the full output.
+func (m Model) markCursorLine(lines []string, first int) []string {
+	marked := make([]string, len(lines))
+	for i, l := range lines {
+		if first+i == m.lineCursor {
+			marked[i] = cursorLineStyle.Render("> " + l)
+		} else {
+			marked[i] = "  " + l
+		}
+	}
+	return marked
+}
@@ -0,0 +1,76 @@
+//
+// quota.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"tmcli/tmutil"
+)
+
+// quotaWarnFraction is the share of the quota (or disk) at which usage is
+// shown as an error.
+const quotaWarnFraction = 0.9
+
+// destinationUsage renders quota, space used and a usage bar for each
+// destination.
+func destinationUsage(_ []string) (string, error) {
+	usage, err := tmutil.GetDestinationUsage()
+	if err != nil {
+		return "", err
+	}
+	if len(usage) == 0 {
+		return "No destinations configured.", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("Destination Quota & Usage\n")
+	b.WriteString(tmutil.Rule(40) + "\n")
+	for _, u := range usage {
+		name := u.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		quota := "unlimited"
+		if u.Quota > 0 {
+			quota = tmutil.FormatBytesInt64(u.Quota)
+		}
+		fmt.Fprintf(&b, "\n  %s\n", name)
+		if u.ID != "" {
+			fmt.Fprintf(&b, "  ID:     %s\n", u.ID)
+		}
+		fmt.Fprintf(&b, "  Quota:  %s\n", quota)
+		fmt.Fprintf(&b, "  Used:   %s\n", tmutil.FormatBytesInt64(u.Used))
+		limit := u.Limit()
+		if limit <= 0 {
+			b.WriteString("  Usage:  unknown\n")
+			continue
+		}
+		fraction := float64(u.Used) / float64(limit)
+		of := "of disk"
+		if u.Quota > 0 {
+			of = "of quota"
+		}
+		line := fmt.Sprintf("%s %.0f%% %s", usageBar(fraction), fraction*100, of)
+		if fraction >= quotaWarnFraction {
+			line = errorStyle.Render(line)
+		}
+		fmt.Fprintf(&b, "  Usage:  %s\n", line)
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// usageBar is a plain block bar, so it can be restyled as a whole when
+// usage nears the limit.
+func usageBar(fraction float64) string {
+	return tmutil.ProgressBar(fraction, progressBarWidth/2)
+}
@@ -0,0 +1,68 @@
+//
+// remedy.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"tmcli/tmutil"
+)
+
+// remedies maps fragments of common tmutil error text (lowercased) to a
+// plain-language suggestion. The first matching entry wins.
+var remedies = []struct {
+	match      []string
+	suggestion string
+}{
+	{[]string{"not privileged", "must be run as root", "requires root", "permission denied"},
+		"This command needs administrator rights. Re-run it with sudo, e.g. 'sudo tmcli <command>'."},
+	{[]string{"full disk access", "operation not permitted"},
+		"Grant your terminal Full Disk Access in System Settings → Privacy & Security, then try again."},
+	{[]string{"no destinations configured", "no backup destination", "no destination"},
+		"No backup destination is set. Use Destinations → Set Destination, e.g. 'sudo tmcli setdestination /Volumes/Backup'."},
+	{[]string{"already running", "already in progress"},
+		"A backup is already running. Watch it with Backup → Monitor, or stop it with 'sudo tmcli stop'."},
+	{[]string{"unreachable", "not mounted", "unable to locate", "could not find", "no such file or directory", "no backups found"},
+		"The backup disk may not be connected. Connect or mount it, then try again."},
+	{[]string{"executable file not found"},
+		"tmutil was not found. tmcli must run on macOS with Time Machine available."},
+}
+
+// Remediation returns a suggested fix for a recognized error, or "" if the
+// error is not one of the common cases.
+func Remediation(err error) string {
+	if err == nil {
+		return ""
+	}
+	// A batch report gives each failure its own reason.
+	var batch *tmutil.BatchError
+	if errors.As(err, &batch) {
+		return ""
+	}
+	var sshErr *tmutil.SSHError
+	if errors.As(err, &sshErr) {
+		return fmt.Sprintf("tmutil never ran on %s. Check that 'ssh %s' logs in without asking for a password (tmcli cannot answer prompts), then try again.", sshErr.Host, sshErr.Host)
+	}
+	if errors.Is(err, tmutil.ErrTimeout) {
+		return "The command ran past its time limit. Raise it under \"timeouts\" in config.json ('tmcli config edit'), or set it to \"0\" for none."
+	}
+	msg := strings.ToLower(err.Error())
+	for _, r := range remedies {
+		for _, m := range r.match {
+			if strings.Contains(msg, m) {
+				return tmutil.ToASCII(r.suggestion)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,337 @@
+//
+// saved.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SavedCommand is a fully specified command invocation pinned by the user.
+type SavedCommand struct {
+	Name string   `json:"name"`
+	ID   string   `json:"id"`
+	Args []string `json:"args"`
+}
+
+// configDir returns tmcli's configuration directory, creating it if needed.
+func configDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "tmcli")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func savedPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "saved.json"), nil
+}
+
+// LoadSaved reads the saved commands; a missing file yields an empty list.
+func LoadSaved() ([]SavedCommand, error) {
+	path, err := savedPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var saved []SavedCommand
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	return saved, nil
+}
+
+func writeSaved(saved []SavedCommand) error {
+	path, err := savedPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// AddSaved pins an invocation under name, replacing any existing entry
+// with the same name.
+func AddSaved(name, id string, args []string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	cmd := FindCommand(id)
+	if cmd == nil {
+		return fmt.Errorf("unknown command: %s", id)
+	}
+	if cmd.Execute == nil || cmd.IsMonitor || cmd.IsBrowser || cmd.IsDeleter {
+		return fmt.Errorf("%s opens an interactive screen, so it cannot be saved", cmd.ID)
+	}
+	id = cmd.ID
+	saved, err := LoadSaved()
+	if err != nil {
+		return err
+	}
+	entry := SavedCommand{Name: name, ID: id, Args: args}
+	for i := range saved {
+		if saved[i].Name == name {
+			saved[i] = entry
+			return writeSaved(saved)
+		}
+	}
+	return writeSaved(append(saved, entry))
+}
+
+// RemoveSaved deletes the saved command with the given name.
+func RemoveSaved(name string) error {
+	saved, err := LoadSaved()
+	if err != nil {
+		return err
+	}
+	for i := range saved {
+		if saved[i].Name == name {
+			return writeSaved(append(saved[:i], saved[i+1:]...))
+		}
+	}
+	return fmt.Errorf("no saved command named %q", name)
+}
+
+// String renders the invocation as it would be typed on the command line.
+func (s SavedCommand) String() string {
+	return tmutil.ShellJoin(append([]string{"tmcli", s.ID}, s.Args...))
+}
+
+// SavedCLI implements `tmcli saved`:
+//
+//	tmcli saved                          list saved commands
+//	tmcli saved add <name> <command> ... save an invocation
+//	tmcli saved rm <name>                remove a saved command
+//	tmcli saved run <name>               run a saved command
+func SavedCLI(args []string) (string, error) {
+	if len(args) == 0 || args[0] == "list" {
+		saved, err := LoadSaved()
+		if err != nil {
+			return "", err
+		}
+		if len(saved) == 0 {
+			return "No saved commands. Add one with: tmcli saved add <name> <command> [args...]", nil
+		}
+		var b strings.Builder
+		for _, s := range saved {
+			fmt.Fprintf(&b, "%-20s %s\n", s.Name, s)
+		}
+		return strings.TrimSuffix(b.String(), "\n"), nil
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) < 3 {
+			return "", fmt.Errorf("usage: tmcli saved add <name> <command> [args...]")
+		}
+		if err := AddSaved(args[1], args[2], args[3:]); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Saved %q.", args[1]), nil
+	case "rm", "remove":
+		if len(args) < 2 {
+			return "", fmt.Errorf("usage: tmcli saved rm <name>")
+		}
+		if err := RemoveSaved(args[1]); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Removed %q.", args[1]), nil
+	case "run":
+		if len(args) < 2 {
+			return "", fmt.Errorf("usage: tmcli saved run <name>")
+		}
+		saved, err := LoadSaved()
+		if err != nil {
+			return "", err
+		}
+		for _, s := range saved {
+			if s.Name == args[1] {
+				cmd := FindCommand(s.ID)
+				if cmd == nil || cmd.Execute == nil {
+					return "", fmt.Errorf("saved command %q refers to %s, which cannot be run directly", s.Name, s.ID)
+				}
+				return cmd.Execute(s.Args)
+			}
+		}
+		return "", fmt.Errorf("no saved command named %q", args[1])
+	}
+	return "", fmt.Errorf("unknown saved subcommand %q (expected list, add, rm, or run)", args[0])
+}
+
+// pinCommand is the input form used to name the last invocation when
+// pinning it from the output view.
+func pinCommand(id string, args []string) Command {
+	return Command{
+		ID:    "pin",
+		Title: "Save Command",
+		Inputs: []InputField{
+			{Label: "Name", Placeholder: "weekly-cleanup", Required: true},
+		},
+		Execute: func(in []string) (string, error) {
+			if err := AddSaved(in[0], id, args); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Saved %q: %s", in[0], SavedCommand{ID: id, Args: args}), nil
+		},
+	}
+}
+
+// --- Saved view ---
+
+func (m Model) openSaved() (Model, tea.Cmd) {
+	m.saved, m.err = LoadSaved()
+	m.savedCursor = 0
+	m.view = savedView
+	return m, nil
+}
+
+func (m Model) updateSaved(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch k := msg.String(); {
+	case k == "ctrl+c", bindings.is(k, actQuit):
+		return m, tea.Quit
+	case bindings.is(k, actBack):
+		m.err = nil
+		m.view = categoryView
+	case bindings.is(k, actUp):
+		if m.savedCursor > 0 {
+			m.savedCursor--
+		}
+	case bindings.is(k, actDown):
+		if m.savedCursor < len(m.saved)-1 {
+			m.savedCursor++
+		}
+	case bindings.is(k, actRemove):
+		if len(m.saved) > 0 {
+			if err := RemoveSaved(m.saved[m.savedCursor].Name); err != nil {
+				m.err = err
+				return m, nil
+			}
+			return m.openSaved()
+		}
+	case bindings.is(k, actSelect):
+		if len(m.saved) > 0 {
+			s := m.saved[m.savedCursor]
+			cmd := FindCommand(s.ID)
+			if cmd == nil {
+				m.err = fmt.Errorf("unknown command: %s", s.ID)
+				return m, nil
+			}
+			return m.runSaved(*cmd, s.Args)
+		}
+	}
+	return m, nil
+}
+
+// runSaved runs a saved command through the same checks as the menu: a
+// guide's question is asked, or the values reviewed, with the saved
+// arguments, and a command that changes something without either opens
+// its input form filled in, to run with enter. ReadOnly commands run at
+// once.
+func (m Model) runSaved(cmd Command, args []string) (tea.Model, tea.Cmd) {
+	if cmd.Execute == nil || cmd.IsMonitor || cmd.IsBrowser || cmd.IsDeleter || cmd.IsSetup {
+		return m.selectCommand(cmd)
+	}
+	if cmd.ReadOnly {
+		return m.execute(cmd, args)
+	}
+	asks := cmd.Guide != nil && cmd.Guide.Confirm != nil
+	if len(cmd.Inputs) > 0 {
+		var init tea.Cmd
+		m, init = m.openInput(cmd, savedView)
+		m.input = m.input.withValues(args...)
+		if !asks && !cmd.ReviewBeforeRun {
+			return m, init
+		}
+	} else if !asks {
+		return m.execute(cmd, args)
+	}
+	// Only the question: the saved arguments stand in for the steps.
+	ask := cmd
+	if asks {
+		ask.Guide = &Guide{Confirm: cmd.Guide.Confirm, ConfirmArgs: cmd.Guide.ConfirmArgs}
+		args = trimConfirmArgs(args, cmd.Guide.ConfirmArgs)
+	}
+	var init tea.Cmd
+	if cmd.ReviewBeforeRun {
+		m.guide, init = NewFormReview(ask, args)
+	} else {
+		m.guide, init = NewFormConfirm(ask, args)
+	}
+	m.guide.fromForm = len(cmd.Inputs) > 0
+	m.guide.fromSaved = !m.guide.fromForm
+	m.view = guideView
+	return m, init
+}
+
+// trimConfirmArgs removes a guide's ConfirmArgs, such as "--yes", from the
+// end of saved arguments, so they are only added back once confirmed.
+func trimConfirmArgs(args, confirm []string) []string {
+	n := len(args) - len(confirm)
+	if len(confirm) > 0 && n >= 0 && slices.Equal(args[n:], confirm) {
+		return args[:n]
+	}
+	return args
+}
+
+func (m Model) renderSaved() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitle("Saved Commands"))
+	b.WriteString("\n\n")
+
+	var list strings.Builder
+	switch {
+	case m.err != nil:
+		list.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+	case len(m.saved) == 0:
+		list.WriteString("No saved commands yet.\n\nRun a command, then press p in its output to save it.")
+	default:
+		for i, s := range m.saved {
+			prefix := "  "
+			if i == m.savedCursor {
+				prefix = "> "
+			}
+			fmt.Fprintf(&list, "%s%-20s %s\n", prefix, s.Name, helpStyle.Render(s.String()))
+		}
+	}
+	b.WriteString(frameStyle(m.height).Render(list.String()))
+
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("↑/↓: navigate • enter: run • " + bindings.help(actRemove) + ": remove • esc: back"))
+
+	return place(m.width, m.height, b.String())
+}
@@ -0,0 +1,178 @@
+//
+// serve.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"tmcli/tmutil"
+)
+
+// DefaultServeAddr is where `tmcli serve` listens unless --addr is given.
+// It is loopback-only so backup state is not exposed to the network by
+// accident.
+const DefaultServeAddr = "localhost:8080"
+
+// healthCheck is one doctor check in the /health response.
+type healthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Serve runs a read-only HTTP endpoint for dashboards and scrapers:
+//
+//	GET /status        current backup status (same fields as the monitor feed)
+//	GET /health        doctor checks; 503 when any check fails
+//	GET /destinations  configured destinations, as from tmcli destinations --json
+//	GET /metrics       Prometheus gauges
+//	GET /run/{command} a ReadOnly command's output; each ?arg= is an argument, in order
+//
+// There are deliberately no endpoints that change anything. Cancelling
+// ctx shuts the server down.
+func Serve(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           serveMux(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serveMux routes the endpoints Serve lists.
+func serveMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", serveStatus)
+	mux.HandleFunc("GET /health", serveHealth)
+	mux.HandleFunc("GET /destinations", serveDestinations)
+	mux.HandleFunc("GET /metrics", serveMetrics)
+	mux.HandleFunc("GET /run/{command}", serveRun)
+	return mux
+}
+
+func serveStatus(w http.ResponseWriter, _ *http.Request) {
+	now := time.Now()
+	info, err := tmutil.GetStatus()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, feedRecord{Time: now, Error: err.Error()})
+		return
+	}
+	rec := feedRecord{
+		Time:          now,
+		Running:       info.Running,
+		Phase:         info.Phase,
+		Destination:   info.Destination,
+		Percent:       info.Percent,
+		TimeRemaining: info.TimeRemaining,
+		BytesCopied:   info.BytesCopied,
+		TotalBytes:    info.TotalBytes,
+		FilesCopied:   info.FilesCopied,
+		TotalFiles:    info.TotalFiles,
+	}
+	if !info.StartedAt.IsZero() {
+		started := info.StartedAt
+		rec.StartedAt = &started
+	}
+	if info.TimeRemaining > 0 {
+		eta := now.Add(time.Duration(info.TimeRemaining) * time.Second)
+		rec.ETA = &eta
+	}
+	writeJSON(w, http.StatusOK, rec)
+}
+
+func serveHealth(w http.ResponseWriter, _ *http.Request) {
+	checks := tmutil.Diagnose()
+	resp := struct {
+		Status string        `json:"status"`
+		Checks []healthCheck `json:"checks"`
+	}{Status: tmutil.Worst(checks).String()}
+	for _, c := range checks {
+		resp.Checks = append(resp.Checks, healthCheck{Name: c.Name, Status: c.Status.String(), Detail: c.Detail})
+	}
+	code := http.StatusOK
+	if tmutil.Worst(checks) == tmutil.CheckFail {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, resp)
+}
+
+func serveDestinations(w http.ResponseWriter, _ *http.Request) {
+	records, err := tmutil.ListDestinationRecords()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// runResult is the /run response.
+type runResult struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+	Error   string `json:"error,omitempty"`
+}
+
+// serveRun runs a ReadOnly command with the request's arg parameters. The
+// monitor and browser are interactive, so they cannot be run this way, and
+// nor can arguments that read through backups or a volume: any client could
+// start hours of disk reads that nothing stops.
+func serveRun(w http.ResponseWriter, r *http.Request) {
+	cmd := FindCommand(r.PathValue("command"))
+	if cmd == nil || !cmd.ReadOnly || cmd.Execute == nil || cmd.IsMonitor {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": fmt.Sprintf("%s is not a read-only command serve can run", r.PathValue("command"))})
+		return
+	}
+	args := r.URL.Query()["arg"]
+	if cmd.Walks != nil && cmd.Walks(args) {
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": fmt.Sprintf("%s reads through backups or a whole volume and can take hours; run it with tmcli instead", cmd.ID)})
+		return
+	}
+	output, err := cmd.Execute(args)
+	res := runResult{Command: cmd.ID, Output: output}
+	if err != nil {
+		res.Error = err.Error()
+		writeJSON(w, http.StatusInternalServerError, res)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(redactWriter{w}).Encode(v)
+}
+
+// redactWriter passes each write through tmutil.Redact. Writes must hold
+// whole records, as json.Encoder's do.
+type redactWriter struct {
+	w io.Writer
+}
+
+func (r redactWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, tmutil.Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,88 @@
+//
+// serve_test.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// useFakeTmutil makes tmutil replay the tmutil package's testdata, with no
+// fixture variant and no user configuration.
+func useFakeTmutil(t *testing.T) {
+	t.Helper()
+	fake, err := filepath.Abs(filepath.Join("..", "tmutil", "testdata", "fake-tmutil"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TMCLI_TMUTIL", fake)
+	t.Setenv("TMCLI_FIXTURE", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+}
+
+// TestServeRun checks which commands /run runs: read-only ones, but not
+// the interactive ones (404), anything that changes Time Machine (404) or
+// arguments that read through backups or a volume (403).
+func TestServeRun(t *testing.T) {
+	useFakeTmutil(t)
+
+	tests := []struct {
+		method, url string
+		code        int
+	}{
+		{"GET", "/run/version", http.StatusOK},
+		{"GET", "/run/latestbackup", http.StatusOK},
+		{"GET", "/run/backups", http.StatusOK}, // an alias
+		{"GET", "/run/recent?arg=5", http.StatusOK},
+
+		{"GET", "/run/nosuchcommand", http.StatusNotFound},
+		{"GET", "/run/start", http.StatusNotFound},
+		{"GET", "/run/stop", http.StatusNotFound},
+		{"GET", "/run/deleteinprogress?arg=/tmp", http.StatusNotFound},
+		{"GET", "/run/rotate", http.StatusNotFound},
+		{"GET", "/run/monitor", http.StatusNotFound},
+		{"GET", "/run/browse", http.StatusNotFound},
+
+		{"GET", "/run/compare", http.StatusForbidden},
+		{"GET", "/run/findfile?arg=notes.txt", http.StatusForbidden},
+		{"GET", "/run/verifylatest", http.StatusForbidden},
+		{"GET", "/run/recent?arg=5&arg=--sizes", http.StatusForbidden},
+		{"GET", "/run/recent?arg=5&arg=yes", http.StatusForbidden},
+		{"GET", "/run/diskpressure?arg=--measure", http.StatusForbidden},
+
+		{"POST", "/run/version", http.StatusMethodNotAllowed},
+	}
+	mux := serveMux()
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, nil))
+		if rec.Code != tt.code {
+			t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.url, rec.Code, tt.code, rec.Body)
+		}
+	}
+}
+
+func TestServeRunOutput(t *testing.T) {
+	useFakeTmutil(t)
+
+	rec := httptest.NewRecorder()
+	serveMux().ServeHTTP(rec, httptest.NewRequest("GET", "/run/version", nil))
+	var res runResult
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Command != "version" || res.Output != "tmutil version 4.0.0 (fake)" || res.Error != "" {
+		t.Errorf("/run/version = %+v", res)
+	}
+}
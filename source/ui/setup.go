@@ -0,0 +1,171 @@
+//
+// setup.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type setupState int
+
+const (
+	setupOffer setupState = iota // first run: ask whether to run setup
+	setupPick                    // choose a mounted volume
+)
+
+// setupNeededMsg reports that no backup destination is configured.
+type setupNeededMsg struct{}
+
+// setupChosenMsg carries the volume picked in the setup wizard.
+type setupChosenMsg struct {
+	volume string
+}
+
+// setupExitMsg signals that the user left the setup wizard.
+type setupExitMsg struct{}
+
+// checkDestination reports setupNeededMsg when no destination is configured.
+func checkDestination() tea.Msg {
+	dests, err := tmutil.ListDestinations()
+	if err == nil && len(dests) == 0 {
+		return setupNeededMsg{}
+	}
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "no destinations") {
+		return setupNeededMsg{}
+	}
+	return nil
+}
+
+// SetupModel is the first step of the setup wizard: it offers setup on a
+// fresh system and lets the user pick a mounted volume. The remaining
+// options are collected with the regular input form.
+type SetupModel struct {
+	state   setupState
+	volumes []string
+	cursor  int
+	width   int
+	height  int
+}
+
+// NewSetupModel creates the wizard, starting with the first-run offer when
+// offer is true and with the volume picker otherwise.
+func NewSetupModel(offer bool) SetupModel {
+	m := SetupModel{state: setupPick}
+	if offer {
+		m.state = setupOffer
+	}
+	m.volumes, _ = filepath.Glob("/Volumes/*")
+	return m
+}
+
+// Update handles key events.
+func (m SetupModel) Update(msg tea.Msg) (SetupModel, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	exit := func() tea.Msg { return setupExitMsg{} }
+
+	if key.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if m.state == setupOffer {
+		switch key.String() {
+		case "y", "Y", "enter":
+			m.state = setupPick
+		case "n", "N", "esc", "q":
+			return m, exit
+		}
+		return m, nil
+	}
+
+	switch k := key.String(); {
+	case bindings.is(k, actBack):
+		return m, exit
+	case bindings.is(k, actQuit):
+		return m, tea.Quit
+	case bindings.is(k, actUp):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case bindings.is(k, actDown):
+		if m.cursor < len(m.volumes)-1 {
+			m.cursor++
+		}
+	case bindings.is(k, actRefresh):
+		m.volumes, _ = filepath.Glob("/Volumes/*")
+		m.cursor = 0
+	case bindings.is(k, actSelect):
+		if len(m.volumes) > 0 {
+			vol := m.volumes[m.cursor]
+			return m, func() tea.Msg { return setupChosenMsg{volume: vol} }
+		}
+	}
+	return m, nil
+}
+
+// View renders the wizard step.
+func (m SetupModel) View() string {
+	var b strings.Builder
+	b.WriteString(headerStyle(m.height).Render("Setup"))
+	b.WriteString("\n\n")
+
+	var body strings.Builder
+	help := ""
+	if m.state == setupOffer {
+		body.WriteString("No Time Machine backup destination is configured.\n\n")
+		body.WriteString("Run the setup wizard to choose a backup disk,\n")
+		body.WriteString("set an optional quota, and enable automatic backups?")
+		help = "y/enter: start setup • n/esc: skip"
+	} else {
+		body.WriteString("Step 1 of 2: choose the backup disk\n\n")
+		if len(m.volumes) == 0 {
+			body.WriteString("No volumes are mounted under /Volumes.\nConnect a disk and press r to rescan.")
+		}
+		for i, v := range m.volumes {
+			if i == m.cursor {
+				fmt.Fprintf(&body, "> %s\n", v)
+			} else {
+				fmt.Fprintf(&body, "  %s\n", v)
+			}
+		}
+		body.WriteString("\n")
+		body.WriteString(helpStyle.Render("Encryption can only be turned on in System Settings → Time Machine."))
+		help = "↑/↓: navigate • enter: select • " + bindings.key(actRefresh) + ": rescan • esc: cancel"
+	}
+	b.WriteString(frameStyle(m.height).Render(body.String()))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(help))
+
+	return place(m.width, m.height, b.String())
+}
+
+// setupOptionsCommand is the second wizard step: the input form for the
+// optional quota, executing tmutil.Setup for the chosen volume.
+func setupOptionsCommand(volume string) Command {
+	return Command{
+		ID:           "setupoptions",
+		Title:        "Setup — " + volume,
+		RequiresRoot: true,
+		Inputs: []InputField{
+			{Label: "Quota (GB)", Placeholder: "(optional, blank for no limit)"},
+		},
+		Execute: func(args []string) (string, error) {
+			return tmutil.Setup(append([]string{volume}, args...))
+		},
+	}
+}
@@ -0,0 +1,52 @@
+//
+// sizesort.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+// sizeOrder is how the output view orders the rows of a command with Sized.
+type sizeOrder int
+
+const (
+	sizeListed   sizeOrder = iota // as the command listed them
+	sizeLargest                   // largest first
+	sizeSmallest                  // smallest first
+)
+
+// next is the order z switches to: largest first, then back and forth
+// between smallest and largest.
+func (o sizeOrder) next() sizeOrder {
+	if o == sizeLargest {
+		return sizeSmallest
+	}
+	return sizeLargest
+}
+
+func (o sizeOrder) String() string {
+	switch o {
+	case sizeLargest:
+		return "largest first"
+	case sizeSmallest:
+		return "smallest first"
+	}
+	return "as listed"
+}
+
+// sortBySize re-sorts the sized rows of the output into the next order,
+// keeping the lines around them, and moves the line cursor back to the
+// first path.
+func (m Model) sortBySize() Model {
+	m.sizeOrder = m.sizeOrder.next()
+	m.output = m.sized.SortedBySize(m.sizeOrder == sizeLargest).String()
+	m.report = m.output
+	m.scrollOffset, m.following = 0, false
+	m = m.resetLineCursor()
+	m.outputNote = "Sorted by size, " + m.sizeOrder.String() + "."
+	return m
+}
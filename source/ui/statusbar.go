@@ -0,0 +1,96 @@
+//
+// statusbar.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusBarInterval is how often the status bar asks tmutil for the backup
+// state. It is slow on purpose: the bar is a glance, the monitor is live.
+const statusBarInterval = 15 * time.Second
+
+// statusBarMsg carries the status bar's new text; "" hides the bar.
+type statusBarMsg struct {
+	text string
+}
+
+// statusBarTickMsg triggers the next status bar poll.
+type statusBarTickMsg struct{}
+
+// pollStatusBar reads the backup state for the status bar.
+func pollStatusBar() tea.Msg {
+	return statusBarMsg{text: statusBarText(time.Now())}
+}
+
+// scheduleStatusBar polls the status bar again after statusBarInterval.
+func scheduleStatusBar() tea.Cmd {
+	return tea.Tick(statusBarInterval, func(time.Time) tea.Msg { return statusBarTickMsg{} })
+}
+
+// statusBarText describes the backup state in a few words, e.g. "Backup
+// running 42%" or "Idle, last backup 2 hours ago".
+func statusBarText(now time.Time) string {
+	info, err := tmutil.GetStatus()
+	if err != nil {
+		return ""
+	}
+	if info.Running {
+		if info.HasProgress && info.Percent > 0 {
+			return fmt.Sprintf("Backup running %.0f%%", info.Percent*100)
+		}
+		if info.Phase != "" {
+			return "Backup running: " + info.Phase
+		}
+		return "Backup running"
+	}
+	latest, err := tmutil.LatestBackup()
+	if err != nil || strings.TrimSpace(latest) == "" {
+		return "Idle, no completed backup"
+	}
+	t, err := tmutil.BackupDate(strings.TrimSpace(latest))
+	if err != nil {
+		return "Idle"
+	}
+	return "Idle, last backup " + tmutil.FormatRelative(t, now)
+}
+
+// withStatusBar puts the status bar on its own line above a view's help
+// line, its last non-blank line. A blank line of the padding around the
+// view makes room, so the view keeps its height.
+func withStatusBar(view, bar string, width int) string {
+	if bar == "" {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	last := len(lines) - 1
+	for last > 0 && strings.TrimSpace(lines[last]) == "" {
+		last--
+	}
+	line := helpStyle.Render("• " + bar)
+	if width > 0 {
+		line = lipgloss.PlaceHorizontal(width, lipgloss.Center, line)
+	}
+	lines = append(lines[:last], append([]string{line}, lines[last:]...)...)
+	if strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	} else if n := len(lines) - 1; n > last+1 && strings.TrimSpace(lines[n]) == "" {
+		lines = lines[:n]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,371 @@
+//
+// stream.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"tmcli/tmutil"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// streamLineMsg carries one line of output from a streaming command.
+type streamLineMsg struct {
+	gen  int
+	line string
+}
+
+// streamDoneMsg reports that a streaming command finished.
+type streamDoneMsg struct {
+	gen    int
+	output string
+	sized  tmutil.SizedList // from Sized; output is its String
+	err    error
+}
+
+// streamTotalMsg carries the expected number of progress lines.
+type streamTotalMsg struct {
+	gen   int
+	total int
+}
+
+// newSpinner returns the spinner shown while a command runs.
+func newSpinner() spinner.Model {
+	if tmutil.ASCII() {
+		return spinner.New(spinner.WithSpinner(spinner.Line), spinner.WithStyle(progressFullStyle))
+	}
+	return spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(progressFullStyle))
+}
+
+// waitStream returns a command that delivers the next message from ch; a
+// closed channel delivers nothing.
+func waitStream(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// startStream runs cmd.Stream in the background and switches to the
+// progress view, which shows a spinner and a running line count until the
+// command finishes and its result is shown in the output view. Unless the
+// command streams status lines, the lines themselves are shown as they
+// arrive, following the newest one until the user scrolls up.
+func (m Model) startStream(cmd Command, args []string) (Model, tea.Cmd) {
+	ch := make(chan tea.Msg, 64)
+	gen := m.runGen
+	go func() {
+		defer close(ch)
+		if cmd.StreamTotal != nil {
+			ch <- streamTotalMsg{gen: gen, total: cmd.StreamTotal(args)}
+		}
+		progress := func(line string) {
+			ch <- streamLineMsg{gen: gen, line: line}
+		}
+		if cmd.Sized != nil {
+			list, err := cmd.Sized(args, progress)
+			ch <- streamDoneMsg{gen: gen, output: list.String(), sized: list, err: err}
+			return
+		}
+		output, err := cmd.Stream(args, progress)
+		ch <- streamDoneMsg{gen: gen, output: output, err: err}
+	}()
+
+	m.stream = ch
+	m.streamTitle = cmd.Title
+	m.streamStatus = cmd.StreamStatus
+	m.streamCount = 0
+	m.streamLast = ""
+	m.streamLines = nil
+	m.scrollOffset, m.following = 0, true
+	m.streamTotal = 0
+	m.streamStart = time.Now()
+	m.spinner = newSpinner()
+	m.view = streamView
+	return m, tea.Batch(waitStream(ch), m.spinner.Tick)
+}
+
+func (m Model) updateStream(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			if m.stopping {
+				// Stop waiting for a command slow to wind down.
+				return m.cancelRun(), nil
+			}
+			return m.stopStream(), nil
+		}
+		if m.streamStatus {
+			break
+		}
+		switch k := msg.String(); {
+		case bindings.is(k, actUp):
+			m = m.scrollOutput(-1, len(m.streamLines))
+		case bindings.is(k, actDown):
+			m = m.scrollOutput(1, len(m.streamLines))
+		case bindings.is(k, actPageUp):
+			m = m.scrollOutput(-m.outputPageSize(), len(m.streamLines))
+		case bindings.is(k, actPageDown):
+			m = m.scrollOutput(m.outputPageSize(), len(m.streamLines))
+		}
+	case streamTotalMsg:
+		if msg.gen != m.runGen {
+			return m, nil
+		}
+		m.streamTotal = msg.total
+		return m, waitStream(m.stream)
+	case streamLineMsg:
+		if msg.gen != m.runGen {
+			return m, nil
+		}
+		if strings.TrimSpace(msg.line) != "" {
+			m.streamCount++
+			m.streamLast = msg.line
+		}
+		if !m.streamStatus {
+			m.streamLines = append(m.streamLines, msg.line)
+		}
+		return m, waitStream(m.stream)
+	case streamDoneMsg:
+		if msg.gen != m.runGen {
+			return m, nil
+		}
+		stopping := m.stopping
+		m = m.endRun()
+		m.stream, m.stopping = nil, false
+		if partial := m.streamPartial(msg.err); partial != "" {
+			return m.showPartial(partial, msg.err), nil
+		}
+		if stopping && errors.Is(msg.err, tmutil.ErrCancelled) {
+			// It printed nothing before it stopped.
+			m.streamLines = nil
+			return m.cancelled(), nil
+		}
+		m.output = msg.output
+		m.sized, m.sizeOrder = msg.sized, sizeListed
+		m.err = msg.err
+		if m.streamStatus {
+			m.scrollOffset, m.following = 0, false
+		}
+		// Otherwise the result keeps the scroll position: at the end while
+		// following, or where the user scrolled to.
+		m.streamLines = nil
+		m.view = outputView
+		m = m.resetLineCursor()
+		return m, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m Model) renderStream() string {
+	if !m.streamStatus {
+		return m.renderLiveStream()
+	}
+	var b strings.Builder
+
+	b.WriteString(m.renderTitle(m.streamTitle))
+	b.WriteString("\n\n")
+
+	var body strings.Builder
+	if m.streamStatus {
+		// Status lines carry their own counts, e.g. "Sizing 3 of 12 (16%)".
+		fmt.Fprintf(&body, "%s %s", m.spinner.View(), m.streamState())
+	} else {
+		fmt.Fprintf(&body, "%s %s %d file(s) processed", m.spinner.View(), m.streamState(), m.streamCount)
+		if m.streamTotal > 0 {
+			fmt.Fprintf(&body, " of ~%d", m.streamTotal)
+		}
+	}
+	body.WriteString("\n")
+	elapsed := time.Since(m.streamStart)
+	fmt.Fprintf(&body, "Elapsed: %s", tmutil.FormatDuration(elapsed.Round(time.Second)))
+	if eta, ok := m.streamETA(elapsed); ok {
+		fmt.Fprintf(&body, " • ETA: %s", eta)
+	}
+	body.WriteString("\n\n")
+	last := m.streamLast
+	if max := m.width - 12; max > 0 && len(last) > max {
+		last = "…" + last[len(last)-max+1:]
+	}
+	body.WriteString(helpStyle.Render(last))
+	b.WriteString(frameStyle(m.height).Render(body.String()))
+
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("please wait • " + m.streamCancelHint()))
+
+	return place(m.width, m.height, b.String())
+}
+
+// streamState is the stream's status word: running, or stopping once it
+// was cancelled.
+func (m Model) streamState() string {
+	if m.stopping {
+		return "Stopping..."
+	}
+	return "Running..."
+}
+
+func (m Model) streamCancelHint() string {
+	if m.stopping {
+		return "esc/ctrl+c: stop waiting"
+	}
+	return "esc/ctrl+c: cancel"
+}
+
+// stopStream cancels a streaming command, which kills the tmutil process
+// group it started, and waits for the command to return, so the output
+// it printed before that can be kept; see showPartial.
+func (m Model) stopStream() Model {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.stopping = true
+	return m
+}
+
+// streamPartial returns what a stream that was stopped, by esc or its
+// timeout, printed before that: the lines shown as it ran or, for status
+// streams, the output the command kept. It is "" for other results.
+func (m Model) streamPartial(err error) string {
+	if !errors.Is(err, tmutil.ErrCancelled) {
+		return ""
+	}
+	if !m.streamStatus && len(m.streamLines) > 0 {
+		return strings.TrimSpace(strings.Join(m.streamLines, "\n"))
+	}
+	partial, _ := tmutil.PartialOutput(err)
+	return partial
+}
+
+// showPartial shows the output of a command that was stopped partway, by
+// esc or its timeout, marked as incomplete.
+func (m Model) showPartial(partial string, err error) Model {
+	m.stopped = tmutil.ErrCancelled
+	if errors.Is(err, tmutil.ErrTimeout) {
+		m.stopped = tmutil.ErrTimeout
+	}
+	m.stoppedAfter = time.Since(m.runStart).Round(time.Second)
+	m.output, m.report, m.rawOutput, m.showRaw = partial, partial, "", false
+	m.sized = tmutil.SizedList{}
+	m.err = nil
+	m.streamLines = nil
+	m.view = outputView
+	return m.resetLineCursor()
+}
+
+// stoppedNote labels partial output, saying why the command stopped.
+func (m Model) stoppedNote() string {
+	why := "was cancelled"
+	if errors.Is(m.stopped, tmutil.ErrTimeout) {
+		why = "timed out"
+	}
+	return warnStyle.Render(fmt.Sprintf("Incomplete: %s %s after %s; this is the output before that.",
+		m.lastCmd.Title, why, tmutil.FormatDuration(m.stoppedAfter)))
+}
+
+// renderLiveStream shows the lines streamed so far in the output view's
+// frame, on the last page while following, with the progress below.
+func (m Model) renderLiveStream() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitle(m.streamTitle))
+	b.WriteString("\n\n")
+
+	lines := m.streamLines
+	if len(lines) == 0 {
+		lines = []string{helpStyle.Render("Waiting for output…")}
+	}
+	start := m.outputOffset(len(lines))
+	end := min(start+m.outputPageSize(), len(lines))
+	b.WriteString(frameStyle(m.height).Render(strings.Join(fitLines(lines[start:end], m.width-frameOverhead(m.height)), "\n")))
+	b.WriteString("\n\n")
+
+	status := fmt.Sprintf("%s %s %d file(s) processed", m.spinner.View(), m.streamState(), m.streamCount)
+	if m.streamTotal > 0 {
+		status += fmt.Sprintf(" of ~%d", m.streamTotal)
+	}
+	elapsed := time.Since(m.streamStart)
+	status += " • Elapsed: " + tmutil.FormatDuration(elapsed.Round(time.Second))
+	if eta, ok := m.streamETA(elapsed); ok {
+		status += " • ETA: " + eta
+	}
+	b.WriteString(status + "\n")
+
+	keys := m.streamCancelHint()
+	if len(m.streamLines) > m.outputPageSize() {
+		follow := "following"
+		if !m.following {
+			follow = "paused, scroll to the end to follow"
+		}
+		keys = fmt.Sprintf("↑/↓: scroll • pgup/pgdn: page • lines %d–%d of %d, %s • %s", start+1, end, len(lines), follow, keys)
+	}
+	b.WriteString(helpStyle.Render(keys))
+
+	return place(m.width, m.height, b.String())
+}
+
+// streamETA estimates the time remaining from the rate of progress lines so
+// far; it needs a known total and a few seconds of progress.
+func (m Model) streamETA(elapsed time.Duration) (string, bool) {
+	if m.streamTotal == 0 || m.streamCount == 0 || elapsed < 3*time.Second {
+		return "", false
+	}
+	if m.streamCount >= m.streamTotal {
+		return "finishing…", true
+	}
+	perLine := elapsed / time.Duration(m.streamCount)
+	remaining := perLine * time.Duration(m.streamTotal-m.streamCount)
+	return "~" + tmutil.FormatDuration(remaining.Round(time.Second)), true
+}
+
+// updateRunning handles the running view shown while a non-streaming
+// command executes; the command's result moves on to the output view.
+func (m Model) updateRunning(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			return m.cancelRun(), nil
+		}
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m Model) renderRunning() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitle(m.lastCmd.Title))
+	b.WriteString("\n\n")
+
+	body := fmt.Sprintf("%s Running...", m.spinner.View())
+	if elapsed := time.Since(m.runStart); elapsed >= time.Second {
+		body += "\n" + fmt.Sprintf("Elapsed: %s", tmutil.FormatDuration(elapsed.Round(time.Second)))
+	}
+	b.WriteString(frameStyle(m.height).Render(body))
+
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("please wait • esc/ctrl+c: cancel"))
+
+	return place(m.width, m.height, b.String())
+}
@@ -38,6 +38,10 @@ var (
 				Foreground(colorOrange).
 				Bold(true)
 
+	cursorLineStyle = lipgloss.NewStyle().
+			Foreground(colorOrange).
+			Bold(true)
+
 	helpStyle = lipgloss.NewStyle().
 			Foreground(colorGray)
 
@@ -54,6 +58,10 @@ var (
 			Foreground(colorGreen).
 			Bold(true)
 
+	warnStyle = lipgloss.NewStyle().
+			Foreground(colorOrange).
+			Bold(true)
+
 	progressBarWidth = 40
 
 	progressFullStyle = lipgloss.NewStyle().
@@ -77,3 +85,79 @@ var (
 			Foreground(colorOrange).
 			Bold(true)
 )
+
+// CompactHeight is the terminal height below which the TUI drops the
+// centering and borders in favor of a top-aligned, minimal layout.
+const CompactHeight = 30
+
+// compactSaved is the number of lines the compact layout saves: the title
+// and output borders and the output padding.
+const compactSaved = 6
+
+var (
+	compactTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(colorOrange)
+
+	compactOutputStyle = lipgloss.NewStyle().
+				PaddingLeft(1)
+)
+
+// forceCompact is set by --compact to use the compact layout at any size.
+var forceCompact bool
+
+// SetCompact forces the compact layout regardless of terminal height.
+func SetCompact(on bool) {
+	forceCompact = on
+}
+
+// isCompact reports whether a view of the given height uses the compact
+// layout. A height of 0 means the size is not known yet.
+func isCompact(height int) bool {
+	return forceCompact || (height > 0 && height < CompactHeight)
+}
+
+// place positions a rendered view: centered normally, top-left when compact.
+func place(width, height int, view string) string {
+	if isCompact(height) {
+		return view
+	}
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, view)
+}
+
+// headerStyle is the style for view titles at the given height.
+func headerStyle(height int) lipgloss.Style {
+	if isCompact(height) {
+		return compactTitleStyle
+	}
+	return titleStyle
+}
+
+// frameStyle is the style for the main body of a view at the given height.
+func frameStyle(height int) lipgloss.Style {
+	if isCompact(height) {
+		return compactOutputStyle
+	}
+	return outputStyle
+}
+
+// frameOverhead is the width the output frame takes from the terminal:
+// the border and horizontal padding, or the compact layout's left padding.
+func frameOverhead(height int) int {
+	if isCompact(height) {
+		return 1
+	}
+	return 6
+}
+
+// pageSize returns the number of list or output lines that fit in height
+// after overhead lines of chrome (counted for the full layout), at least 5.
+func pageSize(height, overhead int) int {
+	if isCompact(height) {
+		overhead -= compactSaved
+	}
+	if ps := height - overhead; ps > 5 {
+		return ps
+	}
+	return 5
+}
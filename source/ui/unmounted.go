@@ -0,0 +1,87 @@
+//
+// unmounted.go
+// ~~~~~~~~~~~~~~~~~~~~~
+//
+// Copyright (c) 2004-2026 Metasystems Technologies Inc. (MTI)
+//
+// Licensed under the MIT License. See LICENSE file in the project root
+// for full license text.
+//
+
+package ui
+
+import (
+	"strings"
+
+	"tmcli/tmutil"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// unmountedRun is a command held back because it reads backups and no
+// backup destination is mounted.
+type unmountedRun struct {
+	cmd     Command
+	args    []string
+	warning string    // from tmutil.UnmountedWarning
+	back    viewState // where esc returns
+}
+
+// checkMounted holds back a command with WhenUnmounted while no backup
+// destination is mounted, saying why it would find nothing. It reports
+// whether it did.
+func (m Model) checkMounted(cmd Command, args []string) (Model, bool) {
+	if cmd.WhenUnmounted == "" {
+		return m, false
+	}
+	warning := tmutil.UnmountedWarning()
+	if warning == "" {
+		return m, false
+	}
+	back := commandView
+	switch {
+	case m.view == inputView || m.view == guideView && m.guide.fromForm:
+		back = inputView
+	case m.view == guideView && m.guide.fromSaved:
+		back = savedView
+	}
+	m.unmounted = unmountedRun{cmd: cmd, args: args, warning: warning, back: back}
+	m.view = unmountedView
+	return m, true
+}
+
+func (m Model) updateUnmounted(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch k := msg.String(); {
+	case k == "a":
+		if m.unmounted.cmd.IsBrowser {
+			return m.openBrowser()
+		}
+		if held, busy := m.checkBusy(m.unmounted.cmd, m.unmounted.args); busy {
+			return held, nil
+		}
+		return m.launch(m.unmounted.cmd, m.unmounted.args)
+	case k == "esc", bindings.is(k, actBack):
+		m.view = m.unmounted.back
+		if m.view == inputView {
+			return m, m.input.Init()
+		}
+		return m, nil
+	case k == "ctrl+c", bindings.is(k, actQuit):
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) renderUnmounted() string {
+	var b strings.Builder
+	b.WriteString(m.renderTitle(m.unmounted.cmd.Title))
+	b.WriteString("\n\n")
+
+	body := wordWrap(m.unmounted.warning, 60) + "\n\n" +
+		wordWrap(m.unmounted.cmd.WhenUnmounted+" Connect the backup disk, or see Destinations → Destination Info, and try again.", 60)
+	b.WriteString(frameStyle(m.height).Render(body))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("a: " + strings.ToLower(m.unmounted.cmd.Title) + " anyway • " + bindings.backQuitHelp()))
+
+	return place(m.width, m.height, b.String())
+}